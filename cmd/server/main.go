@@ -13,10 +13,15 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/alarm-agent/internal/adapters/http"
+	"github.com/alarm-agent/internal/adapters/llm"
 	"github.com/alarm-agent/internal/adapters/repo"
+	"github.com/alarm-agent/internal/adapters/telegram"
+	"github.com/alarm-agent/internal/adapters/templates"
 	"github.com/alarm-agent/internal/adapters/whatsapp"
 	"github.com/alarm-agent/internal/config"
+	"github.com/alarm-agent/internal/domain"
 	"github.com/alarm-agent/internal/infra"
+	"github.com/alarm-agent/internal/ports"
 	"github.com/alarm-agent/internal/usecase"
 	"github.com/alarm-agent/internal/workers"
 )
@@ -63,38 +68,104 @@ func run() error {
 
 	// LLM client is now created per-request from database configuration
 
-	whatsappSender := whatsapp.NewInfobipClient(
-		cfg.Infobip.BaseURL,
-		cfg.Infobip.APIKey,
-		cfg.Infobip.WhatsAppSender,
-	)
+	providerClient, webhookVerifier, inboundParser := newWhatsAppProvider(cfg)
+	senders := []ports.ChannelSender{providerClient}
+
+	var telegramClient *telegram.Client
+	if cfg.Telegram.Enabled() {
+		telegramClient = telegram.NewClient(cfg.Telegram.BaseURL, cfg.Telegram.BotToken)
+		senders = append(senders, telegramClient)
+	}
 
-	webhookVerifier := whatsapp.NewInfobipWebhookVerifier(cfg.Infobip.WebhookSecret)
 	timeProvider := infra.NewRealTimeProvider()
 
-	eventUseCase := usecase.NewEventUseCase(repos)
+	messages, err := templates.NewRenderer(repos.MessageTemplate(), cfg.Messages.TemplatesDir)
+	if err != nil {
+		return fmt.Errorf("failed to load message templates: %w", err)
+	}
+
+	// Every outbound message goes through the outbox; only the dispatcher
+	// talks to the WhatsApp provider and the Telegram bot.
+	outboxDispatcher := workers.NewOutboxDispatcher(
+		repos,
+		senders,
+		timeProvider,
+		logger,
+		cfg.Outbox.TickInterval,
+		cfg.Worker.InstanceID,
+		cfg.Worker.LeaseDuration,
+		domain.RetryPolicy{
+			MaxAttempts: cfg.Outbox.MaxAttempts,
+			BaseBackoff: cfg.Outbox.BaseBackoff,
+			MaxBackoff:  cfg.Outbox.MaxBackoff,
+		},
+	)
+	whatsappSender := whatsapp.NewOutboxSender(repos.OutboundMessage(), timeProvider, outboxDispatcher.Wake)
+
+	participantUseCase := usecase.NewParticipantUseCase(repos, whatsappSender, messages, timeProvider, logger)
+	eventUseCase := usecase.NewEventUseCase(repos, participantUseCase, messages, timeProvider)
 	messageUseCase := usecase.NewMessageUseCase(
 		repos,
 		whatsappSender,
+		messages,
 		eventUseCase,
+		participantUseCase,
 		"America/Sao_Paulo", // Default timezone - users can change this in their profile
-		cfg,
+		llm.NewDBClientFactory(repos.LLMConfig(), cfg),
+		timeProvider,
 	)
+	identityUseCase := usecase.NewIdentityUseCase(repos, whatsappSender, messages)
 
 	reminderWorker := workers.NewReminderWorker(
 		repos,
-		whatsappSender,
+		repo.NewEventChangeListener(cfg.Database.DSN),
+		messages,
 		timeProvider,
 		logger,
-		cfg.Worker.ReminderTickInterval,
+		cfg.Worker.ReminderResyncInterval,
+		cfg.Worker.InstanceID,
+		cfg.Worker.LeaseDuration,
+		domain.CatchUpPolicy{
+			Mode:  domain.CatchUpMode(cfg.Worker.CatchUpMode),
+			Grace: cfg.Worker.CatchUpGrace,
+		},
+		cfg.WhatsApp.Templates,
 	)
 
+	digestWorker := workers.NewDigestWorker(
+		repos,
+		eventUseCase,
+		messages,
+		timeProvider,
+		logger,
+		cfg.Worker.DigestTickInterval,
+	)
+
+	// Telegram updates come either through the webhook or a poller.
+	var telegramVerifier ports.WhatsAppWebhookVerifier
+	var telegramParser whatsapp.InboundParser
+	var telegramPoller *telegram.Poller
+	if telegramClient != nil {
+		if cfg.Telegram.Mode == config.TelegramModePolling {
+			telegramPoller = telegram.NewPoller(telegramClient, messageUseCase.ProcessInboundMessage, cfg.Telegram.PollTimeout, logger)
+		} else {
+			telegramVerifier = telegram.NewWebhookVerifier(cfg.Telegram.WebhookSecret)
+			telegramParser = telegram.NewParser()
+		}
+	}
+
 	server := http.NewServer(
 		cfg,
 		repos,
 		messageUseCase,
 		eventUseCase,
+		identityUseCase,
 		webhookVerifier,
+		inboundParser,
+		telegramVerifier,
+		telegramParser,
+		messages,
+		timeProvider,
 		logger,
 	)
 
@@ -121,6 +192,35 @@ func run() error {
 		}
 	}()
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := digestWorker.Start(ctx); err != nil && err != context.Canceled {
+			logger.Error("Digest worker error", zap.Error(err))
+			cancel()
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := outboxDispatcher.Start(ctx); err != nil && err != context.Canceled {
+			logger.Error("Outbox dispatcher error", zap.Error(err))
+			cancel()
+		}
+	}()
+
+	if telegramPoller != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := telegramPoller.Start(ctx); err != nil && err != context.Canceled {
+				logger.Error("Telegram poller error", zap.Error(err))
+				cancel()
+			}
+		}()
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
@@ -137,6 +237,11 @@ func run() error {
 	defer shutdownCancel()
 
 	reminderWorker.Stop()
+	digestWorker.Stop()
+	outboxDispatcher.Stop()
+	if telegramPoller != nil {
+		telegramPoller.Stop()
+	}
 
 	if err := server.Stop(shutdownCtx); err != nil {
 		logger.Error("Error shutting down HTTP server", zap.Error(err))
@@ -151,3 +256,16 @@ func run() error {
 
 // getLLMAPIKey function removed - API keys are now stored in database
 
+// newWhatsAppProvider builds the client, webhook verifier and payload parser
+// of the configured WhatsApp provider.
+func newWhatsAppProvider(cfg *config.Config) (ports.ChannelSender, ports.WhatsAppWebhookVerifier, whatsapp.InboundParser) {
+	if cfg.WhatsApp.Provider == config.WhatsAppProviderMeta {
+		return whatsapp.NewMetaClient(cfg.Meta.BaseURL, cfg.Meta.AccessToken, cfg.Meta.PhoneNumberID),
+			whatsapp.NewMetaWebhookVerifier(cfg.Meta.AppSecret, cfg.Meta.VerifyToken),
+			whatsapp.NewMetaParser()
+	}
+
+	return whatsapp.NewInfobipClient(cfg.Infobip.BaseURL, cfg.Infobip.APIKey, cfg.Infobip.WhatsAppSender),
+		whatsapp.NewInfobipWebhookVerifier(cfg.Infobip.WebhookSecret),
+		whatsapp.NewInfobipParser()
+}
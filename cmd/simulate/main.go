@@ -0,0 +1,234 @@
+// Command simulate replays a scripted week of WhatsApp messages against the
+// real use cases and workers on a fake clock, printing every message that
+// would be sent. The whole run happens in one database transaction that is
+// rolled back at the end, so it leaves no trace; still, point it at a
+// development database, since the workers also see the events stored there.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/alarm-agent/internal/adapters/repo"
+	"github.com/alarm-agent/internal/adapters/templates"
+	"github.com/alarm-agent/internal/adapters/whatsapp"
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/infra"
+	"github.com/alarm-agent/internal/ports"
+	"github.com/alarm-agent/internal/usecase"
+	"github.com/alarm-agent/internal/workers"
+)
+
+// instanceID names the simulator in the leases it takes.
+const instanceID = "simulator"
+
+// errRollback ends the simulation transaction without committing it.
+var errRollback = errors.New("simulation rolled back")
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	scriptPath := flag.String("script", "", "simulation script (defaults to the built-in week)")
+	dsn := flag.String("dsn", os.Getenv("POSTGRES_DSN"), "Postgres DSN of a development database")
+	verbose := flag.Bool("v", false, "log what the workers do")
+	flag.Parse()
+
+	if *dsn == "" {
+		return fmt.Errorf("a database is required: set POSTGRES_DSN or -dsn")
+	}
+
+	script, err := loadScript(*scriptPath)
+	if err != nil {
+		return err
+	}
+
+	logger := zap.NewNop()
+	if *verbose {
+		if logger, err = infra.NewLogger("development"); err != nil {
+			return fmt.Errorf("failed to create logger: %w", err)
+		}
+	}
+
+	repos, err := repo.NewPostgresRepositories(*dsn)
+	if err != nil {
+		return fmt.Errorf("failed to create repositories: %w", err)
+	}
+	defer repos.Close()
+
+	ctx := context.Background()
+	err = repos.WithTx(ctx, func(tx ports.Repositories) error {
+		if err := simulate(ctx, tx, script, logger, os.Stdout); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if errors.Is(err, errRollback) {
+		return nil
+	}
+	return err
+}
+
+// simulate wires the use cases and workers to a fake clock and steps it
+// through the script, delivering each scripted message when it is due and
+// running the workers after every step.
+func simulate(ctx context.Context, repos ports.Repositories, script *Script, logger *zap.Logger, out io.Writer) error {
+	loc, err := time.LoadLocation(script.Timezone)
+	if err != nil {
+		return fmt.Errorf("invalid script timezone: %w", err)
+	}
+
+	clock := infra.NewFakeClock(script.Start)
+	printer := &transcript{clock: clock, loc: loc, out: out}
+	llmClients := &scriptedLLM{}
+
+	messages, err := templates.NewRenderer(repos.MessageTemplate(), "")
+	if err != nil {
+		return fmt.Errorf("failed to load message templates: %w", err)
+	}
+
+	sender := whatsapp.NewOutboxSender(repos.OutboundMessage(), clock, func() {})
+	participantUseCase := usecase.NewParticipantUseCase(repos, sender, messages, clock, logger)
+	eventUseCase := usecase.NewEventUseCase(repos, participantUseCase, messages, clock)
+	messageUseCase := usecase.NewMessageUseCase(repos, sender, messages, eventUseCase, participantUseCase, script.Timezone, llmClients, clock)
+
+	reminderWorker := workers.NewReminderWorker(
+		repos,
+		nil,
+		messages,
+		clock,
+		logger,
+		script.Step(),
+		instanceID,
+		script.Step(),
+		domain.CatchUpPolicy{Mode: domain.CatchUpLate, Grace: script.Step()},
+		nil,
+	)
+	digestWorker := workers.NewDigestWorker(repos, eventUseCase, messages, clock, logger, script.Step())
+	dispatcher := workers.NewOutboxDispatcher(
+		repos,
+		[]ports.ChannelSender{printer},
+		clock,
+		logger,
+		script.Step(),
+		instanceID,
+		script.Step(),
+		domain.RetryPolicy{MaxAttempts: 1},
+	)
+
+	fmt.Fprintf(out, "Simulating %s to %s\n\n",
+		script.Start.In(loc).Format("02/01/2006 15:04"),
+		script.End().In(loc).Format("02/01/2006 15:04"),
+	)
+
+	next := 0
+	for now := clock.Now(); now.Before(script.End()); now = clock.Now() {
+		for next < len(script.Messages) && !script.Messages[next].At.After(now) {
+			message := script.Messages[next]
+			next++
+
+			printer.inbound(script.User.Number, message.Text)
+			llmClients.next = &message.LLMResponse
+			err := messageUseCase.ProcessInboundMessage(ctx, whatsapp.ParsedMessage{
+				ID:          fmt.Sprintf("simulation-%d", next),
+				Channel:     domain.ChannelWhatsApp,
+				From:        script.User.Number,
+				Timestamp:   now,
+				Type:        "text",
+				Text:        message.Text,
+				ContactName: script.User.Name,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to process message %q: %w", message.Text, err)
+			}
+		}
+
+		if err := reminderWorker.RunOnce(ctx); err != nil {
+			return err
+		}
+		if err := digestWorker.RunOnce(ctx); err != nil {
+			return err
+		}
+		if err := dispatcher.RunOnce(ctx); err != nil {
+			return err
+		}
+
+		clock.Advance(script.Step())
+	}
+
+	fmt.Fprintf(out, "%d messages sent\n", printer.sent)
+	return nil
+}
+
+// scriptedLLM answers every prompt with the interpretation scripted for the
+// message being processed.
+type scriptedLLM struct {
+	next *domain.LLMResponse
+}
+
+func (l *scriptedLLM) ForUser(ctx context.Context, userID int) (ports.LLMClient, error) {
+	return l, nil
+}
+
+func (l *scriptedLLM) Chat(ctx context.Context, systemPrompt, userMessage string) (*domain.LLMResponse, error) {
+	if l.next == nil {
+		return nil, fmt.Errorf("no scripted response")
+	}
+	response := l.next
+	l.next = nil
+	return response, nil
+}
+
+// transcript prints the conversation as the simulated clock sees it. It is
+// the WhatsApp sender of the outbox dispatcher, so it prints exactly what
+// would be delivered.
+type transcript struct {
+	clock ports.TimeProvider
+	loc   *time.Location
+	out   io.Writer
+	sent  int
+}
+
+func (t *transcript) Channel() domain.Channel {
+	return domain.ChannelWhatsApp
+}
+
+func (t *transcript) SendText(ctx context.Context, to, text string) error {
+	t.sent++
+	t.print("→", to, text)
+	return nil
+}
+
+// SendInteractive prints the options under the text, as buttons would show.
+func (t *transcript) SendInteractive(ctx context.Context, to, text string, interactive domain.Interactive) error {
+	return t.SendText(ctx, to, interactive.AsText(text))
+}
+
+// SendTemplate prints the template with its parameters.
+func (t *transcript) SendTemplate(ctx context.Context, to string, template domain.TemplateMessage) error {
+	return t.SendText(ctx, to, fmt.Sprintf("[%s] %s", template.Name, strings.Join(template.Params, " | ")))
+}
+
+func (t *transcript) inbound(from, text string) {
+	t.print("←", from, text)
+}
+
+func (t *transcript) print(direction, number, text string) {
+	fmt.Fprintf(t.out, "[%s] %s %s\n", t.clock.Now().In(t.loc).Format("Mon 02/01 15:04"), direction, number)
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(t.out, "    %s\n", line)
+	}
+	fmt.Fprintln(t.out)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/alarm-agent/internal/domain"
+)
+
+//go:embed week.json
+var defaultScript []byte
+
+// Script describes a simulation: who is talking, what they say and when, and
+// how long and how finely the clock runs.
+type Script struct {
+	Start       time.Time `json:"start"`
+	Days        int       `json:"days"`
+	StepMinutes int       `json:"step_minutes"`
+	Timezone    string    `json:"timezone"`
+	User        struct {
+		Number string `json:"number"`
+		Name   string `json:"name"`
+	} `json:"user"`
+	Messages []ScriptedMessage `json:"messages"`
+}
+
+// ScriptedMessage is an inbound WhatsApp message together with the
+// interpretation the LLM is assumed to return for it, so runs are
+// deterministic and need no LLM provider.
+type ScriptedMessage struct {
+	At   time.Time `json:"at"`
+	Text string    `json:"text"`
+	domain.LLMResponse
+}
+
+func (s *Script) End() time.Time {
+	return s.Start.AddDate(0, 0, s.Days)
+}
+
+func (s *Script) Step() time.Duration {
+	return time.Duration(s.StepMinutes) * time.Minute
+}
+
+// loadScript reads the script at path, or the built-in week when path is
+// empty.
+func loadScript(path string) (*Script, error) {
+	data := defaultScript
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("failed to read script: %w", err)
+		}
+	}
+
+	var script Script
+	if err := json.Unmarshal(data, &script); err != nil {
+		return nil, fmt.Errorf("failed to parse script: %w", err)
+	}
+
+	if script.Start.IsZero() {
+		return nil, fmt.Errorf("script start is required")
+	}
+	if script.Days <= 0 {
+		script.Days = 7
+	}
+	if script.StepMinutes <= 0 {
+		script.StepMinutes = 1
+	}
+	if script.Timezone == "" {
+		script.Timezone = "America/Sao_Paulo"
+	}
+	if script.User.Number == "" {
+		return nil, fmt.Errorf("script user number is required")
+	}
+
+	sort.SliceStable(script.Messages, func(i, j int) bool {
+		return script.Messages[i].At.Before(script.Messages[j].At)
+	})
+
+	return &script, nil
+}
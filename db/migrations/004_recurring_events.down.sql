@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_events_recurring;
+
+-- Remove recurrence columns from events table
+ALTER TABLE events DROP COLUMN IF EXISTS next_occurrence_at;
+ALTER TABLE events DROP COLUMN IF EXISTS recurrence_rule;
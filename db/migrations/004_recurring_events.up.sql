@@ -0,0 +1,6 @@
+-- Add recurrence support to events
+ALTER TABLE events ADD COLUMN recurrence_rule VARCHAR(255);
+ALTER TABLE events ADD COLUMN next_occurrence_at TIMESTAMP WITH TIME ZONE;
+
+-- Create index for the recurring events the reminder worker advances
+CREATE INDEX idx_events_recurring ON events(next_occurrence_at) WHERE recurrence_rule IS NOT NULL AND status IN ('scheduled', 'confirmed');
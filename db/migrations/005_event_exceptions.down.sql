@@ -0,0 +1,2 @@
+-- Drop event_exceptions table
+DROP TABLE IF EXISTS event_exceptions;
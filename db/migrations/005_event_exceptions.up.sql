@@ -0,0 +1,19 @@
+-- Create event_exceptions table for per-occurrence overrides of recurring events
+CREATE TABLE event_exceptions (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER REFERENCES events(id) ON DELETE CASCADE,
+    original_starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) CHECK (status IN ('scheduled', 'confirmed', 'canceled')),
+    starts_at TIMESTAMP WITH TIME ZONE,
+    title VARCHAR(500),
+    location VARCHAR(500),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(event_id, original_starts_at)
+);
+
+-- Create index for performance
+CREATE INDEX idx_event_exceptions_event_id ON event_exceptions(event_id);
+
+-- Create trigger for updating updated_at column
+CREATE TRIGGER update_event_exceptions_updated_at BEFORE UPDATE ON event_exceptions FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_events_snoozed_until;
+
+-- Remove snooze column from events table
+ALTER TABLE events DROP COLUMN IF EXISTS snoozed_until;
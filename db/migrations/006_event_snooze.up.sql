@@ -0,0 +1,5 @@
+-- Add snooze support to events
+ALTER TABLE events ADD COLUMN snoozed_until TIMESTAMP WITH TIME ZONE;
+
+-- Create index for snoozed reminders the worker must deliver
+CREATE INDEX idx_events_snoozed_until ON events(snoozed_until) WHERE snoozed_until IS NOT NULL;
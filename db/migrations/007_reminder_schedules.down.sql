@@ -0,0 +1,5 @@
+-- Remove default reminder schedule from users
+ALTER TABLE users DROP COLUMN IF EXISTS default_reminder_offsets;
+
+-- Drop event_reminders table
+DROP TABLE IF EXISTS event_reminders;
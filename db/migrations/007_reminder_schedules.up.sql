@@ -0,0 +1,14 @@
+-- Create event_reminders table for multi-stage reminder schedules
+CREATE TABLE event_reminders (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER REFERENCES events(id) ON DELETE CASCADE,
+    offset_minutes INTEGER NOT NULL CHECK (offset_minutes >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(event_id, offset_minutes)
+);
+
+-- Create index for performance
+CREATE INDEX idx_event_reminders_event_id ON event_reminders(event_id);
+
+-- Add default reminder schedule to users
+ALTER TABLE users ADD COLUMN default_reminder_offsets INTEGER[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,2 @@
+-- Drop event_participants table
+DROP TABLE IF EXISTS event_participants;
@@ -0,0 +1,4 @@
+-- Remove end time and all-day flag from events table
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_ends_after_start;
+ALTER TABLE events DROP COLUMN IF EXISTS all_day;
+ALTER TABLE events DROP COLUMN IF EXISTS ends_at;
@@ -0,0 +1,5 @@
+-- Add end time and all-day flag to events
+ALTER TABLE events ADD COLUMN ends_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE events ADD COLUMN all_day BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE events ADD CONSTRAINT events_ends_after_start CHECK (ends_at IS NULL OR ends_at > starts_at);
@@ -0,0 +1,6 @@
+-- Remove the tentative event status
+DROP INDEX IF EXISTS idx_events_tentative;
+UPDATE events SET status = 'canceled' WHERE status = 'tentative';
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_status_check;
+ALTER TABLE events ADD CONSTRAINT events_status_check
+    CHECK (status IN ('scheduled', 'confirmed', 'canceled', 'completed'));
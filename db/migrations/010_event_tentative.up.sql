@@ -0,0 +1,6 @@
+-- Allow events to be held as tentative while a scheduling conflict is unresolved
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_status_check;
+ALTER TABLE events ADD CONSTRAINT events_status_check
+    CHECK (status IN ('scheduled', 'confirmed', 'canceled', 'completed', 'tentative'));
+
+CREATE INDEX idx_events_tentative ON events(user_id, created_at) WHERE status = 'tentative';
@@ -0,0 +1,8 @@
+-- Remove agenda digest preferences from users
+DROP INDEX IF EXISTS idx_users_digests;
+ALTER TABLE users DROP COLUMN IF EXISTS last_weekly_digest_at;
+ALTER TABLE users DROP COLUMN IF EXISTS last_daily_digest_at;
+ALTER TABLE users DROP COLUMN IF EXISTS weekly_digest_time;
+ALTER TABLE users DROP COLUMN IF EXISTS weekly_digest_enabled;
+ALTER TABLE users DROP COLUMN IF EXISTS daily_digest_time;
+ALTER TABLE users DROP COLUMN IF EXISTS daily_digest_enabled;
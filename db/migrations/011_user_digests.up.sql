@@ -0,0 +1,10 @@
+-- Add daily and weekly agenda digest preferences to users
+ALTER TABLE users ADD COLUMN daily_digest_enabled BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE users ADD COLUMN daily_digest_time VARCHAR(5) NOT NULL DEFAULT '07:00';
+ALTER TABLE users ADD COLUMN weekly_digest_enabled BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE users ADD COLUMN weekly_digest_time VARCHAR(5) NOT NULL DEFAULT '19:00';
+ALTER TABLE users ADD COLUMN last_daily_digest_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN last_weekly_digest_at TIMESTAMP WITH TIME ZONE;
+
+-- Create index for the digest worker
+CREATE INDEX idx_users_digests ON users(id) WHERE daily_digest_enabled OR weekly_digest_enabled;
@@ -0,0 +1,3 @@
+-- Remove quiet hours from users and events
+ALTER TABLE events DROP COLUMN IF EXISTS ignore_quiet_hours;
+ALTER TABLE users DROP COLUMN IF EXISTS quiet_hours;
@@ -0,0 +1,3 @@
+-- Add quiet hours to users and a per-event override
+ALTER TABLE users ADD COLUMN quiet_hours JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE events ADD COLUMN ignore_quiet_hours BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,13 @@
+-- Remove the no_response event status
+DROP INDEX IF EXISTS idx_events_open_single;
+ALTER TABLE users DROP COLUMN IF EXISTS notify_no_response;
+
+DELETE FROM event_exceptions WHERE status = 'no_response';
+ALTER TABLE event_exceptions DROP CONSTRAINT IF EXISTS event_exceptions_status_check;
+ALTER TABLE event_exceptions ADD CONSTRAINT event_exceptions_status_check
+    CHECK (status IN ('scheduled', 'confirmed', 'canceled'));
+
+UPDATE events SET status = 'completed' WHERE status = 'no_response';
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_status_check;
+ALTER TABLE events ADD CONSTRAINT events_status_check
+    CHECK (status IN ('scheduled', 'confirmed', 'canceled', 'completed', 'tentative'));
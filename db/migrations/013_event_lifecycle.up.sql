@@ -0,0 +1,13 @@
+-- Track missed confirmations and let users opt out of being told about them
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_status_check;
+ALTER TABLE events ADD CONSTRAINT events_status_check
+    CHECK (status IN ('scheduled', 'confirmed', 'canceled', 'completed', 'tentative', 'no_response'));
+
+ALTER TABLE event_exceptions DROP CONSTRAINT IF EXISTS event_exceptions_status_check;
+ALTER TABLE event_exceptions ADD CONSTRAINT event_exceptions_status_check
+    CHECK (status IN ('scheduled', 'confirmed', 'canceled', 'no_response'));
+
+ALTER TABLE users ADD COLUMN notify_no_response BOOLEAN NOT NULL DEFAULT true;
+
+CREATE INDEX idx_events_open_single ON events(starts_at)
+    WHERE recurrence_rule IS NULL AND status IN ('scheduled', 'confirmed');
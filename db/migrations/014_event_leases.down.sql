@@ -0,0 +1,4 @@
+-- Remove event leases
+DROP INDEX IF EXISTS idx_events_leased_until;
+ALTER TABLE events DROP COLUMN IF EXISTS leased_until;
+ALTER TABLE events DROP COLUMN IF EXISTS leased_by;
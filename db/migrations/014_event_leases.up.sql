@@ -0,0 +1,5 @@
+-- Let worker instances lease events so a reminder is sent by a single replica
+ALTER TABLE events ADD COLUMN leased_by VARCHAR(255);
+ALTER TABLE events ADD COLUMN leased_until TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_events_leased_until ON events(leased_until) WHERE leased_until IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Drop outbound_messages table
+DROP TABLE IF EXISTS outbound_messages;
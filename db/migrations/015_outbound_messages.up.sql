@@ -0,0 +1,22 @@
+-- Create the outbox of WhatsApp messages waiting to be delivered
+CREATE TABLE outbound_messages (
+    id SERIAL PRIMARY KEY,
+    to_number VARCHAR(20) NOT NULL,
+    body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'dead')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    leased_by VARCHAR(255),
+    leased_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create indexes for performance
+CREATE INDEX idx_outbound_messages_due ON outbound_messages(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_outbound_messages_status ON outbound_messages(status, created_at);
+
+-- Create trigger for updating updated_at column
+CREATE TRIGGER update_outbound_messages_updated_at BEFORE UPDATE ON outbound_messages FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+-- Drop triggers
+DROP TRIGGER IF EXISTS notify_event_reminders_change ON event_reminders;
+DROP TRIGGER IF EXISTS notify_events_change ON events;
+
+-- Drop functions
+DROP FUNCTION IF EXISTS notify_event_reminders_change();
+DROP FUNCTION IF EXISTS notify_event_change();
@@ -0,0 +1,26 @@
+-- Notify reminder workers when an event's schedule changes so they can keep
+-- their in-memory reminder queue in sync. Lease and updated_at changes alone
+-- do not affect the schedule and are not notified.
+CREATE OR REPLACE FUNCTION notify_event_change()
+    RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+       AND (to_jsonb(NEW) - 'leased_by' - 'leased_until' - 'updated_at')
+         = (to_jsonb(OLD) - 'leased_by' - 'leased_until' - 'updated_at') THEN
+        RETURN NULL;
+    END IF;
+    PERFORM pg_notify('event_changes', COALESCE(NEW.id, OLD.id)::text);
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION notify_event_reminders_change()
+    RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('event_changes', COALESCE(NEW.event_id, OLD.event_id)::text);
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER notify_events_change AFTER INSERT OR UPDATE OR DELETE ON events FOR EACH ROW EXECUTE FUNCTION notify_event_change();
+CREATE TRIGGER notify_event_reminders_change AFTER INSERT OR UPDATE OR DELETE ON event_reminders FOR EACH ROW EXECUTE FUNCTION notify_event_reminders_change();
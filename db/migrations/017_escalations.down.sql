@@ -0,0 +1,5 @@
+-- Remove escalation policies and their history
+DROP TABLE IF EXISTS event_escalations;
+ALTER TABLE events DROP COLUMN IF EXISTS escalation_level;
+ALTER TABLE events DROP COLUMN IF EXISTS escalation_policy;
+ALTER TABLE users DROP COLUMN IF EXISTS default_escalation_policy;
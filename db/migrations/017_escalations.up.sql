@@ -0,0 +1,22 @@
+-- Escalate unconfirmed events to the user's allowed contacts
+ALTER TABLE users ADD COLUMN default_escalation_policy JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE events ADD COLUMN escalation_policy JSONB;
+ALTER TABLE events ADD COLUMN escalation_level INTEGER NOT NULL DEFAULT 0;
+
+-- Create event_escalations table to keep the history of escalations
+CREATE TABLE event_escalations (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    occurrence_starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    step INTEGER NOT NULL,
+    contact_number VARCHAR(20) NOT NULL,
+    unanswered_reminders INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'notified' CHECK (status IN ('notified', 'canceled')),
+    notified_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    canceled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create indexes for performance
+CREATE INDEX idx_event_escalations_event ON event_escalations(event_id, occurrence_starts_at);
@@ -0,0 +1,2 @@
+-- Remove message template overrides
+DROP TABLE IF EXISTS message_templates;
@@ -0,0 +1,12 @@
+-- Create message_templates table to override the wording of bot messages
+CREATE TABLE message_templates (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- A template is overridden at most once globally (no user) and once per user
+CREATE UNIQUE INDEX idx_message_templates_user_name ON message_templates((COALESCE(user_id, 0)), name);
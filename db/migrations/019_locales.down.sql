@@ -0,0 +1,7 @@
+-- Remove locales
+DROP INDEX IF EXISTS idx_message_templates_user_locale_name;
+DELETE FROM message_templates WHERE locale <> 'pt-BR';
+CREATE UNIQUE INDEX idx_message_templates_user_name ON message_templates((COALESCE(user_id, 0)), name);
+ALTER TABLE message_templates DROP COLUMN IF EXISTS locale;
+
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
@@ -0,0 +1,9 @@
+-- Add the language users are talked to in, empty until detected from their
+-- first messages or set through the API
+ALTER TABLE users ADD COLUMN locale VARCHAR(10) NOT NULL DEFAULT '';
+
+-- Template overrides are written for one locale
+ALTER TABLE message_templates ADD COLUMN locale VARCHAR(10) NOT NULL DEFAULT 'pt-BR';
+
+DROP INDEX IF EXISTS idx_message_templates_user_name;
+CREATE UNIQUE INDEX idx_message_templates_user_locale_name ON message_templates((COALESCE(user_id, 0)), locale, name);
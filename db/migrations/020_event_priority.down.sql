@@ -0,0 +1,2 @@
+-- Remove event priority
+ALTER TABLE events DROP COLUMN IF EXISTS priority;
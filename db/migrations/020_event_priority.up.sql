@@ -0,0 +1,3 @@
+-- Add the event priority, which sets the defaults of how insistently its
+-- reminders nag
+ALTER TABLE events ADD COLUMN priority VARCHAR(10) NOT NULL DEFAULT 'normal';
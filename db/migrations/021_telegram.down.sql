@@ -0,0 +1,7 @@
+-- Remove channels
+ALTER TABLE outbound_messages DROP COLUMN IF EXISTS channel;
+
+DROP INDEX IF EXISTS idx_users_channel_wa_number;
+DELETE FROM users WHERE channel <> 'whatsapp';
+ALTER TABLE users ADD CONSTRAINT users_wa_number_key UNIQUE (wa_number);
+ALTER TABLE users DROP COLUMN IF EXISTS channel;
@@ -0,0 +1,8 @@
+-- Users can talk to the agent on Telegram too; wa_number then holds their
+-- Telegram chat ID, unique per channel
+ALTER TABLE users ADD COLUMN channel VARCHAR(20) NOT NULL DEFAULT 'whatsapp';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_wa_number_key;
+CREATE UNIQUE INDEX idx_users_channel_wa_number ON users(channel, wa_number);
+
+-- Outbound messages are delivered on the recipient's channel
+ALTER TABLE outbound_messages ADD COLUMN channel VARCHAR(20) NOT NULL DEFAULT 'whatsapp';
@@ -0,0 +1,21 @@
+-- Key users by wa_number again, on the channel they prefer when it is
+-- WhatsApp or Telegram and on WhatsApp otherwise
+DELETE FROM outbound_messages WHERE channel NOT IN ('whatsapp', 'telegram');
+ALTER TABLE outbound_messages ALTER COLUMN to_number TYPE VARCHAR(20);
+
+ALTER TABLE users ADD COLUMN wa_number VARCHAR(20);
+ALTER TABLE users RENAME COLUMN preferred_channel TO channel;
+
+UPDATE users u SET wa_number = i.external_id
+FROM user_identities i
+WHERE i.user_id = u.id AND i.channel = u.channel AND i.channel IN ('whatsapp', 'telegram');
+
+UPDATE users u SET wa_number = i.external_id, channel = 'whatsapp'
+FROM user_identities i
+WHERE u.wa_number IS NULL AND i.user_id = u.id AND i.channel = 'whatsapp';
+
+DELETE FROM users WHERE wa_number IS NULL;
+ALTER TABLE users ALTER COLUMN wa_number SET NOT NULL;
+CREATE UNIQUE INDEX idx_users_channel_wa_number ON users(channel, wa_number);
+
+DROP TABLE IF EXISTS user_identities;
@@ -0,0 +1,27 @@
+-- Identify users by their identities on any channel instead of by
+-- wa_number, so one user can be reached on WhatsApp, Telegram, SMS and email
+CREATE TABLE user_identities (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('whatsapp', 'telegram', 'sms', 'email')),
+    external_id VARCHAR(255) NOT NULL,
+    verified BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(channel, external_id)
+);
+
+CREATE INDEX idx_user_identities_user ON user_identities(user_id);
+
+-- Every existing user wrote from the number or chat ID they were created with
+INSERT INTO user_identities (user_id, channel, external_id, verified)
+SELECT id, channel, wa_number, true FROM users;
+
+-- Reminders go to the channel the user prefers, which so far is the one
+-- they wrote from
+ALTER TABLE users RENAME COLUMN channel TO preferred_channel;
+DROP INDEX IF EXISTS idx_users_channel_wa_number;
+ALTER TABLE users DROP COLUMN wa_number;
+
+-- Outbound messages can go to any identity, such as an email address
+ALTER TABLE outbound_messages ALTER COLUMN to_number TYPE VARCHAR(255);
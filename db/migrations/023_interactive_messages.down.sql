@@ -0,0 +1,2 @@
+-- Remove interactive messages
+ALTER TABLE outbound_messages DROP COLUMN IF EXISTS interactive;
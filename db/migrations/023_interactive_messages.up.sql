@@ -0,0 +1,2 @@
+-- Outbound messages may offer reply buttons or a list to pick from
+ALTER TABLE outbound_messages ADD COLUMN interactive JSONB;
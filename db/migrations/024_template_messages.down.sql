@@ -0,0 +1,3 @@
+-- Remove template messages
+ALTER TABLE outbound_messages DROP COLUMN IF EXISTS template;
+ALTER TABLE user_identities DROP COLUMN IF EXISTS last_inbound_at;
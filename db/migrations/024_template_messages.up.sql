@@ -0,0 +1,11 @@
+-- WhatsApp only delivers free-form messages within 24 hours of the user's
+-- last message; outside that window reminders go as approved templates
+ALTER TABLE user_identities ADD COLUMN last_inbound_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE outbound_messages ADD COLUMN template JSONB;
+
+-- Sessions still open when this runs are known from the messages received
+UPDATE user_identities
+SET last_inbound_at = (
+    SELECT MAX(m.created_at) FROM inbound_messages m WHERE m.from_number = user_identities.external_id
+)
+WHERE channel = 'whatsapp';
@@ -0,0 +1,8 @@
+-- Remove identity verification codes
+DELETE FROM user_identities WHERE NOT verified;
+
+DROP INDEX IF EXISTS idx_user_identities_claim;
+DROP INDEX IF EXISTS idx_user_identities_verified;
+ALTER TABLE user_identities ADD CONSTRAINT user_identities_channel_external_id_key UNIQUE (channel, external_id);
+
+ALTER TABLE user_identities DROP COLUMN IF EXISTS verification_code;
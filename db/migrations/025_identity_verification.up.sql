@@ -0,0 +1,12 @@
+-- Identities linked through the API are verified by echoing back a code sent
+-- to them, so several users may claim the same ID while only one holds it
+-- verified
+ALTER TABLE user_identities ADD COLUMN verification_code VARCHAR(16);
+
+ALTER TABLE user_identities DROP CONSTRAINT user_identities_channel_external_id_key;
+CREATE UNIQUE INDEX idx_user_identities_verified ON user_identities(channel, external_id) WHERE verified;
+CREATE UNIQUE INDEX idx_user_identities_claim ON user_identities(user_id, channel, external_id);
+
+-- Claims made before codes existed were verified by any message from the
+-- identity; they have to be linked again
+DELETE FROM user_identities WHERE NOT verified;
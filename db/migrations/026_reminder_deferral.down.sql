@@ -0,0 +1,3 @@
+-- Remove reminder deferral; pending deferrals become snoozes again
+UPDATE events SET snoozed_until = deferred_until WHERE deferred_until IS NOT NULL AND snoozed_until IS NULL;
+ALTER TABLE events DROP COLUMN IF EXISTS deferred_until;
@@ -0,0 +1,3 @@
+-- Reminders held for the user's quiet hours are deferred rather than
+-- snoozed, so they still count towards max_notifications and escalation
+ALTER TABLE events ADD COLUMN deferred_until TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,2 @@
+-- Numbers keep their normalized form; the original formatting is not recorded
+SELECT 1;
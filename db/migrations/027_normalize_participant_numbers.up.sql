@@ -0,0 +1,9 @@
+-- Participant numbers are stored without the leading +, as providers send
+-- the numbers of inbound messages, so invitation replies match them
+DELETE FROM event_participants p
+USING event_participants other
+WHERE p.wa_number LIKE '+%'
+  AND other.event_id = p.event_id
+  AND other.wa_number = LTRIM(p.wa_number, '+');
+
+UPDATE event_participants SET wa_number = LTRIM(wa_number, '+') WHERE wa_number LIKE '+%';
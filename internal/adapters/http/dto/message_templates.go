@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/alarm-agent/internal/domain"
+)
+
+// MessageTemplateQuery picks a user's overrides; without a user, the global
+// ones. Locale defaults to pt-BR.
+type MessageTemplateQuery struct {
+	UserID *int   `form:"user_id" binding:"omitempty,min=1"`
+	Locale string `form:"locale"`
+}
+
+type UpsertMessageTemplateRequest struct {
+	UserID *int   `json:"user_id" binding:"omitempty,min=1"`
+	Locale string `json:"locale"`
+	Body   string `json:"body" binding:"required"`
+}
+
+type MessageTemplateResponse struct {
+	ID        int       `json:"id"`
+	UserID    *int      `json:"user_id,omitempty"`
+	Locale    string    `json:"locale"`
+	Name      string    `json:"name"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func MessageTemplateToResponse(template *domain.MessageTemplate) MessageTemplateResponse {
+	return MessageTemplateResponse{
+		ID:        template.ID,
+		UserID:    template.UserID,
+		Locale:    string(template.Locale),
+		Name:      template.Name,
+		Body:      template.Body,
+		CreatedAt: template.CreatedAt,
+		UpdatedAt: template.UpdatedAt,
+	}
+}
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/alarm-agent/internal/domain"
+)
+
+type ListOutboundMessagesQuery struct {
+	Status *string `form:"status" binding:"omitempty,oneof=pending sent dead"`
+	Limit  *int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset *int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+type OutboundMessageResponse struct {
+	ID            int        `json:"id"`
+	ToNumber      string     `json:"to_number"`
+	Body          string     `json:"body"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LastError     *string    `json:"last_error,omitempty"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+type ListOutboundMessagesResponse struct {
+	Messages   []OutboundMessageResponse `json:"messages"`
+	TotalCount int                       `json:"total_count"`
+	Limit      int                       `json:"limit"`
+	Offset     int                       `json:"offset"`
+}
+
+func OutboundMessageToResponse(message *domain.OutboundMessage) OutboundMessageResponse {
+	return OutboundMessageResponse{
+		ID:            message.ID,
+		ToNumber:      message.ToNumber,
+		Body:          message.Body,
+		Status:        string(message.Status),
+		Attempts:      message.Attempts,
+		NextAttemptAt: message.NextAttemptAt,
+		LastError:     message.LastError,
+		SentAt:        message.SentAt,
+		CreatedAt:     message.CreatedAt,
+		UpdatedAt:     message.UpdatedAt,
+	}
+}
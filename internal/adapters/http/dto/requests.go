@@ -2,27 +2,56 @@ package dto
 
 import (
 	"time"
+
+	"github.com/alarm-agent/internal/domain"
 )
 
 type CreateEventRequest struct {
-	Title                  string    `json:"title" binding:"required,max=500"`
-	Location               *string   `json:"location,omitempty" binding:"omitempty,max=500"`
-	StartsAt               time.Time `json:"starts_at" binding:"required"`
-	RemindBeforeMinutes    *int      `json:"remind_before_minutes,omitempty" binding:"omitempty,min=0,max=10080"`
-	RemindFrequencyMinutes *int      `json:"remind_frequency_minutes,omitempty" binding:"omitempty,min=1,max=1440"`
-	RequireConfirmation    *bool     `json:"require_confirmation,omitempty"`
-	MaxNotifications       *int      `json:"max_notifications,omitempty" binding:"omitempty,min=1,max=10"`
+	Title                  string                   `json:"title" binding:"required,max=500"`
+	Location               *string                  `json:"location,omitempty" binding:"omitempty,max=500"`
+	StartsAt               time.Time                `json:"starts_at" binding:"required"`
+	EndsAt                 *time.Time               `json:"ends_at,omitempty"`
+	DurationMinutes        *int                     `json:"duration_minutes,omitempty" binding:"omitempty,min=1"`
+	AllDay                 *bool                    `json:"all_day,omitempty"`
+	RemindBeforeMinutes    *int                     `json:"remind_before_minutes,omitempty" binding:"omitempty,min=0,max=10080"`
+	ReminderOffsets        []int                    `json:"reminder_offsets,omitempty" binding:"omitempty,max=10,dive,min=0,max=10080"`
+	RemindFrequencyMinutes *int                     `json:"remind_frequency_minutes,omitempty" binding:"omitempty,min=1,max=1440"`
+	RequireConfirmation    *bool                    `json:"require_confirmation,omitempty"`
+	MaxNotifications       *int                     `json:"max_notifications,omitempty" binding:"omitempty,min=1,max=10"`
+	IgnoreQuietHours       *bool                    `json:"ignore_quiet_hours,omitempty"`
+	Priority               *string                  `json:"priority,omitempty" binding:"omitempty,oneof=low normal high critical"`
+	EscalationPolicy       *domain.EscalationPolicy `json:"escalation_policy,omitempty"`
+	Recurrence             *string                  `json:"recurrence,omitempty" binding:"omitempty,max=255"`
+	Participants           []string                 `json:"participants,omitempty" binding:"omitempty,max=20,dive,max=255"`
+	Force                  bool                     `json:"force,omitempty"`
 }
 
 type UpdateEventRequest struct {
-	Title                  *string    `json:"title,omitempty" binding:"omitempty,max=500"`
-	Location               *string    `json:"location,omitempty" binding:"omitempty,max=500"`
-	StartsAt               *time.Time `json:"starts_at,omitempty"`
-	RemindBeforeMinutes    *int       `json:"remind_before_minutes,omitempty" binding:"omitempty,min=0,max=10080"`
-	RemindFrequencyMinutes *int       `json:"remind_frequency_minutes,omitempty" binding:"omitempty,min=1,max=1440"`
-	RequireConfirmation    *bool      `json:"require_confirmation,omitempty"`
-	MaxNotifications       *int       `json:"max_notifications,omitempty" binding:"omitempty,min=1,max=10"`
-	Status                 *string    `json:"status,omitempty" binding:"omitempty,oneof=scheduled confirmed canceled completed"`
+	Title                  *string                  `json:"title,omitempty" binding:"omitempty,max=500"`
+	Location               *string                  `json:"location,omitempty" binding:"omitempty,max=500"`
+	StartsAt               *time.Time               `json:"starts_at,omitempty"`
+	EndsAt                 *time.Time               `json:"ends_at,omitempty"`
+	DurationMinutes        *int                     `json:"duration_minutes,omitempty" binding:"omitempty,min=1"`
+	AllDay                 *bool                    `json:"all_day,omitempty"`
+	RemindBeforeMinutes    *int                     `json:"remind_before_minutes,omitempty" binding:"omitempty,min=0,max=10080"`
+	ReminderOffsets        []int                    `json:"reminder_offsets,omitempty" binding:"omitempty,max=10,dive,min=0,max=10080"`
+	RemindFrequencyMinutes *int                     `json:"remind_frequency_minutes,omitempty" binding:"omitempty,min=1,max=1440"`
+	RequireConfirmation    *bool                    `json:"require_confirmation,omitempty"`
+	MaxNotifications       *int                     `json:"max_notifications,omitempty" binding:"omitempty,min=1,max=10"`
+	IgnoreQuietHours       *bool                    `json:"ignore_quiet_hours,omitempty"`
+	Priority               *string                  `json:"priority,omitempty" binding:"omitempty,oneof=low normal high critical"`
+	EscalationPolicy       *domain.EscalationPolicy `json:"escalation_policy,omitempty"`
+	Recurrence             *string                  `json:"recurrence,omitempty" binding:"omitempty,max=255"`
+	Status                 *string                  `json:"status,omitempty" binding:"omitempty,oneof=scheduled confirmed canceled completed no_response"`
+	Scope                  *string                  `json:"scope,omitempty" binding:"omitempty,oneof=occurrence following series"`
+	OccurrenceStartsAt     *time.Time               `json:"occurrence_starts_at,omitempty"`
+	Force                  bool                     `json:"force,omitempty"`
+}
+
+// OccurrenceQuery targets a single occurrence of a recurring event.
+type OccurrenceQuery struct {
+	Scope              *string    `form:"scope" binding:"omitempty,oneof=occurrence following series"`
+	OccurrenceStartsAt *time.Time `form:"occurrence_starts_at"`
 }
 
 type UpdateUserProfileRequest struct {
@@ -31,6 +60,7 @@ type UpdateUserProfileRequest struct {
 	DefaultRemindBeforeMinutes    *int    `json:"default_remind_before_minutes,omitempty" binding:"omitempty,min=0,max=10080"`
 	DefaultRemindFrequencyMinutes *int    `json:"default_remind_frequency_minutes,omitempty" binding:"omitempty,min=1,max=1440"`
 	DefaultRequireConfirmation    *bool   `json:"default_require_confirmation,omitempty"`
+	DefaultReminderOffsets        []int   `json:"default_reminder_offsets,omitempty" binding:"omitempty,max=10,dive,min=0,max=10080"`
 	LLMProvider                   *string `json:"llm_provider,omitempty"`
 	LLMModel                      *string `json:"llm_model,omitempty"`
 }
@@ -38,7 +68,7 @@ type UpdateUserProfileRequest struct {
 type ListEventsQuery struct {
 	StartDate *time.Time `form:"start_date"`
 	EndDate   *time.Time `form:"end_date"`
-	Status    *string    `form:"status" binding:"omitempty,oneof=scheduled confirmed canceled completed"`
+	Status    *string    `form:"status" binding:"omitempty,oneof=scheduled confirmed canceled completed no_response"`
 	Limit     *int       `form:"limit" binding:"omitempty,min=1,max=100"`
 	Offset    *int       `form:"offset" binding:"omitempty,min=0"`
 }
@@ -7,29 +7,65 @@ import (
 )
 
 type EventResponse struct {
-	ID                     int        `json:"id"`
-	Title                  string     `json:"title"`
-	Location               *string    `json:"location,omitempty"`
-	StartsAt               time.Time  `json:"starts_at"`
-	RemindBeforeMinutes    int        `json:"remind_before_minutes"`
-	RemindFrequencyMinutes int        `json:"remind_frequency_minutes"`
-	RequireConfirmation    bool       `json:"require_confirmation"`
-	MaxNotifications       int        `json:"max_notifications"`
-	Status                 string     `json:"status"`
-	NotificationsSent      int        `json:"notifications_sent"`
-	LastNotifiedAt         *time.Time `json:"last_notified_at,omitempty"`
-	CreatedAt              time.Time  `json:"created_at"`
-	UpdatedAt              time.Time  `json:"updated_at"`
+	ID                     int                      `json:"id"`
+	Title                  string                   `json:"title"`
+	Location               *string                  `json:"location,omitempty"`
+	StartsAt               time.Time                `json:"starts_at"`
+	EndsAt                 *time.Time               `json:"ends_at,omitempty"`
+	AllDay                 bool                     `json:"all_day"`
+	RecurrenceRule         *string                  `json:"recurrence_rule,omitempty"`
+	NextOccurrenceAt       *time.Time               `json:"next_occurrence_at,omitempty"`
+	OriginalStartsAt       *time.Time               `json:"original_starts_at,omitempty"`
+	RemindBeforeMinutes    int                      `json:"remind_before_minutes"`
+	ReminderOffsets        []int                    `json:"reminder_offsets,omitempty"`
+	RemindFrequencyMinutes int                      `json:"remind_frequency_minutes"`
+	RequireConfirmation    bool                     `json:"require_confirmation"`
+	MaxNotifications       int                      `json:"max_notifications"`
+	Status                 string                   `json:"status"`
+	NotificationsSent      int                      `json:"notifications_sent"`
+	LastNotifiedAt         *time.Time               `json:"last_notified_at,omitempty"`
+	SnoozedUntil           *time.Time               `json:"snoozed_until,omitempty"`
+	DeferredUntil          *time.Time               `json:"deferred_until,omitempty"`
+	IgnoreQuietHours       bool                     `json:"ignore_quiet_hours"`
+	Priority               string                   `json:"priority"`
+	EscalationPolicy       *domain.EscalationPolicy `json:"escalation_policy,omitempty"`
+	EscalationLevel        int                      `json:"escalation_level"`
+	CreatedAt              time.Time                `json:"created_at"`
+	UpdatedAt              time.Time                `json:"updated_at"`
+
+	Participants []ParticipantResponse `json:"participants,omitempty"`
+}
+
+type ParticipantResponse struct {
+	ID          int        `json:"id"`
+	Name        *string    `json:"name,omitempty"`
+	WANumber    *string    `json:"wa_number,omitempty"`
+	Status      string     `json:"status"`
+	InvitedAt   *time.Time `json:"invited_at,omitempty"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+}
+
+type EscalationResponse struct {
+	ID                  int        `json:"id"`
+	OccurrenceStartsAt  time.Time  `json:"occurrence_starts_at"`
+	Step                int        `json:"step"`
+	ContactNumber       string     `json:"contact_number"`
+	UnansweredReminders int        `json:"unanswered_reminders"`
+	Status              string     `json:"status"`
+	NotifiedAt          time.Time  `json:"notified_at"`
+	CanceledAt          *time.Time `json:"canceled_at,omitempty"`
 }
 
 type UserResponse struct {
 	ID                            int       `json:"id"`
-	WANumber                      string    `json:"wa_number"`
+	WANumber                      string    `json:"wa_number,omitempty"`
+	PreferredChannel              string    `json:"preferred_channel"`
 	Name                          *string   `json:"name,omitempty"`
 	Timezone                      string    `json:"timezone"`
 	DefaultRemindBeforeMinutes    int       `json:"default_remind_before_minutes"`
 	DefaultRemindFrequencyMinutes int       `json:"default_remind_frequency_minutes"`
 	DefaultRequireConfirmation    bool      `json:"default_require_confirmation"`
+	DefaultReminderOffsets        []int     `json:"default_reminder_offsets"`
 	LLMProvider                   *string   `json:"llm_provider,omitempty"`
 	LLMModel                      *string   `json:"llm_model,omitempty"`
 	CreatedAt                     time.Time `json:"created_at"`
@@ -67,38 +103,84 @@ type ErrorResponse struct {
 	Code    int    `json:"code,omitempty"`
 }
 
+// ConflictResponse lists the events a new or moved event would overlap.
+type ConflictResponse struct {
+	Error     string          `json:"error"`
+	Message   string          `json:"message"`
+	Conflicts []EventResponse `json:"conflicts"`
+}
+
 type SuccessResponse struct {
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
 }
 
+func ConflictToResponse(conflict *domain.ScheduleConflictError) ConflictResponse {
+	response := ConflictResponse{
+		Error:     "schedule_conflict",
+		Message:   "Event overlaps existing events; resend with force=true to keep both",
+		Conflicts: make([]EventResponse, 0, len(conflict.Conflicts)),
+	}
+	for i := range conflict.Conflicts {
+		response.Conflicts = append(response.Conflicts, EventToResponse(&conflict.Conflicts[i]))
+	}
+	return response
+}
+
 func EventToResponse(event *domain.Event) EventResponse {
-	return EventResponse{
+	response := EventResponse{
 		ID:                     event.ID,
 		Title:                  event.Title,
 		Location:               event.Location,
 		StartsAt:               event.StartsAt,
+		EndsAt:                 event.EndsAt,
+		AllDay:                 event.AllDay,
+		RecurrenceRule:         event.RecurrenceRule,
+		NextOccurrenceAt:       event.NextOccurrenceAt,
+		OriginalStartsAt:       event.OriginalStartsAt,
 		RemindBeforeMinutes:    event.RemindBeforeMinutes,
+		ReminderOffsets:        event.ReminderOffsets,
 		RemindFrequencyMinutes: event.RemindFrequencyMinutes,
 		RequireConfirmation:    event.RequireConfirmation,
 		MaxNotifications:       event.MaxNotifications,
 		Status:                 string(event.Status),
 		NotificationsSent:      event.NotificationsSent,
 		LastNotifiedAt:         event.LastNotifiedAt,
+		SnoozedUntil:           event.SnoozedUntil,
+		DeferredUntil:          event.DeferredUntil,
+		IgnoreQuietHours:       event.IgnoreQuietHours,
+		Priority:               string(event.Priority.OrDefault()),
+		EscalationPolicy:       event.EscalationPolicy,
+		EscalationLevel:        event.EscalationLevel,
 		CreatedAt:              event.CreatedAt,
 		UpdatedAt:              event.UpdatedAt,
 	}
+
+	for _, participant := range event.Participants {
+		response.Participants = append(response.Participants, ParticipantResponse{
+			ID:          participant.ID,
+			Name:        participant.Name,
+			WANumber:    participant.WANumber,
+			Status:      string(participant.Status),
+			InvitedAt:   participant.InvitedAt,
+			RespondedAt: participant.RespondedAt,
+		})
+	}
+
+	return response
 }
 
 func UserToResponse(user *domain.User) UserResponse {
 	return UserResponse{
 		ID:                            user.ID,
 		WANumber:                      user.WANumber,
+		PreferredChannel:              string(user.PreferredChannel.OrDefault()),
 		Name:                          user.Name,
 		Timezone:                      user.Timezone,
 		DefaultRemindBeforeMinutes:    user.DefaultRemindBeforeMinutes,
 		DefaultRemindFrequencyMinutes: user.DefaultRemindFrequencyMinutes,
 		DefaultRequireConfirmation:    user.DefaultRequireConfirmation,
+		DefaultReminderOffsets:        user.DefaultReminderOffsets,
 		LLMProvider:                   user.LLMProvider,
 		LLMModel:                      user.LLMModel,
 		CreatedAt:                     user.CreatedAt,
@@ -126,3 +208,20 @@ func LLMModelToResponse(model *domain.LLMModel) LLMModelResponse {
 		IsActive:    model.IsActive,
 	}
 }
+
+func EscalationsToResponse(escalations []domain.EventEscalation) []EscalationResponse {
+	response := make([]EscalationResponse, 0, len(escalations))
+	for _, escalation := range escalations {
+		response = append(response, EscalationResponse{
+			ID:                  escalation.ID,
+			OccurrenceStartsAt:  escalation.OccurrenceStartsAt,
+			Step:                escalation.Step,
+			ContactNumber:       escalation.ContactNumber,
+			UnansweredReminders: escalation.UnansweredReminders,
+			Status:              string(escalation.Status),
+			NotifiedAt:          escalation.NotifiedAt,
+			CanceledAt:          escalation.CanceledAt,
+		})
+	}
+	return response
+}
@@ -1,16 +1,28 @@
 package dto
 
+import "github.com/alarm-agent/internal/domain"
+
 // UpdateUserConfigRequest represents a request to update user configuration
 type UpdateUserConfigRequest struct {
-	Name                          *string `json:"name,omitempty"`
-	Timezone                      *string `json:"timezone,omitempty"`
-	DefaultRemindBeforeMinutes    *int    `json:"default_remind_before_minutes,omitempty"`
-	DefaultRemindFrequencyMinutes *int    `json:"default_remind_frequency_minutes,omitempty"`
-	DefaultRequireConfirmation    *bool   `json:"default_require_confirmation,omitempty"`
-	LLMProvider                   *string `json:"llm_provider,omitempty"`
-	LLMModel                      *string `json:"llm_model,omitempty"`
-	RateLimitPerMinute            *int    `json:"rate_limit_per_minute,omitempty"`
-	IsActive                      *bool   `json:"is_active,omitempty"`
+	Name                          *string                 `json:"name,omitempty"`
+	Timezone                      *string                 `json:"timezone,omitempty"`
+	Locale                        *string                 `json:"locale,omitempty"`
+	PreferredChannel              *string                 `json:"preferred_channel,omitempty" binding:"omitempty,oneof=whatsapp telegram sms email"`
+	DefaultRemindBeforeMinutes    *int                    `json:"default_remind_before_minutes,omitempty"`
+	DefaultRemindFrequencyMinutes *int                    `json:"default_remind_frequency_minutes,omitempty"`
+	DefaultRequireConfirmation    *bool                   `json:"default_require_confirmation,omitempty"`
+	DefaultReminderOffsets        []int                   `json:"default_reminder_offsets,omitempty" binding:"omitempty,max=10,dive,min=0,max=10080"`
+	DailyDigestEnabled            *bool                   `json:"daily_digest_enabled,omitempty"`
+	DailyDigestTime               *string                 `json:"daily_digest_time,omitempty"`
+	WeeklyDigestEnabled           *bool                   `json:"weekly_digest_enabled,omitempty"`
+	WeeklyDigestTime              *string                 `json:"weekly_digest_time,omitempty"`
+	QuietHours                    []domain.QuietWindow    `json:"quiet_hours,omitempty"`
+	NotifyNoResponse              *bool                   `json:"notify_no_response,omitempty"`
+	DefaultEscalationPolicy       []domain.EscalationStep `json:"default_escalation_policy,omitempty"`
+	LLMProvider                   *string                 `json:"llm_provider,omitempty"`
+	LLMModel                      *string                 `json:"llm_model,omitempty"`
+	RateLimitPerMinute            *int                    `json:"rate_limit_per_minute,omitempty"`
+	IsActive                      *bool                   `json:"is_active,omitempty"`
 }
 
 // AddAllowedContactRequest represents a request to add an allowed contact
@@ -19,6 +31,13 @@ type AddAllowedContactRequest struct {
 	Note          *string `json:"note,omitempty"`
 }
 
+// AddUserIdentityRequest represents a request to link an identity on a
+// channel to the user
+type AddUserIdentityRequest struct {
+	Channel    string `json:"channel" binding:"required,oneof=whatsapp telegram sms email"`
+	ExternalID string `json:"external_id" binding:"required,max=255"`
+}
+
 // UpdateAllowedContactRequest represents a request to update an allowed contact
 type UpdateAllowedContactRequest struct {
 	Note *string `json:"note,omitempty"`
@@ -26,16 +45,26 @@ type UpdateAllowedContactRequest struct {
 
 // UserConfigResponse represents the user's configuration
 type UserConfigResponse struct {
-	UserID                        int     `json:"user_id"`
-	Name                          *string `json:"name,omitempty"`
-	Timezone                      string  `json:"timezone"`
-	DefaultRemindBeforeMinutes    int     `json:"default_remind_before_minutes"`
-	DefaultRemindFrequencyMinutes int     `json:"default_remind_frequency_minutes"`
-	DefaultRequireConfirmation    bool    `json:"default_require_confirmation"`
-	LLMProvider                   *string `json:"llm_provider,omitempty"`
-	LLMModel                      *string `json:"llm_model,omitempty"`
-	RateLimitPerMinute            int     `json:"rate_limit_per_minute"`
-	IsActive                      bool    `json:"is_active"`
+	UserID                        int                     `json:"user_id"`
+	Name                          *string                 `json:"name,omitempty"`
+	Timezone                      string                  `json:"timezone"`
+	Locale                        string                  `json:"locale,omitempty"`
+	PreferredChannel              string                  `json:"preferred_channel"`
+	DefaultRemindBeforeMinutes    int                     `json:"default_remind_before_minutes"`
+	DefaultRemindFrequencyMinutes int                     `json:"default_remind_frequency_minutes"`
+	DefaultRequireConfirmation    bool                    `json:"default_require_confirmation"`
+	DefaultReminderOffsets        []int                   `json:"default_reminder_offsets"`
+	DailyDigestEnabled            bool                    `json:"daily_digest_enabled"`
+	DailyDigestTime               string                  `json:"daily_digest_time"`
+	WeeklyDigestEnabled           bool                    `json:"weekly_digest_enabled"`
+	WeeklyDigestTime              string                  `json:"weekly_digest_time"`
+	QuietHours                    []domain.QuietWindow    `json:"quiet_hours"`
+	NotifyNoResponse              bool                    `json:"notify_no_response"`
+	DefaultEscalationPolicy       []domain.EscalationStep `json:"default_escalation_policy"`
+	LLMProvider                   *string                 `json:"llm_provider,omitempty"`
+	LLMModel                      *string                 `json:"llm_model,omitempty"`
+	RateLimitPerMinute            int                     `json:"rate_limit_per_minute"`
+	IsActive                      bool                    `json:"is_active"`
 }
 
 // AllowedContactResponse represents an allowed contact
@@ -46,3 +75,13 @@ type AllowedContactResponse struct {
 	CreatedAt     string  `json:"created_at"`
 	UpdatedAt     string  `json:"updated_at"`
 }
+
+// UserIdentityResponse represents one of the user's identities
+type UserIdentityResponse struct {
+	ID         int    `json:"id"`
+	Channel    string `json:"channel"`
+	ExternalID string `json:"external_id"`
+	Verified   bool   `json:"verified"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -48,13 +49,28 @@ func (h *EventsHandler) CreateEvent(c *gin.Context) {
 		Title:                  &req.Title,
 		Location:               req.Location,
 		StartsAt:               &req.StartsAt,
+		EndsAt:                 req.EndsAt,
+		DurationMinutes:        req.DurationMinutes,
+		AllDay:                 req.AllDay,
 		RemindBeforeMinutes:    req.RemindBeforeMinutes,
+		ReminderOffsets:        req.ReminderOffsets,
 		RemindFrequencyMinutes: req.RemindFrequencyMinutes,
 		RequireConfirmation:    req.RequireConfirmation,
 		MaxNotifications:       req.MaxNotifications,
+		IgnoreQuietHours:       req.IgnoreQuietHours,
+		Priority:               req.Priority,
+		EscalationPolicy:       req.EscalationPolicy,
+		Recurrence:             req.Recurrence,
+		Participants:           req.Participants,
+		Force:                  req.Force,
 	}
 
 	event, err := h.eventUseCase.CreateEvent(c.Request.Context(), userID, entities)
+	var conflict *domain.ScheduleConflictError
+	if errors.As(err, &conflict) {
+		c.JSON(http.StatusConflict, dto.ConflictToResponse(conflict))
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:   "create_failed",
@@ -137,18 +153,34 @@ func (h *EventsHandler) UpdateEvent(c *gin.Context) {
 	// Create event entities with ID for update
 	entities := &domain.EventEntities{
 		Identifier: &domain.EventIdentifier{
-			EventID: &eventID,
+			EventID:            &eventID,
+			Scope:              toEditScope(req.Scope),
+			OccurrenceStartsAt: req.OccurrenceStartsAt,
 		},
 		Title:                  req.Title,
 		Location:               req.Location,
 		StartsAt:               req.StartsAt,
+		EndsAt:                 req.EndsAt,
+		DurationMinutes:        req.DurationMinutes,
+		AllDay:                 req.AllDay,
 		RemindBeforeMinutes:    req.RemindBeforeMinutes,
+		ReminderOffsets:        req.ReminderOffsets,
 		RemindFrequencyMinutes: req.RemindFrequencyMinutes,
 		RequireConfirmation:    req.RequireConfirmation,
 		MaxNotifications:       req.MaxNotifications,
+		IgnoreQuietHours:       req.IgnoreQuietHours,
+		Priority:               req.Priority,
+		EscalationPolicy:       req.EscalationPolicy,
+		Recurrence:             req.Recurrence,
+		Force:                  req.Force,
 	}
 
 	event, err := h.eventUseCase.UpdateEvent(c.Request.Context(), userID, entities)
+	var conflict *domain.ScheduleConflictError
+	if errors.As(err, &conflict) {
+		c.JSON(http.StatusConflict, dto.ConflictToResponse(conflict))
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:   "update_failed",
@@ -185,8 +217,19 @@ func (h *EventsHandler) DeleteEvent(c *gin.Context) {
 		return
 	}
 
+	var query dto.OccurrenceQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_query",
+			Message: err.Error(),
+		})
+		return
+	}
+
 	identifier := &domain.EventIdentifier{
-		EventID: &eventID,
+		EventID:            &eventID,
+		Scope:              toEditScope(query.Scope),
+		OccurrenceStartsAt: query.OccurrenceStartsAt,
 	}
 
 	event, err := h.eventUseCase.CancelEvent(c.Request.Context(), userID, identifier)
@@ -305,8 +348,19 @@ func (h *EventsHandler) ConfirmEvent(c *gin.Context) {
 		return
 	}
 
+	var query dto.OccurrenceQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_query",
+			Message: err.Error(),
+		})
+		return
+	}
+
 	identifier := &domain.EventIdentifier{
-		EventID: &eventID,
+		EventID:            &eventID,
+		Scope:              toEditScope(query.Scope),
+		OccurrenceStartsAt: query.OccurrenceStartsAt,
 	}
 
 	event, err := h.eventUseCase.ConfirmEvent(c.Request.Context(), userID, identifier)
@@ -323,3 +377,45 @@ func (h *EventsHandler) ConfirmEvent(c *gin.Context) {
 		Data:    dto.EventToResponse(event),
 	})
 }
+
+// ListEscalations returns the escalation history of an event
+// GET /api/v1/events/:id/escalations
+func (h *EventsHandler) ListEscalations(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	eventIDStr := c.Param("id")
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_event_id",
+			Message: "Invalid event ID format",
+		})
+		return
+	}
+
+	escalations, err := h.eventUseCase.ListEscalations(c.Request.Context(), userID, eventID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "event_not_found",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.EscalationsToResponse(escalations))
+}
+
+func toEditScope(scope *string) *domain.EditScope {
+	if scope == nil {
+		return nil
+	}
+	editScope := domain.EditScope(*scope)
+	return &editScope
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alarm-agent/internal/adapters/http/dto"
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+type MessageTemplatesHandler struct {
+	templates ports.MessageTemplateRepository
+	messages  ports.MessageRenderer
+}
+
+func NewMessageTemplatesHandler(templates ports.MessageTemplateRepository, messages ports.MessageRenderer) *MessageTemplatesHandler {
+	return &MessageTemplatesHandler{
+		templates: templates,
+		messages:  messages,
+	}
+}
+
+// ListMessageTemplates lists the template overrides of a user, or the global ones
+// GET /api/v1/admin/templates
+func (h *MessageTemplatesHandler) ListMessageTemplates(c *gin.Context) {
+	var query dto.MessageTemplateQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_query",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	templates, err := h.templates.List(c.Request.Context(), query.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "list_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	responses := make([]dto.MessageTemplateResponse, len(templates))
+	for i := range templates {
+		responses[i] = dto.MessageTemplateToResponse(&templates[i])
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// UpsertMessageTemplate overrides the wording of a template for a user, or for everyone
+// PUT /api/v1/admin/templates/:name
+func (h *MessageTemplatesHandler) UpsertMessageTemplate(c *gin.Context) {
+	var req dto.UpsertMessageTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	locale, err := templateLocale(req.Locale)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	name := c.Param("name")
+	if err := h.messages.Validate(locale, name, req.Body); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_template",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	template := &domain.MessageTemplate{
+		UserID: req.UserID,
+		Locale: locale,
+		Name:   name,
+		Body:   req.Body,
+	}
+	if err := h.templates.Upsert(c.Request.Context(), template); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "save_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Template saved",
+		Data:    dto.MessageTemplateToResponse(template),
+	})
+}
+
+// DeleteMessageTemplate restores the default wording of a template
+// DELETE /api/v1/admin/templates/:name
+func (h *MessageTemplatesHandler) DeleteMessageTemplate(c *gin.Context) {
+	var query dto.MessageTemplateQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_query",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	locale, err := templateLocale(query.Locale)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_query",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.templates.Delete(c.Request.Context(), query.UserID, locale, c.Param("name")); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "delete_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Template restored to its default",
+	})
+}
+
+// templateLocale parses the locale of an override, the default one when
+// unset.
+func templateLocale(value string) (domain.Locale, error) {
+	if value == "" {
+		return domain.DefaultLocale, nil
+	}
+	return domain.ParseLocale(value)
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alarm-agent/internal/adapters/http/dto"
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+type OutboxHandler struct {
+	outbox       ports.OutboundMessageRepository
+	timeProvider ports.TimeProvider
+}
+
+func NewOutboxHandler(outbox ports.OutboundMessageRepository, timeProvider ports.TimeProvider) *OutboxHandler {
+	return &OutboxHandler{
+		outbox:       outbox,
+		timeProvider: timeProvider,
+	}
+}
+
+// ListOutboundMessages lists outbox messages by status, dead letters by default
+// GET /api/v1/admin/outbox
+func (h *OutboxHandler) ListOutboundMessages(c *gin.Context) {
+	var query dto.ListOutboundMessagesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_query",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	status := domain.OutboundMessageDead
+	limit := 50
+	offset := 0
+	if query.Status != nil {
+		status = domain.OutboundMessageStatus(*query.Status)
+	}
+	if query.Limit != nil {
+		limit = *query.Limit
+	}
+	if query.Offset != nil {
+		offset = *query.Offset
+	}
+
+	messages, err := h.outbox.ListByStatus(c.Request.Context(), status, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "list_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	totalCount, err := h.outbox.CountByStatus(c.Request.Context(), status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "list_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	responses := make([]dto.OutboundMessageResponse, len(messages))
+	for i := range messages {
+		responses[i] = dto.OutboundMessageToResponse(&messages[i])
+	}
+
+	c.JSON(http.StatusOK, dto.ListOutboundMessagesResponse{
+		Messages:   responses,
+		TotalCount: totalCount,
+		Limit:      limit,
+		Offset:     offset,
+	})
+}
+
+// RetryOutboundMessage puts a dead letter back in the outbox for delivery
+// POST /api/v1/admin/outbox/:id/retry
+func (h *OutboxHandler) RetryOutboundMessage(c *gin.Context) {
+	messageID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_message_id",
+			Message: "Invalid message ID format",
+		})
+		return
+	}
+
+	message, err := h.outbox.GetByID(c.Request.Context(), messageID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "lookup_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+	if message == nil {
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "message_not_found",
+			Message: "Outbound message not found",
+		})
+		return
+	}
+	if message.Status != domain.OutboundMessageDead {
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "message_not_dead",
+			Message: "Only dead messages can be retried",
+		})
+		return
+	}
+
+	message.Requeue(h.timeProvider.Now())
+	if err := h.outbox.Update(c.Request.Context(), message); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "retry_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Message queued for delivery",
+		Data:    dto.OutboundMessageToResponse(message),
+	})
+}
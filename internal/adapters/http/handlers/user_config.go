@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -10,15 +11,18 @@ import (
 	"github.com/alarm-agent/internal/adapters/http/middleware"
 	"github.com/alarm-agent/internal/domain"
 	"github.com/alarm-agent/internal/ports"
+	"github.com/alarm-agent/internal/usecase"
 )
 
 type UserConfigHandler struct {
-	repos ports.Repositories
+	repos      ports.Repositories
+	identities *usecase.IdentityUseCase
 }
 
-func NewUserConfigHandler(repos ports.Repositories) *UserConfigHandler {
+func NewUserConfigHandler(repos ports.Repositories, identities *usecase.IdentityUseCase) *UserConfigHandler {
 	return &UserConfigHandler{
-		repos: repos,
+		repos:      repos,
+		identities: identities,
 	}
 }
 
@@ -55,9 +59,19 @@ func (h *UserConfigHandler) GetUserConfig(c *gin.Context) {
 		UserID:                        user.ID,
 		Name:                          user.Name,
 		Timezone:                      user.Timezone,
+		Locale:                        string(user.Locale),
+		PreferredChannel:              string(user.PreferredChannel.OrDefault()),
 		DefaultRemindBeforeMinutes:    user.DefaultRemindBeforeMinutes,
 		DefaultRemindFrequencyMinutes: user.DefaultRemindFrequencyMinutes,
 		DefaultRequireConfirmation:    user.DefaultRequireConfirmation,
+		DefaultReminderOffsets:        user.DefaultReminderOffsets,
+		DailyDigestEnabled:            user.DailyDigestEnabled,
+		DailyDigestTime:               user.DailyDigestTime,
+		WeeklyDigestEnabled:           user.WeeklyDigestEnabled,
+		WeeklyDigestTime:              user.WeeklyDigestTime,
+		QuietHours:                    user.QuietHours,
+		NotifyNoResponse:              user.NotifyNoResponse,
+		DefaultEscalationPolicy:       user.DefaultEscalationPolicy,
 		LLMProvider:                   user.LLMProvider,
 		LLMModel:                      user.LLMModel,
 		RateLimitPerMinute:            user.RateLimitPerMinute,
@@ -107,18 +121,7 @@ func (h *UserConfigHandler) UpdateUserConfig(c *gin.Context) {
 	}
 
 	// Apply updates (only change non-nil fields)
-	config := &domain.UserConfig{
-		UserID:                        userID,
-		Name:                          user.Name,
-		Timezone:                      user.Timezone,
-		DefaultRemindBeforeMinutes:    user.DefaultRemindBeforeMinutes,
-		DefaultRemindFrequencyMinutes: user.DefaultRemindFrequencyMinutes,
-		DefaultRequireConfirmation:    user.DefaultRequireConfirmation,
-		LLMProvider:                   user.LLMProvider,
-		LLMModel:                      user.LLMModel,
-		RateLimitPerMinute:            user.RateLimitPerMinute,
-		IsActive:                      user.IsActive,
-	}
+	config := user.Config()
 
 	if req.Name != nil {
 		config.Name = req.Name
@@ -126,6 +129,43 @@ func (h *UserConfigHandler) UpdateUserConfig(c *gin.Context) {
 	if req.Timezone != nil {
 		config.Timezone = *req.Timezone
 	}
+	if req.Locale != nil {
+		locale, err := domain.ParseLocale(*req.Locale)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: err.Error(),
+			})
+			return
+		}
+		config.Locale = locale
+	}
+	if req.PreferredChannel != nil {
+		channel, err := domain.ParseChannel(*req.PreferredChannel)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: err.Error(),
+			})
+			return
+		}
+		identities, err := h.repos.UserIdentity().ListByUserID(c.Request.Context(), userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Error:   "database_error",
+				Message: "Failed to retrieve identities",
+			})
+			return
+		}
+		if !domain.HasVerifiedIdentity(identities, channel) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: "preferred_channel needs a verified identity on " + string(channel),
+			})
+			return
+		}
+		config.PreferredChannel = channel
+	}
 	if req.DefaultRemindBeforeMinutes != nil {
 		config.DefaultRemindBeforeMinutes = *req.DefaultRemindBeforeMinutes
 	}
@@ -135,6 +175,55 @@ func (h *UserConfigHandler) UpdateUserConfig(c *gin.Context) {
 	if req.DefaultRequireConfirmation != nil {
 		config.DefaultRequireConfirmation = *req.DefaultRequireConfirmation
 	}
+	if req.DefaultReminderOffsets != nil {
+		schedule, err := domain.NewReminderSchedule(req.DefaultReminderOffsets)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: err.Error(),
+			})
+			return
+		}
+		config.DefaultReminderOffsets = schedule
+	}
+	digest := domain.DigestSettings{
+		DailyDigest:      req.DailyDigestEnabled,
+		DailyDigestTime:  req.DailyDigestTime,
+		WeeklyDigest:     req.WeeklyDigestEnabled,
+		WeeklyDigestTime: req.WeeklyDigestTime,
+	}
+	if err := digest.Apply(config); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+	if req.QuietHours != nil {
+		quietHours, err := domain.NewQuietHours(req.QuietHours)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: err.Error(),
+			})
+			return
+		}
+		config.QuietHours = quietHours
+	}
+	if req.NotifyNoResponse != nil {
+		config.NotifyNoResponse = *req.NotifyNoResponse
+	}
+	if req.DefaultEscalationPolicy != nil {
+		policy, err := domain.NewEscalationPolicy(req.DefaultEscalationPolicy)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: err.Error(),
+			})
+			return
+		}
+		config.DefaultEscalationPolicy = policy
+	}
 	if req.LLMProvider != nil {
 		config.LLMProvider = req.LLMProvider
 	}
@@ -160,9 +249,19 @@ func (h *UserConfigHandler) UpdateUserConfig(c *gin.Context) {
 		UserID:                        config.UserID,
 		Name:                          config.Name,
 		Timezone:                      config.Timezone,
+		Locale:                        string(config.Locale),
+		PreferredChannel:              string(config.PreferredChannel),
 		DefaultRemindBeforeMinutes:    config.DefaultRemindBeforeMinutes,
 		DefaultRemindFrequencyMinutes: config.DefaultRemindFrequencyMinutes,
 		DefaultRequireConfirmation:    config.DefaultRequireConfirmation,
+		DefaultReminderOffsets:        config.DefaultReminderOffsets,
+		DailyDigestEnabled:            config.DailyDigestEnabled,
+		DailyDigestTime:               config.DailyDigestTime,
+		WeeklyDigestEnabled:           config.WeeklyDigestEnabled,
+		WeeklyDigestTime:              config.WeeklyDigestTime,
+		QuietHours:                    config.QuietHours,
+		NotifyNoResponse:              config.NotifyNoResponse,
+		DefaultEscalationPolicy:       config.DefaultEscalationPolicy,
 		LLMProvider:                   config.LLMProvider,
 		LLMModel:                      config.LLMModel,
 		RateLimitPerMinute:            config.RateLimitPerMinute,
@@ -312,3 +411,210 @@ func (h *UserConfigHandler) RemoveAllowedContact(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Allowed contact removed successfully"})
 }
+
+// ListIdentities lists the current user's identities
+// GET /api/v1/user/identities
+func (h *UserConfigHandler) ListIdentities(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	identities, err := h.repos.UserIdentity().ListByUserID(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to retrieve identities",
+		})
+		return
+	}
+
+	response := make([]dto.UserIdentityResponse, len(identities))
+	for i, identity := range identities {
+		response[i] = identityResponse(identity)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// AddIdentity links an identity on another channel to the current user and
+// sends it a verification code; it stays unverified until the code is sent
+// back from it
+// POST /api/v1/user/identities
+func (h *UserConfigHandler) AddIdentity(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	var req dto.AddUserIdentityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	channel, err := domain.ParseChannel(req.Channel)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	user, err := h.repos.User().GetByID(c.Request.Context(), userID)
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to retrieve user",
+		})
+		return
+	}
+
+	existing, err := h.repos.UserIdentity().GetByChannelID(c.Request.Context(), channel, req.ExternalID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to check identity existence",
+		})
+		return
+	}
+
+	if existing != nil {
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "identity_taken",
+			Message: "Identity is already linked to a user",
+		})
+		return
+	}
+
+	identities, err := h.repos.UserIdentity().ListByUserID(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to retrieve identities",
+		})
+		return
+	}
+
+	for _, identity := range identities {
+		if identity.Channel == channel && identity.ExternalID == req.ExternalID {
+			c.JSON(http.StatusConflict, dto.ErrorResponse{
+				Error:   "identity_pending",
+				Message: "Identity was already linked and awaits verification",
+			})
+			return
+		}
+	}
+
+	identity, err := h.identities.LinkIdentity(c.Request.Context(), user, channel, req.ExternalID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to add identity",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, identityResponse(*identity))
+}
+
+// RemoveIdentity unlinks one of the current user's identities, unless
+// reminders are delivered to it
+// DELETE /api/v1/user/identities/:id
+func (h *UserConfigHandler) RemoveIdentity(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid identity ID",
+		})
+		return
+	}
+
+	user, err := h.repos.User().GetByID(c.Request.Context(), userID)
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to retrieve user",
+		})
+		return
+	}
+
+	identities, err := h.repos.UserIdentity().ListByUserID(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to retrieve identities",
+		})
+		return
+	}
+
+	remaining := make([]domain.UserIdentity, 0, len(identities))
+	found := false
+	for _, identity := range identities {
+		if identity.ID == id {
+			found = true
+			continue
+		}
+		remaining = append(remaining, identity)
+	}
+
+	if !found {
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "identity_not_found",
+			Message: "Identity not found",
+		})
+		return
+	}
+
+	preferred := user.PreferredChannel.OrDefault()
+	if domain.HasVerifiedIdentity(identities, preferred) && !domain.HasVerifiedIdentity(remaining, preferred) {
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "identity_in_use",
+			Message: "Reminders are delivered to this identity; choose another preferred_channel first",
+		})
+		return
+	}
+
+	if err := h.repos.UserIdentity().Delete(c.Request.Context(), userID, id); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to remove identity",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Identity removed successfully"})
+}
+
+func identityResponse(identity domain.UserIdentity) dto.UserIdentityResponse {
+	return dto.UserIdentityResponse{
+		ID:         identity.ID,
+		Channel:    string(identity.Channel),
+		ExternalID: identity.ExternalID,
+		Verified:   identity.Verified,
+		CreatedAt:  identity.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  identity.UpdatedAt.Format(time.RFC3339),
+	}
+}
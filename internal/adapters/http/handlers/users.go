@@ -76,6 +76,17 @@ func (h *UsersHandler) UpdateProfile(c *gin.Context) {
 	if req.DefaultRequireConfirmation != nil {
 		domainUser.DefaultRequireConfirmation = *req.DefaultRequireConfirmation
 	}
+	if req.DefaultReminderOffsets != nil {
+		schedule, err := domain.NewReminderSchedule(req.DefaultReminderOffsets)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: err.Error(),
+			})
+			return
+		}
+		domainUser.DefaultReminderOffsets = schedule
+	}
 	if req.LLMProvider != nil {
 		domainUser.LLMProvider = req.LLMProvider
 	}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alarm-agent/internal/adapters/http/dto"
+)
+
+// AuthenticateAdmin only lets through requests whose X-Admin-Key header
+// matches apiKey.
+func AuthenticateAdmin(apiKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("X-Admin-Key")
+		if key == "" || subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) != 1 {
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+				Error:   "invalid_admin_key",
+				Message: "A valid admin key is required in X-Admin-Key header",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/alarm-agent/internal/adapters/http/dto"
+	"github.com/alarm-agent/internal/domain"
 	"github.com/alarm-agent/internal/ports"
 )
 
@@ -26,24 +27,40 @@ func NewAuthMiddleware(userRepo ports.UserRepository) *AuthMiddleware {
 	}
 }
 
-// AuthenticateByWANumber validates that the user exists and is active
-func (a *AuthMiddleware) AuthenticateByWANumber() gin.HandlerFunc {
+// AuthenticateByIdentity validates that the user exists and is active,
+// finding them by a verified identity: X-Channel and X-External-ID, or the
+// WhatsApp number in X-WA-Number.
+func (a *AuthMiddleware) AuthenticateByIdentity() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		waNumber := c.GetHeader("X-WA-Number")
-		if waNumber == "" {
+		channel := domain.ChannelWhatsApp
+		externalID := c.GetHeader("X-WA-Number")
+		if header := c.GetHeader("X-Channel"); header != "" {
+			parsed, err := domain.ParseChannel(header)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+					Error:   "invalid_channel",
+					Message: err.Error(),
+				})
+				c.Abort()
+				return
+			}
+			channel = parsed
+			externalID = c.GetHeader("X-External-ID")
+		}
+
+		// Clean the identity format
+		externalID = strings.TrimSpace(externalID)
+		if externalID == "" {
 			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-				Error:   "missing_wa_number",
-				Message: "WhatsApp number is required in X-WA-Number header",
+				Error:   "missing_identity",
+				Message: "An identity is required in the X-WA-Number header, or X-Channel and X-External-ID",
 			})
 			c.Abort()
 			return
 		}
 
-		// Clean the phone number format
-		waNumber = strings.TrimSpace(waNumber)
-
-		// Get user by WhatsApp number
-		user, err := a.userRepo.GetByWANumber(c.Request.Context(), waNumber)
+		// Get user by their identity on the channel
+		user, err := a.userRepo.GetByIdentity(c.Request.Context(), channel, externalID)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 				Error:   "user_lookup_failed",
@@ -56,7 +73,7 @@ func (a *AuthMiddleware) AuthenticateByWANumber() gin.HandlerFunc {
 		if user == nil {
 			c.JSON(http.StatusNotFound, dto.ErrorResponse{
 				Error:   "user_not_found",
-				Message: "User not found. Please send a WhatsApp message first to create your account.",
+				Message: "User not found. Please send a message to the bot first to create your account.",
 			})
 			c.Abort()
 			return
@@ -12,6 +12,7 @@ import (
 
 	"github.com/alarm-agent/internal/adapters/http/handlers"
 	"github.com/alarm-agent/internal/adapters/http/middleware"
+	"github.com/alarm-agent/internal/adapters/whatsapp"
 	"github.com/alarm-agent/internal/config"
 	"github.com/alarm-agent/internal/ports"
 	"github.com/alarm-agent/internal/usecase"
@@ -21,6 +22,9 @@ type Server struct {
 	config       *config.Config
 	repos        ports.Repositories
 	eventUseCase *usecase.EventUseCase
+	identities   *usecase.IdentityUseCase
+	messages     ports.MessageRenderer
+	timeProvider ports.TimeProvider
 	logger       *zap.Logger
 	router       *gin.Engine
 	server       *http.Server
@@ -31,7 +35,13 @@ func NewServer(
 	repos ports.Repositories,
 	messageUseCase *usecase.MessageUseCase,
 	eventUseCase *usecase.EventUseCase,
+	identityUseCase *usecase.IdentityUseCase,
 	verifier ports.WhatsAppWebhookVerifier,
+	parser whatsapp.InboundParser,
+	telegramVerifier ports.WhatsAppWebhookVerifier,
+	telegramParser whatsapp.InboundParser,
+	messages ports.MessageRenderer,
+	timeProvider ports.TimeProvider,
 	logger *zap.Logger,
 ) *Server {
 	if cfg.IsProduction() {
@@ -49,6 +59,9 @@ func NewServer(
 		config:       cfg,
 		repos:        repos,
 		eventUseCase: eventUseCase,
+		identities:   identityUseCase,
+		messages:     messages,
+		timeProvider: timeProvider,
 		logger:       logger,
 		router:       router,
 		server: &http.Server{
@@ -57,12 +70,15 @@ func NewServer(
 		},
 	}
 
-	server.setupRoutes(messageUseCase, verifier)
+	server.setupRoutes(messageUseCase, verifier, parser)
+	if telegramParser != nil {
+		server.setupTelegramRoutes(messageUseCase, telegramVerifier, telegramParser)
+	}
 	return server
 }
 
-func (s *Server) setupRoutes(messageUseCase *usecase.MessageUseCase, verifier ports.WhatsAppWebhookVerifier) {
-	webhookHandler := NewWebhookHandler(messageUseCase, verifier, s.logger)
+func (s *Server) setupRoutes(messageUseCase *usecase.MessageUseCase, verifier ports.WhatsAppWebhookVerifier, parser whatsapp.InboundParser) {
+	webhookHandler := NewWebhookHandler(messageUseCase, verifier, parser, s.logger)
 	healthHandler := NewHealthHandler(s.repos, s.logger)
 
 	s.router.GET("/health", healthHandler.Health)
@@ -72,7 +88,8 @@ func (s *Server) setupRoutes(messageUseCase *usecase.MessageUseCase, verifier po
 
 	webhookGroup := s.router.Group("/webhook")
 	{
-		webhookGroup.POST("/whatsapp", webhookHandler.HandleWhatsAppWebhook)
+		webhookGroup.GET("/whatsapp", webhookHandler.VerifyWhatsAppWebhook)
+		webhookGroup.POST("/whatsapp", webhookHandler.HandleWebhook)
 	}
 
 	// Setup API routes
@@ -83,12 +100,19 @@ func (s *Server) setupRoutes(messageUseCase *usecase.MessageUseCase, verifier po
 	}
 }
 
+// setupTelegramRoutes receives the updates of the Telegram bot when it runs
+// in webhook mode.
+func (s *Server) setupTelegramRoutes(messageUseCase *usecase.MessageUseCase, verifier ports.WhatsAppWebhookVerifier, parser whatsapp.InboundParser) {
+	telegramHandler := NewWebhookHandler(messageUseCase, verifier, parser, s.logger)
+	s.router.POST("/webhook/telegram", telegramHandler.HandleWebhook)
+}
+
 func (s *Server) setupAPIRoutes() {
 	// Initialize handlers
 	eventsHandler := handlers.NewEventsHandler(s.eventUseCase)
 	usersHandler := handlers.NewUsersHandler(s.repos.User())
 	llmHandler := handlers.NewLLMHandler(s.repos.LLMConfig())
-	userConfigHandler := handlers.NewUserConfigHandler(s.repos)
+	userConfigHandler := handlers.NewUserConfigHandler(s.repos, s.identities)
 
 	// Initialize auth middleware
 	authMiddleware := middleware.NewAuthMiddleware(s.repos.User())
@@ -104,7 +128,7 @@ func (s *Server) setupAPIRoutes() {
 
 	// Protected routes (authentication required)
 	protectedAPI := s.router.Group("/api/v1")
-	protectedAPI.Use(authMiddleware.AuthenticateByWANumber())
+	protectedAPI.Use(authMiddleware.AuthenticateByIdentity())
 	{
 		// User profile routes
 		protectedAPI.GET("/profile", usersHandler.GetProfile)
@@ -119,6 +143,11 @@ func (s *Server) setupAPIRoutes() {
 		protectedAPI.POST("/user/allowed-contacts", userConfigHandler.AddAllowedContact)
 		protectedAPI.DELETE("/user/allowed-contacts/:contactNumber", userConfigHandler.RemoveAllowedContact)
 
+		// User identities routes
+		protectedAPI.GET("/user/identities", userConfigHandler.ListIdentities)
+		protectedAPI.POST("/user/identities", userConfigHandler.AddIdentity)
+		protectedAPI.DELETE("/user/identities/:id", userConfigHandler.RemoveIdentity)
+
 		// Events routes
 		protectedAPI.POST("/events", eventsHandler.CreateEvent)
 		protectedAPI.GET("/events", eventsHandler.ListEvents)
@@ -126,6 +155,23 @@ func (s *Server) setupAPIRoutes() {
 		protectedAPI.PUT("/events/:id", eventsHandler.UpdateEvent)
 		protectedAPI.DELETE("/events/:id", eventsHandler.DeleteEvent)
 		protectedAPI.POST("/events/:id/confirm", eventsHandler.ConfirmEvent)
+		protectedAPI.GET("/events/:id/escalations", eventsHandler.ListEscalations)
+	}
+
+	// Admin routes, only available when an admin key is configured
+	if s.config.Admin.APIKey != "" {
+		outboxHandler := handlers.NewOutboxHandler(s.repos.OutboundMessage(), s.timeProvider)
+		messageTemplatesHandler := handlers.NewMessageTemplatesHandler(s.repos.MessageTemplate(), s.messages)
+
+		adminAPI := s.router.Group("/api/v1/admin")
+		adminAPI.Use(middleware.AuthenticateAdmin(s.config.Admin.APIKey))
+		{
+			adminAPI.GET("/outbox", outboxHandler.ListOutboundMessages)
+			adminAPI.POST("/outbox/:id/retry", outboxHandler.RetryOutboundMessage)
+			adminAPI.GET("/templates", messageTemplatesHandler.ListMessageTemplates)
+			adminAPI.PUT("/templates/:name", messageTemplatesHandler.UpsertMessageTemplate)
+			adminAPI.DELETE("/templates/:name", messageTemplatesHandler.DeleteMessageTemplate)
+		}
 	}
 }
 
@@ -15,22 +15,41 @@ import (
 type WebhookHandler struct {
 	messageUseCase *usecase.MessageUseCase
 	verifier       ports.WhatsAppWebhookVerifier
+	parser         whatsapp.InboundParser
 	logger         *zap.Logger
 }
 
 func NewWebhookHandler(
 	messageUseCase *usecase.MessageUseCase,
 	verifier ports.WhatsAppWebhookVerifier,
+	parser whatsapp.InboundParser,
 	logger *zap.Logger,
 ) *WebhookHandler {
 	return &WebhookHandler{
 		messageUseCase: messageUseCase,
 		verifier:       verifier,
+		parser:         parser,
 		logger:         logger,
 	}
 }
 
-func (h *WebhookHandler) HandleWhatsAppWebhook(c *gin.Context) {
+// VerifyWhatsAppWebhook answers the GET handshake providers such as Meta make
+// when the webhook is subscribed, echoing hub.challenge once the verify
+// token matches.
+func (h *WebhookHandler) VerifyWhatsAppWebhook(c *gin.Context) {
+	subscriptions, ok := h.verifier.(ports.WhatsAppSubscriptionVerifier)
+	if !ok || !subscriptions.VerifySubscription(c.Query("hub.mode"), c.Query("hub.verify_token")) {
+		h.logger.Warn("Rejected webhook verification", zap.String("from", c.ClientIP()))
+		c.JSON(http.StatusForbidden, gin.H{"error": "Verification failed"})
+		return
+	}
+
+	c.String(http.StatusOK, c.Query("hub.challenge"))
+}
+
+// HandleWebhook verifies and parses the messages a channel posts, then
+// processes each of them in the background.
+func (h *WebhookHandler) HandleWebhook(c *gin.Context) {
 	body, err := io.ReadAll(c.Request.Body)
 	if err != nil {
 		h.logger.Error("Failed to read request body", zap.Error(err))
@@ -38,7 +57,7 @@ func (h *WebhookHandler) HandleWhatsAppWebhook(c *gin.Context) {
 		return
 	}
 
-	signature := c.GetHeader("X-Signature-256")
+	signature := c.GetHeader(h.parser.SignatureHeader())
 	if !h.verifier.VerifySignature(body, signature) {
 		h.logger.Warn("Invalid webhook signature",
 			zap.String("signature", signature),
@@ -48,15 +67,14 @@ func (h *WebhookHandler) HandleWhatsAppWebhook(c *gin.Context) {
 		return
 	}
 
-	var webhookRequest whatsapp.InfobipWebhookRequest
-	if err := c.ShouldBindJSON(&webhookRequest); err != nil {
+	messages, err := h.parser.Parse(body)
+	if err != nil {
 		h.logger.Error("Failed to parse webhook payload", zap.Error(err))
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
 		return
 	}
 
-	messages := webhookRequest.ExtractMessages()
-	h.logger.Info("Received WhatsApp messages", zap.Int("count", len(messages)))
+	h.logger.Info("Received inbound messages", zap.Int("count", len(messages)))
 
 	for _, message := range messages {
 		go func(msg whatsapp.ParsedMessage) {
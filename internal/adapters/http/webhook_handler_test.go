@@ -60,7 +60,7 @@ func TestWebhookHandler_HandleWhatsAppWebhook_Success(t *testing.T) {
 	// mockMessageUseCase.On("ProcessInboundMessage", mock.AnythingOfType("*gin.Context"), mock.AnythingOfType("whatsapp.ParsedMessage")).Return(nil)
 
 	// router := gin.New()
-	// router.POST("/webhook/whatsapp", handler.HandleWhatsAppWebhook)
+	// router.POST("/webhook/whatsapp", handler.HandleWebhook)
 
 	// req := httptest.NewRequest("POST", "/webhook/whatsapp", bytes.NewString(payload))
 	// req.Header.Set("Content-Type", "application/json")
@@ -92,7 +92,7 @@ func TestWebhookHandler_HandleWhatsAppWebhook_InvalidSignature(t *testing.T) {
 	// mockVerifier.On("VerifySignature", mock.AnythingOfType("[]uint8"), "invalid-signature").Return(false)
 
 	// router := gin.New()
-	// router.POST("/webhook/whatsapp", handler.HandleWhatsAppWebhook)
+	// router.POST("/webhook/whatsapp", handler.HandleWebhook)
 
 	// req := httptest.NewRequest("POST", "/webhook/whatsapp", bytes.NewString(payload))
 	// req.Header.Set("Content-Type", "application/json")
@@ -125,7 +125,7 @@ func TestWebhookHandler_HandleWhatsAppWebhook_InvalidJSON(t *testing.T) {
 	// mockVerifier.On("VerifySignature", mock.AnythingOfType("[]uint8"), "valid-signature").Return(true)
 
 	// router := gin.New()
-	// router.POST("/webhook/whatsapp", handler.HandleWhatsAppWebhook)
+	// router.POST("/webhook/whatsapp", handler.HandleWebhook)
 
 	// req := httptest.NewRequest("POST", "/webhook/whatsapp", bytes.NewString(payload))
 	// req.Header.Set("Content-Type", "application/json")
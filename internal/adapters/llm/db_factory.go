@@ -35,3 +35,18 @@ func NewLLMClientFromDB(ctx context.Context, repo ports.LLMConfigRepository, cfg
 
 	return NewLLMClient(model.Provider.Name, apiKey, model.Name)
 }
+
+// DBClientFactory creates each user's LLM client from their database
+// configuration.
+type DBClientFactory struct {
+	repo ports.LLMConfigRepository
+	cfg  *config.Config
+}
+
+func NewDBClientFactory(repo ports.LLMConfigRepository, cfg *config.Config) *DBClientFactory {
+	return &DBClientFactory{repo: repo, cfg: cfg}
+}
+
+func (f *DBClientFactory) ForUser(ctx context.Context, userID int) (ports.LLMClient, error) {
+	return NewLLMClientFromDB(ctx, f.repo, f.cfg, userID)
+}
@@ -0,0 +1,75 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// eventChangesChannel is the channel the notify_event_change trigger
+// publishes event IDs on.
+const eventChangesChannel = "event_changes"
+
+// listenerPingInterval keeps an idle LISTEN connection checked, so a dropped
+// connection is noticed and re-established.
+const listenerPingInterval = 90 * time.Second
+
+// EventChangeListener follows event changes through Postgres LISTEN/NOTIFY,
+// which covers writes made by every instance.
+type EventChangeListener struct {
+	dsn string
+}
+
+func NewEventChangeListener(dsn string) *EventChangeListener {
+	return &EventChangeListener{dsn: dsn}
+}
+
+func (l *EventChangeListener) Subscribe(ctx context.Context) (<-chan int, error) {
+	listener := pq.NewListener(l.dsn, time.Second, time.Minute, nil)
+	if err := listener.Listen(eventChangesChannel); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to listen for event changes: %w", err)
+	}
+
+	changes := make(chan int, 256)
+	go func() {
+		defer close(changes)
+		defer listener.Close()
+
+		ping := time.NewTicker(listenerPingInterval)
+		defer ping.Stop()
+
+		for {
+			var eventID int
+			select {
+			case <-ctx.Done():
+				return
+			case <-ping.C:
+				// Errors surface as a reconnect, reported below.
+				_ = listener.Ping()
+				continue
+			case notification := <-listener.Notify:
+				// A nil notification follows a reconnect: anything sent
+				// while the connection was down is lost.
+				if notification != nil {
+					id, err := strconv.Atoi(notification.Extra)
+					if err != nil {
+						continue
+					}
+					eventID = id
+				}
+			}
+
+			select {
+			case changes <- eventID:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return changes, nil
+}
@@ -0,0 +1,64 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+const eventEscalationColumns = `id, event_id, user_id, occurrence_starts_at, step, contact_number,
+		       unanswered_reminders, status, notified_at, canceled_at, created_at`
+
+type EventEscalationRepository struct {
+	db QueryExecutor
+}
+
+func NewEventEscalationRepository(db QueryExecutor) ports.EventEscalationRepository {
+	return &EventEscalationRepository{db: db}
+}
+
+func (r *EventEscalationRepository) Create(ctx context.Context, escalation *domain.EventEscalation) error {
+	query := `
+		INSERT INTO event_escalations (event_id, user_id, occurrence_starts_at, step, contact_number,
+		                               unanswered_reminders, status, notified_at)
+		VALUES (:event_id, :user_id, :occurrence_starts_at, :step, :contact_number,
+		        :unanswered_reminders, :status, :notified_at)
+		RETURNING id, created_at`
+
+	return namedGetContext(ctx, r.db, escalation, query, escalation)
+}
+
+// ListByEventID returns the escalation history of an event, oldest first.
+func (r *EventEscalationRepository) ListByEventID(ctx context.Context, eventID int) ([]domain.EventEscalation, error) {
+	escalations := []domain.EventEscalation{}
+	query := `
+		SELECT ` + eventEscalationColumns + `
+		FROM event_escalations
+		WHERE event_id = $1
+		ORDER BY notified_at ASC, id ASC`
+
+	if err := r.db.SelectContext(ctx, &escalations, query, eventID); err != nil {
+		return nil, err
+	}
+
+	return escalations, nil
+}
+
+// CancelOpen marks the escalations of an occurrence that are still open as
+// canceled and returns them.
+func (r *EventEscalationRepository) CancelOpen(ctx context.Context, eventID int, occurrenceStartsAt, canceledAt time.Time) ([]domain.EventEscalation, error) {
+	var escalations []domain.EventEscalation
+	query := `
+		UPDATE event_escalations
+		SET status = 'canceled', canceled_at = $3
+		WHERE event_id = $1 AND occurrence_starts_at = $2 AND status = 'notified'
+		RETURNING ` + eventEscalationColumns
+
+	if err := r.db.SelectContext(ctx, &escalations, query, eventID, occurrenceStartsAt, canceledAt); err != nil {
+		return nil, err
+	}
+
+	return escalations, nil
+}
@@ -0,0 +1,67 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+type EventExceptionRepository struct {
+	db QueryExecutor
+}
+
+func NewEventExceptionRepository(db QueryExecutor) ports.EventExceptionRepository {
+	return &EventExceptionRepository{db: db}
+}
+
+func (r *EventExceptionRepository) Upsert(ctx context.Context, exception *domain.EventException) error {
+	query := `
+		INSERT INTO event_exceptions (event_id, original_starts_at, status, starts_at, title, location)
+		VALUES (:event_id, :original_starts_at, :status, :starts_at, :title, :location)
+		ON CONFLICT (event_id, original_starts_at)
+		DO UPDATE SET status = EXCLUDED.status, starts_at = EXCLUDED.starts_at,
+		              title = EXCLUDED.title, location = EXCLUDED.location, updated_at = NOW()`
+
+	_, err := r.db.NamedExecContext(ctx, query, exception)
+	return err
+}
+
+func (r *EventExceptionRepository) ListByEventIDs(ctx context.Context, eventIDs []int) ([]domain.EventException, error) {
+	var exceptions []domain.EventException
+	if len(eventIDs) == 0 {
+		return exceptions, nil
+	}
+
+	query := `
+		SELECT id, event_id, original_starts_at, status, starts_at, title, location, created_at, updated_at
+		FROM event_exceptions
+		WHERE event_id = ANY($1)
+		ORDER BY event_id, original_starts_at ASC`
+
+	ids := make(pq.Int64Array, len(eventIDs))
+	for i, id := range eventIDs {
+		ids[i] = int64(id)
+	}
+
+	err := r.db.SelectContext(ctx, &exceptions, query, ids)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []domain.EventException{}, nil
+		}
+		return nil, err
+	}
+
+	return exceptions, nil
+}
+
+func (r *EventExceptionRepository) DeleteFrom(ctx context.Context, eventID int, from time.Time) error {
+	query := "DELETE FROM event_exceptions WHERE event_id = $1 AND original_starts_at >= $2"
+	_, err := r.db.ExecContext(ctx, query, eventID, from)
+	return err
+}
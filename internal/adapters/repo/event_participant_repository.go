@@ -0,0 +1,85 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+const participantColumns = `p.id, p.event_id, p.name, p.wa_number, p.status, p.invited_at,
+		       p.responded_at, p.created_at, p.updated_at`
+
+type EventParticipantRepository struct {
+	db QueryExecutor
+}
+
+func NewEventParticipantRepository(db QueryExecutor) ports.EventParticipantRepository {
+	return &EventParticipantRepository{db: db}
+}
+
+func (r *EventParticipantRepository) Create(ctx context.Context, participant *domain.EventParticipant) error {
+	query := `
+		INSERT INTO event_participants (event_id, name, wa_number, status, invited_at)
+		VALUES (:event_id, :name, :wa_number, :status, :invited_at)
+		RETURNING id, created_at, updated_at`
+
+	return namedGetContext(ctx, r.db, participant, query, participant)
+}
+
+func (r *EventParticipantRepository) UpdateStatus(ctx context.Context, id int, status domain.ParticipantStatus) error {
+	query := "UPDATE event_participants SET status = $2, responded_at = NOW(), updated_at = NOW() WHERE id = $1"
+	_, err := r.db.ExecContext(ctx, query, id, status)
+	return err
+}
+
+func (r *EventParticipantRepository) MarkInvited(ctx context.Context, id int, invitedAt time.Time) error {
+	query := "UPDATE event_participants SET invited_at = $2, updated_at = NOW() WHERE id = $1"
+	_, err := r.db.ExecContext(ctx, query, id, invitedAt)
+	return err
+}
+
+func (r *EventParticipantRepository) ListByEventID(ctx context.Context, eventID int) ([]domain.EventParticipant, error) {
+	var participants []domain.EventParticipant
+	query := `
+		SELECT ` + participantColumns + `
+		FROM event_participants p
+		WHERE p.event_id = $1
+		ORDER BY p.id ASC`
+
+	err := r.db.SelectContext(ctx, &participants, query, eventID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []domain.EventParticipant{}, nil
+		}
+		return nil, err
+	}
+
+	return participants, nil
+}
+
+func (r *EventParticipantRepository) ListActiveInvitationsByNumber(ctx context.Context, waNumber string, now time.Time) ([]domain.EventParticipant, error) {
+	var participants []domain.EventParticipant
+	query := `
+		SELECT ` + participantColumns + `
+		FROM event_participants p
+		JOIN events e ON p.event_id = e.id
+		WHERE p.wa_number = $1
+		  AND p.invited_at IS NOT NULL
+		  AND e.status IN ('scheduled', 'confirmed')
+		  AND COALESCE(e.next_occurrence_at, e.starts_at) > $2
+		ORDER BY p.invited_at DESC`
+
+	err := r.db.SelectContext(ctx, &participants, query, domain.NormalizeNumber(waNumber), now)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []domain.EventParticipant{}, nil
+		}
+		return nil, err
+	}
+
+	return participants, nil
+}
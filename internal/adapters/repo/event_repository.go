@@ -12,6 +12,34 @@ import (
 	"github.com/alarm-agent/internal/ports"
 )
 
+const eventColumns = `e.id, e.user_id, e.title, e.location, e.starts_at, e.ends_at, e.all_day, e.recurrence_rule,
+		       e.next_occurrence_at, e.remind_before_minutes, e.remind_frequency_minutes,
+		       e.require_confirmation, e.max_notifications, e.status, e.notifications_sent,
+		       e.last_notified_at, e.snoozed_until, e.deferred_until, e.ignore_quiet_hours, e.priority, e.escalation_policy, e.escalation_level,
+		       e.created_at, e.updated_at,
+		       ARRAY(SELECT r.offset_minutes FROM event_reminders r
+		             WHERE r.event_id = e.id ORDER BY r.offset_minutes DESC) AS reminder_offsets`
+
+// reminderAnchor mirrors domain.Event.ReminderAnchor: reminders of all-day
+// events count back from 09:00 on their first day.
+const reminderAnchor = `(COALESCE(e.next_occurrence_at, e.starts_at) + CASE WHEN e.all_day THEN INTERVAL '9 hours' ELSE INTERVAL '0' END)`
+
+// awaitsReminders mirrors domain.Event.AwaitsReminders against $1.
+const awaitsReminders = `(` + reminderAnchor + ` > $1
+		           OR (e.ends_at IS NOT NULL
+		               AND COALESCE(e.next_occurrence_at, e.starts_at) + (e.ends_at - e.starts_at) > $1))`
+
+var eventUserColumns = `u.id as "user.id", ` + userContactColumns("u", "user.") + `,
+		       u.preferred_channel as "user.preferred_channel", u.name as "user.name",
+		       u.timezone as "user.timezone", u.locale as "user.locale",
+		       u.default_remind_before_minutes as "user.default_remind_before_minutes",
+		       u.default_remind_frequency_minutes as "user.default_remind_frequency_minutes",
+		       u.default_require_confirmation as "user.default_require_confirmation",
+		       u.default_reminder_offsets as "user.default_reminder_offsets",
+		       u.quiet_hours as "user.quiet_hours", u.notify_no_response as "user.notify_no_response",
+		       u.default_escalation_policy as "user.default_escalation_policy",
+		       u.created_at as "user.created_at", u.updated_at as "user.updated_at"`
+
 type EventRepository struct {
 	db QueryExecutor
 }
@@ -22,29 +50,23 @@ func NewEventRepository(db QueryExecutor) ports.EventRepository {
 
 func (r *EventRepository) Create(ctx context.Context, event *domain.Event) error {
 	query := `
-		INSERT INTO events (user_id, title, location, starts_at, remind_before_minutes, 
-		                   remind_frequency_minutes, require_confirmation, max_notifications, status)
-		VALUES (:user_id, :title, :location, :starts_at, :remind_before_minutes, 
-		        :remind_frequency_minutes, :require_confirmation, :max_notifications, :status)
+		INSERT INTO events (user_id, title, location, starts_at, ends_at, all_day, recurrence_rule, next_occurrence_at,
+		                   remind_before_minutes, remind_frequency_minutes, require_confirmation,
+		                   max_notifications, status, ignore_quiet_hours, priority, escalation_policy)
+		VALUES (:user_id, :title, :location, :starts_at, :ends_at, :all_day, :recurrence_rule, :next_occurrence_at,
+		        :remind_before_minutes, :remind_frequency_minutes, :require_confirmation,
+		        :max_notifications, :status, :ignore_quiet_hours, :priority, :escalation_policy)
 		RETURNING id, created_at, updated_at`
 
-	rows, err := r.db.NamedExecContext(ctx, query, event)
-	if err != nil {
-		return err
-	}
-
-	id, err := rows.LastInsertId()
-	if err == nil && id > 0 {
-		event.ID = int(id)
-	}
-
-	return nil
+	return namedGetContext(ctx, r.db, event, query, event)
 }
 
 func (r *EventRepository) Update(ctx context.Context, event *domain.Event) error {
 	query := `
 		UPDATE events 
 		SET title = :title, location = :location, starts_at = :starts_at,
+		    ends_at = :ends_at, all_day = :all_day,
+		    recurrence_rule = :recurrence_rule, next_occurrence_at = :next_occurrence_at,
 		    remind_before_minutes = :remind_before_minutes,
 		    remind_frequency_minutes = :remind_frequency_minutes,
 		    require_confirmation = :require_confirmation,
@@ -52,6 +74,12 @@ func (r *EventRepository) Update(ctx context.Context, event *domain.Event) error
 		    status = :status,
 		    notifications_sent = :notifications_sent,
 		    last_notified_at = :last_notified_at,
+		    snoozed_until = :snoozed_until,
+		    deferred_until = :deferred_until,
+		    ignore_quiet_hours = :ignore_quiet_hours,
+		    priority = :priority,
+		    escalation_policy = :escalation_policy,
+		    escalation_level = :escalation_level,
 		    updated_at = NOW()
 		WHERE id = :id`
 
@@ -59,6 +87,23 @@ func (r *EventRepository) Update(ctx context.Context, event *domain.Event) error
 	return err
 }
 
+// ReplaceReminderOffsets stores the event's reminder schedule, replacing any
+// previous one. An empty schedule removes it.
+func (r *EventRepository) ReplaceReminderOffsets(ctx context.Context, eventID int, offsets domain.ReminderSchedule) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM event_reminders WHERE event_id = $1", eventID); err != nil {
+		return err
+	}
+
+	for _, offset := range offsets {
+		query := "INSERT INTO event_reminders (event_id, offset_minutes) VALUES ($1, $2)"
+		if _, err := r.db.ExecContext(ctx, query, eventID, offset); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *EventRepository) Delete(ctx context.Context, id int) error {
 	query := "DELETE FROM events WHERE id = $1"
 	_, err := r.db.ExecContext(ctx, query, id)
@@ -68,11 +113,9 @@ func (r *EventRepository) Delete(ctx context.Context, id int) error {
 func (r *EventRepository) GetByID(ctx context.Context, id int) (*domain.Event, error) {
 	var event domain.Event
 	query := `
-		SELECT id, user_id, title, location, starts_at, remind_before_minutes,
-		       remind_frequency_minutes, require_confirmation, max_notifications,
-		       status, notifications_sent, last_notified_at, created_at, updated_at
-		FROM events 
-		WHERE id = $1`
+		SELECT ` + eventColumns + `
+		FROM events e
+		WHERE e.id = $1`
 
 	err := r.db.GetContext(ctx, &event, query, id)
 	if err != nil {
@@ -85,38 +128,33 @@ func (r *EventRepository) GetByID(ctx context.Context, id int) (*domain.Event, e
 	return &event, nil
 }
 
-func (r *EventRepository) GetByUserID(ctx context.Context, userID int) ([]domain.Event, error) {
+// ListActive returns, in ID order, up to limit scheduled or confirmed events
+// with an ID greater than afterID.
+func (r *EventRepository) ListActive(ctx context.Context, afterID, limit int) ([]domain.Event, error) {
 	var events []domain.Event
 	query := `
-		SELECT id, user_id, title, location, starts_at, remind_before_minutes,
-		       remind_frequency_minutes, require_confirmation, max_notifications,
-		       status, notifications_sent, last_notified_at, created_at, updated_at
-		FROM events 
-		WHERE user_id = $1
-		ORDER BY starts_at ASC`
+		SELECT ` + eventColumns + `
+		FROM events e
+		WHERE e.status IN ('scheduled', 'confirmed') AND e.id > $1
+		ORDER BY e.id ASC
+		LIMIT $2`
 
-	err := r.db.SelectContext(ctx, &events, query, userID)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return []domain.Event{}, nil
-		}
+	if err := r.db.SelectContext(ctx, &events, query, afterID, limit); err != nil {
 		return nil, err
 	}
 
 	return events, nil
 }
 
-func (r *EventRepository) GetByUserIDAndDateRange(ctx context.Context, userID int, start, end time.Time) ([]domain.Event, error) {
+func (r *EventRepository) GetByUserID(ctx context.Context, userID int) ([]domain.Event, error) {
 	var events []domain.Event
 	query := `
-		SELECT id, user_id, title, location, starts_at, remind_before_minutes,
-		       remind_frequency_minutes, require_confirmation, max_notifications,
-		       status, notifications_sent, last_notified_at, created_at, updated_at
-		FROM events 
-		WHERE user_id = $1 AND starts_at BETWEEN $2 AND $3
-		ORDER BY starts_at ASC`
+		SELECT ` + eventColumns + `
+		FROM events e
+		WHERE e.user_id = $1
+		ORDER BY e.starts_at ASC`
 
-	err := r.db.SelectContext(ctx, &events, query, userID, start, end)
+	err := r.db.SelectContext(ctx, &events, query, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return []domain.Event{}, nil
@@ -127,39 +165,52 @@ func (r *EventRepository) GetByUserIDAndDateRange(ctx context.Context, userID in
 	return events, nil
 }
 
-func (r *EventRepository) GetPendingReminders(ctx context.Context, reminderWindow time.Duration) ([]domain.EventWithUser, error) {
-	var eventsWithUsers []domain.EventWithUser
-	now := time.Now()
-	windowEnd := now.Add(reminderWindow)
-
+func (r *EventRepository) GetByUserIDAndDateRange(ctx context.Context, userID int, start, end time.Time) ([]domain.Event, error) {
+	var events []domain.Event
 	query := `
-		SELECT 
-		    e.id, e.user_id, e.title, e.location, e.starts_at, e.remind_before_minutes,
-		    e.remind_frequency_minutes, e.require_confirmation, e.max_notifications,
-		    e.status, e.notifications_sent, e.last_notified_at, e.created_at, e.updated_at,
-		    u.id as "user.id", u.wa_number as "user.wa_number", u.name as "user.name", 
-		    u.timezone as "user.timezone", u.default_remind_before_minutes as "user.default_remind_before_minutes",
-		    u.default_remind_frequency_minutes as "user.default_remind_frequency_minutes",
-		    u.default_require_confirmation as "user.default_require_confirmation",
-		    u.created_at as "user.created_at", u.updated_at as "user.updated_at"
+		SELECT ` + eventColumns + `
 		FROM events e
-		JOIN users u ON e.user_id = u.id
-		WHERE e.status IN ('scheduled', 'confirmed')
-		  AND e.notifications_sent < e.max_notifications
-		  AND (e.starts_at - INTERVAL '1 minute' * e.remind_before_minutes) BETWEEN $1 AND $2
-		  AND (e.last_notified_at IS NULL 
-		       OR e.last_notified_at <= $1 - INTERVAL '1 minute' * e.remind_frequency_minutes)
+		WHERE e.user_id = $1
+		  AND ((e.starts_at <= $3 AND COALESCE(e.ends_at, e.starts_at) >= $2)
+		       OR (e.recurrence_rule IS NOT NULL AND e.starts_at <= $3))
 		ORDER BY e.starts_at ASC`
 
-	err := r.db.SelectContext(ctx, &eventsWithUsers, query, now, windowEnd)
+	err := r.db.SelectContext(ctx, &events, query, userID, start, end)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return []domain.EventWithUser{}, nil
+			return []domain.Event{}, nil
 		}
 		return nil, err
 	}
 
-	return eventsWithUsers, nil
+	return events, nil
+}
+
+// ClaimPendingReminders leases the events with a reminder due at now, however
+// long ago it became due, and returns them with their users. Reminders stay
+// owed until the event starts, or until it ends when it has an end, and
+// those deferred for quiet hours wait until the deferral ends.
+func (r *EventRepository) ClaimPendingReminders(ctx context.Context, now time.Time, lease domain.Lease) ([]domain.EventWithUser, error) {
+	condition := `e.status IN ('scheduled', 'confirmed')
+		  AND (e.deferred_until IS NULL OR e.deferred_until <= $1)
+		  AND ((e.snoozed_until IS NOT NULL AND e.snoozed_until <= $1)
+		       OR (e.snoozed_until IS NULL
+		           AND ` + awaitsReminders + `
+		           AND NOT EXISTS (SELECT 1 FROM event_reminders r WHERE r.event_id = e.id)
+		           AND e.notifications_sent < e.max_notifications
+		           AND (` + reminderAnchor + ` - INTERVAL '1 minute' * e.remind_before_minutes) <= $1
+		           AND (e.last_notified_at IS NULL 
+		                OR e.last_notified_at <= $1 - INTERVAL '1 minute' * e.remind_frequency_minutes))
+		       OR (e.snoozed_until IS NULL
+		           AND ` + awaitsReminders + `
+		           AND EXISTS (
+		               SELECT 1 FROM event_reminders r
+		               WHERE r.event_id = e.id
+		                 AND ` + reminderAnchor + ` - INTERVAL '1 minute' * r.offset_minutes <= $1
+		                 AND (e.last_notified_at IS NULL
+		                      OR ` + reminderAnchor + ` - INTERVAL '1 minute' * r.offset_minutes > e.last_notified_at))))`
+
+	return r.claimEvents(ctx, condition, "COALESCE(e.next_occurrence_at, e.starts_at) ASC", now, lease)
 }
 
 func (r *EventRepository) FindByUserAndIdentifier(ctx context.Context, userID int, identifier *domain.EventIdentifier) ([]domain.Event, error) {
@@ -168,29 +219,29 @@ func (r *EventRepository) FindByUserAndIdentifier(ctx context.Context, userID in
 	var args []interface{}
 
 	baseQuery := `
-		SELECT id, user_id, title, location, starts_at, remind_before_minutes,
-		       remind_frequency_minutes, require_confirmation, max_notifications,
-		       status, notifications_sent, last_notified_at, created_at, updated_at
-		FROM events 
-		WHERE user_id = $1`
+		SELECT ` + eventColumns + `
+		FROM events e
+		WHERE e.user_id = $1`
 
 	args = append(args, userID)
 	argIndex := 2
 
 	if identifier.EventID != nil {
-		conditions = append(conditions, fmt.Sprintf("id = $%d", argIndex))
+		conditions = append(conditions, fmt.Sprintf("e.id = $%d", argIndex))
 		args = append(args, *identifier.EventID)
 		argIndex++
 	}
 
 	if identifier.Title != nil {
-		conditions = append(conditions, fmt.Sprintf("LOWER(title) LIKE LOWER($%d)", argIndex))
+		conditions = append(conditions, fmt.Sprintf("LOWER(e.title) LIKE LOWER($%d)", argIndex))
 		args = append(args, "%"+*identifier.Title+"%")
 		argIndex++
 	}
 
 	if identifier.DateHint != nil {
-		conditions = append(conditions, fmt.Sprintf("DATE(starts_at) = $%d", argIndex))
+		conditions = append(conditions, fmt.Sprintf(
+			"(DATE(e.starts_at) = $%d OR (e.recurrence_rule IS NOT NULL AND DATE(e.starts_at) <= $%d))",
+			argIndex, argIndex))
 		args = append(args, *identifier.DateHint)
 	}
 
@@ -198,7 +249,7 @@ func (r *EventRepository) FindByUserAndIdentifier(ctx context.Context, userID in
 		baseQuery += " AND (" + strings.Join(conditions, " OR ") + ")"
 	}
 
-	baseQuery += " ORDER BY starts_at ASC"
+	baseQuery += " ORDER BY e.starts_at ASC"
 
 	err := r.db.SelectContext(ctx, &events, baseQuery, args...)
 	if err != nil {
@@ -208,5 +259,188 @@ func (r *EventRepository) FindByUserAndIdentifier(ctx context.Context, userID in
 		return nil, err
 	}
 
-	return events, nil
+	return r.resolveOccurrences(ctx, userID, events, identifier)
+}
+
+// resolveOccurrences loads the exceptions of recurring matches and, when the
+// identifier points at a specific date or occurrence, replaces each series
+// with that occurrence. Series matched only by the date condition but
+// without an occurrence on that date are dropped.
+func (r *EventRepository) resolveOccurrences(ctx context.Context, userID int, events []domain.Event, identifier *domain.EventIdentifier) ([]domain.Event, error) {
+	var recurringIDs []int
+	for _, event := range events {
+		if event.IsRecurring() {
+			recurringIDs = append(recurringIDs, event.ID)
+		}
+	}
+	if len(recurringIDs) == 0 {
+		return events, nil
+	}
+
+	exceptions, err := NewEventExceptionRepository(r.db).ListByEventIDs(ctx, recurringIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load event exceptions: %w", err)
+	}
+
+	var timezone string
+	if err := r.db.GetContext(ctx, &timezone, "SELECT COALESCE(timezone, '') FROM users WHERE id = $1", userID); err != nil {
+		return nil, fmt.Errorf("failed to load user timezone: %w", err)
+	}
+	loc := (&domain.User{Timezone: timezone}).Location()
+
+	pointsAtOccurrence := identifier.DateHint != nil || identifier.OccurrenceStartsAt != nil
+
+	resolved := make([]domain.Event, 0, len(events))
+	for _, event := range events {
+		if !event.IsRecurring() {
+			resolved = append(resolved, event)
+			continue
+		}
+
+		for _, exception := range exceptions {
+			if exception.EventID == event.ID {
+				event.Exceptions = append(event.Exceptions, exception)
+			}
+		}
+
+		if !pointsAtOccurrence {
+			resolved = append(resolved, event)
+			continue
+		}
+
+		if occurrence, ok := event.ResolveOccurrence(identifier, loc); ok {
+			resolved = append(resolved, occurrence)
+			continue
+		}
+
+		matchedByID := identifier.EventID != nil && *identifier.EventID == event.ID
+		matchedByTitle := identifier.Title != nil &&
+			strings.Contains(strings.ToLower(event.Title), strings.ToLower(*identifier.Title))
+		if matchedByID || matchedByTitle {
+			resolved = append(resolved, event)
+		}
+	}
+
+	return resolved, nil
+}
+
+func (r *EventRepository) GetLastNotifiedByUserID(ctx context.Context, userID int) (*domain.Event, error) {
+	var event domain.Event
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events e
+		WHERE e.user_id = $1
+		  AND e.status IN ('scheduled', 'confirmed')
+		  AND e.last_notified_at IS NOT NULL
+		ORDER BY e.last_notified_at DESC
+		LIMIT 1`
+
+	err := r.db.GetContext(ctx, &event, query, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// GetTentativeByUserID returns the user's most recently held event that has
+// not started yet, if any.
+func (r *EventRepository) GetTentativeByUserID(ctx context.Context, userID int, now time.Time) (*domain.Event, error) {
+	var event domain.Event
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events e
+		WHERE e.user_id = $1
+		  AND e.status = 'tentative'
+		  AND COALESCE(e.next_occurrence_at, e.starts_at) > $2
+		ORDER BY e.created_at DESC
+		LIMIT 1`
+
+	err := r.db.GetContext(ctx, &event, query, userID, now)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// ClaimRecurringEventsToAdvance leases the recurring series whose current
+// occurrence started before now.
+func (r *EventRepository) ClaimRecurringEventsToAdvance(ctx context.Context, now time.Time, lease domain.Lease) ([]domain.EventWithUser, error) {
+	condition := `e.recurrence_rule IS NOT NULL
+		  AND e.status IN ('scheduled', 'confirmed')
+		  AND COALESCE(e.next_occurrence_at, e.starts_at) < $1`
+
+	return r.claimEvents(ctx, condition, "e.id ASC", now, lease)
+}
+
+// ClaimEventsToClose leases the single events that may have reached the end
+// of their lifecycle at now: those still waiting for a confirmation past
+// their reminder anchor and those that have finished.
+func (r *EventRepository) ClaimEventsToClose(ctx context.Context, now time.Time, lease domain.Lease) ([]domain.EventWithUser, error) {
+	condition := `e.recurrence_rule IS NULL
+		  AND e.status IN ('scheduled', 'confirmed')
+		  AND e.starts_at <= $1
+		  AND ((e.status = 'scheduled' AND e.require_confirmation AND ` + reminderAnchor + ` <= $1)
+		       OR COALESCE(e.ends_at, e.starts_at) <= $1)`
+
+	return r.claimEvents(ctx, condition, "e.id ASC", now, lease)
+}
+
+// ReleaseClaim gives up the owner's lease on an event so it can be claimed
+// again before the lease expires.
+func (r *EventRepository) ReleaseClaim(ctx context.Context, eventID int, owner string) error {
+	query := `
+		UPDATE events
+		SET leased_by = NULL, leased_until = NULL
+		WHERE id = $1 AND leased_by = $2`
+
+	_, err := r.db.ExecContext(ctx, query, eventID, owner)
+	return err
+}
+
+// claimEvents leases the events matching condition to lease.Owner and returns
+// them with their users. Events leased by another owner until after now, or
+// locked by a concurrent claim, are skipped, so each event is handed to a
+// single worker instance. Postgres rechecks condition against rows updated
+// since the claim started, so an event processed and released meanwhile is
+// not claimed again. In condition, $1 is now and extra args start at $4.
+func (r *EventRepository) claimEvents(ctx context.Context, condition, orderBy string, now time.Time, lease domain.Lease, args ...interface{}) ([]domain.EventWithUser, error) {
+	var eventsWithUsers []domain.EventWithUser
+
+	query := `
+		WITH claimed AS (
+			UPDATE events
+			SET leased_by = $2, leased_until = $3
+			WHERE id IN (
+				SELECT e.id
+				FROM events e
+				WHERE (e.leased_until IS NULL OR e.leased_until <= $1 OR e.leased_by = $2)
+				  AND ` + condition + `
+				FOR UPDATE OF e SKIP LOCKED)
+			RETURNING id
+		)
+		SELECT ` + eventColumns + `,
+		       ` + eventUserColumns + `
+		FROM events e
+		JOIN users u ON e.user_id = u.id
+		WHERE e.id IN (SELECT id FROM claimed)
+		ORDER BY ` + orderBy
+
+	args = append([]interface{}{now, lease.Owner, lease.ExpiresAt}, args...)
+	err := r.db.SelectContext(ctx, &eventsWithUsers, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []domain.EventWithUser{}, nil
+		}
+		return nil, err
+	}
+
+	return eventsWithUsers, nil
 }
@@ -0,0 +1,104 @@
+//go:build integration
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alarm-agent/internal/domain"
+)
+
+// testRepositories connects to the database in TEST_POSTGRES_DSN, which must
+// have every migration applied, and skips the test when it is unset.
+func testRepositories(t *testing.T) *PostgresRepositories {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+
+	repos, err := NewPostgresRepositories(dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = repos.Close() })
+	return repos
+}
+
+func TestEventRepository_ClaimPendingReminders(t *testing.T) {
+	repos := testRepositories(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	user := &domain.User{Timezone: "UTC", IsActive: true}
+	require.NoError(t, repos.User().Create(ctx, user))
+	t.Cleanup(func() {
+		_, _ = repos.db.ExecContext(context.Background(), "DELETE FROM users WHERE id = $1", user.ID)
+	})
+	require.NoError(t, repos.UserIdentity().Create(ctx, &domain.UserIdentity{
+		UserID:     user.ID,
+		Channel:    domain.ChannelWhatsApp,
+		ExternalID: fmt.Sprintf("+55119%08d", now.UnixNano()%100_000_000),
+		Verified:   true,
+	}))
+
+	const events = 20
+	ids := make(map[int]bool)
+	for i := 0; i < events; i++ {
+		event := &domain.Event{
+			UserID:              user.ID,
+			Title:               fmt.Sprintf("Evento %d", i),
+			StartsAt:            now.Add(30 * time.Minute),
+			RemindBeforeMinutes: 60,
+			MaxNotifications:    3,
+			Status:              domain.EventStatusScheduled,
+			Priority:            domain.PriorityNormal,
+		}
+		require.NoError(t, repos.Event().Create(ctx, event))
+		ids[event.ID] = true
+	}
+
+	// Instances claiming at the same time each get a disjoint share of the
+	// due events, and every event is claimed.
+	owners := []string{"worker-a", "worker-b", "worker-c", "worker-d"}
+	claims := make([][]domain.EventWithUser, len(owners))
+	errs := make([]error, len(owners))
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i, owner := range owners {
+		wg.Add(1)
+		go func(i int, owner string) {
+			defer wg.Done()
+			<-start
+			claims[i], errs[i] = repos.Event().ClaimPendingReminders(ctx, now, domain.Lease{Owner: owner, ExpiresAt: now.Add(time.Minute)})
+		}(i, owner)
+	}
+	close(start)
+	wg.Wait()
+
+	claimedBy := make(map[int]string)
+	for i, owner := range owners {
+		require.NoError(t, errs[i])
+		for _, claimed := range claims[i] {
+			if !ids[claimed.ID] {
+				continue // due events of other users in the database
+			}
+			previous, taken := claimedBy[claimed.ID]
+			assert.False(t, taken, "event %d claimed by %s and %s", claimed.ID, previous, owner)
+			claimedBy[claimed.ID] = owner
+		}
+	}
+	assert.Len(t, claimedBy, events)
+
+	// Leased events are skipped by other owners until the lease expires.
+	again, err := repos.Event().ClaimPendingReminders(ctx, now, domain.Lease{Owner: "worker-e", ExpiresAt: now.Add(time.Minute)})
+	require.NoError(t, err)
+	for _, claimed := range again {
+		assert.False(t, ids[claimed.ID], "event %d claimed while leased", claimed.ID)
+	}
+}
@@ -0,0 +1,73 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+const messageTemplateColumns = `id, user_id, locale, name, body, created_at, updated_at`
+
+type MessageTemplateRepository struct {
+	db QueryExecutor
+}
+
+func NewMessageTemplateRepository(db QueryExecutor) ports.MessageTemplateRepository {
+	return &MessageTemplateRepository{db: db}
+}
+
+func (r *MessageTemplateRepository) Find(ctx context.Context, userID int, locale domain.Locale, name string) (*domain.MessageTemplate, error) {
+	var template domain.MessageTemplate
+	query := `
+		SELECT ` + messageTemplateColumns + `
+		FROM message_templates
+		WHERE name = $3 AND locale = $2 AND (user_id = $1 OR user_id IS NULL)
+		ORDER BY user_id NULLS LAST
+		LIMIT 1`
+
+	err := r.db.GetContext(ctx, &template, query, userID, locale, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// List returns the overrides of a user, or the global ones for a nil user.
+func (r *MessageTemplateRepository) List(ctx context.Context, userID *int) ([]domain.MessageTemplate, error) {
+	templates := []domain.MessageTemplate{}
+	query := `
+		SELECT ` + messageTemplateColumns + `
+		FROM message_templates
+		WHERE user_id IS NOT DISTINCT FROM $1
+		ORDER BY name, locale`
+
+	if err := r.db.SelectContext(ctx, &templates, query, userID); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (r *MessageTemplateRepository) Upsert(ctx context.Context, template *domain.MessageTemplate) error {
+	query := `
+		INSERT INTO message_templates (user_id, locale, name, body)
+		VALUES (:user_id, :locale, :name, :body)
+		ON CONFLICT ((COALESCE(user_id, 0)), locale, name)
+		DO UPDATE SET body = EXCLUDED.body, updated_at = NOW()
+		RETURNING id, created_at, updated_at`
+
+	return namedGetContext(ctx, r.db, template, query, template)
+}
+
+func (r *MessageTemplateRepository) Delete(ctx context.Context, userID *int, locale domain.Locale, name string) error {
+	query := "DELETE FROM message_templates WHERE user_id IS NOT DISTINCT FROM $1 AND locale = $2 AND name = $3"
+	_, err := r.db.ExecContext(ctx, query, userID, locale, name)
+	return err
+}
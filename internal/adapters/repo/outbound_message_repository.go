@@ -0,0 +1,119 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+const outboundMessageColumns = `id, channel, to_number, body, interactive, template, status, attempts, next_attempt_at, last_error,
+		       sent_at, created_at, updated_at`
+
+type OutboundMessageRepository struct {
+	db QueryExecutor
+}
+
+func NewOutboundMessageRepository(db QueryExecutor) ports.OutboundMessageRepository {
+	return &OutboundMessageRepository{db: db}
+}
+
+func (r *OutboundMessageRepository) Create(ctx context.Context, message *domain.OutboundMessage) error {
+	query := `
+		INSERT INTO outbound_messages (channel, to_number, body, interactive, template, status, next_attempt_at)
+		VALUES (:channel, :to_number, :body, :interactive, :template, :status, :next_attempt_at)
+		RETURNING id, created_at, updated_at`
+
+	return namedGetContext(ctx, r.db, message, query, message)
+}
+
+// Update stores the outcome of a delivery attempt and releases the lease
+// taken by ClaimDue.
+func (r *OutboundMessageRepository) Update(ctx context.Context, message *domain.OutboundMessage) error {
+	query := `
+		UPDATE outbound_messages
+		SET status = :status, attempts = :attempts, next_attempt_at = :next_attempt_at,
+		    last_error = :last_error, sent_at = :sent_at,
+		    leased_by = NULL, leased_until = NULL,
+		    updated_at = NOW()
+		WHERE id = :id`
+
+	_, err := r.db.NamedExecContext(ctx, query, message)
+	return err
+}
+
+func (r *OutboundMessageRepository) GetByID(ctx context.Context, id int) (*domain.OutboundMessage, error) {
+	var message domain.OutboundMessage
+	query := `SELECT ` + outboundMessageColumns + ` FROM outbound_messages WHERE id = $1`
+
+	err := r.db.GetContext(ctx, &message, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &message, nil
+}
+
+// ClaimDue leases up to limit pending messages whose next attempt is due at
+// now, oldest first. Messages leased by another dispatcher instance are
+// skipped until the lease expires.
+func (r *OutboundMessageRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease domain.Lease) ([]domain.OutboundMessage, error) {
+	var messages []domain.OutboundMessage
+	query := `
+		UPDATE outbound_messages
+		SET leased_by = $2, leased_until = $3
+		WHERE id IN (
+			SELECT id
+			FROM outbound_messages
+			WHERE status = 'pending'
+			  AND next_attempt_at <= $1
+			  AND (leased_until IS NULL OR leased_until <= $1 OR leased_by = $2)
+			ORDER BY next_attempt_at ASC
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED)
+		RETURNING ` + outboundMessageColumns
+
+	err := r.db.SelectContext(ctx, &messages, query, now, lease.Owner, lease.ExpiresAt, limit)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []domain.OutboundMessage{}, nil
+		}
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (r *OutboundMessageRepository) ListByStatus(ctx context.Context, status domain.OutboundMessageStatus, limit, offset int) ([]domain.OutboundMessage, error) {
+	var messages []domain.OutboundMessage
+	query := `
+		SELECT ` + outboundMessageColumns + `
+		FROM outbound_messages
+		WHERE status = $1
+		ORDER BY updated_at DESC
+		LIMIT $2 OFFSET $3`
+
+	err := r.db.SelectContext(ctx, &messages, query, status, limit, offset)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []domain.OutboundMessage{}, nil
+		}
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (r *OutboundMessageRepository) CountByStatus(ctx context.Context, status domain.OutboundMessageStatus) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM outbound_messages WHERE status = $1`
+
+	err := r.db.GetContext(ctx, &count, query, status)
+	return count, err
+}
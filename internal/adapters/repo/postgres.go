@@ -14,11 +14,20 @@ import (
 type PostgresRepositories struct {
 	db                     *sqlx.DB
 	userRepo               ports.UserRepository
+	userIdentityRepo       ports.UserIdentityRepository
 	whitelistRepo          ports.WhitelistRepository
 	eventRepo              ports.EventRepository
+	eventExceptionRepo     ports.EventExceptionRepository
+	eventParticipantRepo   ports.EventParticipantRepository
 	inboundMessageRepo     ports.InboundMessageRepository
+	outboundMessageRepo    ports.OutboundMessageRepository
 	llmConfigRepo          ports.LLMConfigRepository
 	userAllowedContactRepo ports.UserAllowedContactRepository
+	eventEscalationRepo    ports.EventEscalationRepository
+	messageTemplateRepo    ports.MessageTemplateRepository
+	// inTx marks repositories bound to a transaction: WithTx on them joins
+	// it instead of starting an independent one.
+	inTx bool
 }
 
 func NewPostgresRepositories(dsn string) (*PostgresRepositories, error) {
@@ -34,11 +43,17 @@ func NewPostgresRepositories(dsn string) (*PostgresRepositories, error) {
 
 	repo := &PostgresRepositories{db: db}
 	repo.userRepo = NewUserRepository(db)
+	repo.userIdentityRepo = NewUserIdentityRepository(db)
 	repo.whitelistRepo = NewWhitelistRepository(db)
 	repo.eventRepo = NewEventRepository(db)
+	repo.eventExceptionRepo = NewEventExceptionRepository(db)
+	repo.eventParticipantRepo = NewEventParticipantRepository(db)
 	repo.inboundMessageRepo = NewInboundMessageRepository(db)
+	repo.outboundMessageRepo = NewOutboundMessageRepository(db)
 	repo.llmConfigRepo = NewLLMConfigRepository(db)
 	repo.userAllowedContactRepo = NewUserAllowedContactRepository(db)
+	repo.eventEscalationRepo = NewEventEscalationRepository(db)
+	repo.messageTemplateRepo = NewMessageTemplateRepository(db)
 
 	return repo, nil
 }
@@ -51,6 +66,10 @@ func (r *PostgresRepositories) User() ports.UserRepository {
 	return r.userRepo
 }
 
+func (r *PostgresRepositories) UserIdentity() ports.UserIdentityRepository {
+	return r.userIdentityRepo
+}
+
 func (r *PostgresRepositories) Whitelist() ports.WhitelistRepository {
 	return r.whitelistRepo
 }
@@ -59,10 +78,22 @@ func (r *PostgresRepositories) Event() ports.EventRepository {
 	return r.eventRepo
 }
 
+func (r *PostgresRepositories) EventException() ports.EventExceptionRepository {
+	return r.eventExceptionRepo
+}
+
+func (r *PostgresRepositories) EventParticipant() ports.EventParticipantRepository {
+	return r.eventParticipantRepo
+}
+
 func (r *PostgresRepositories) InboundMessage() ports.InboundMessageRepository {
 	return r.inboundMessageRepo
 }
 
+func (r *PostgresRepositories) OutboundMessage() ports.OutboundMessageRepository {
+	return r.outboundMessageRepo
+}
+
 func (r *PostgresRepositories) LLMConfig() ports.LLMConfigRepository {
 	return r.llmConfigRepo
 }
@@ -71,7 +102,19 @@ func (r *PostgresRepositories) UserAllowedContact() ports.UserAllowedContactRepo
 	return r.userAllowedContactRepo
 }
 
+func (r *PostgresRepositories) EventEscalation() ports.EventEscalationRepository {
+	return r.eventEscalationRepo
+}
+
+func (r *PostgresRepositories) MessageTemplate() ports.MessageTemplateRepository {
+	return r.messageTemplateRepo
+}
+
 func (r *PostgresRepositories) WithTx(ctx context.Context, fn func(ports.Repositories) error) error {
+	if r.inTx {
+		return fn(r)
+	}
+
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
@@ -88,11 +131,18 @@ func (r *PostgresRepositories) WithTx(ctx context.Context, fn func(ports.Reposit
 	txRepos := &PostgresRepositories{
 		db:                     r.db,
 		userRepo:               NewUserRepository(tx),
+		userIdentityRepo:       NewUserIdentityRepository(tx),
 		whitelistRepo:          NewWhitelistRepository(tx),
 		eventRepo:              NewEventRepository(tx),
+		eventExceptionRepo:     NewEventExceptionRepository(tx),
+		eventParticipantRepo:   NewEventParticipantRepository(tx),
 		inboundMessageRepo:     NewInboundMessageRepository(tx),
+		outboundMessageRepo:    NewOutboundMessageRepository(tx),
 		llmConfigRepo:          NewLLMConfigRepository(tx),
 		userAllowedContactRepo: NewUserAllowedContactRepository(tx),
+		eventEscalationRepo:    NewEventEscalationRepository(tx),
+		messageTemplateRepo:    NewMessageTemplateRepository(tx),
+		inTx:                   true,
 	}
 
 	if err = fn(txRepos); err != nil {
@@ -102,6 +152,16 @@ func (r *PostgresRepositories) WithTx(ctx context.Context, fn func(ports.Reposit
 	return tx.Commit()
 }
 
+// namedGetContext runs a named query returning a single row, such as an
+// INSERT ... RETURNING, and scans that row into dest.
+func namedGetContext(ctx context.Context, db QueryExecutor, dest interface{}, query string, arg interface{}) error {
+	query, args, err := sqlx.Named(query, arg)
+	if err != nil {
+		return err
+	}
+	return db.GetContext(ctx, dest, sqlx.Rebind(sqlx.DOLLAR, query), args...)
+}
+
 type QueryExecutor interface {
 	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
 	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
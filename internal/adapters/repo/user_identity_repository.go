@@ -0,0 +1,107 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+const userIdentityColumns = `id, user_id, channel, external_id, verified, verification_code, last_inbound_at, created_at, updated_at`
+
+type UserIdentityRepository struct {
+	db QueryExecutor
+}
+
+func NewUserIdentityRepository(db QueryExecutor) ports.UserIdentityRepository {
+	return &UserIdentityRepository{db: db}
+}
+
+func (r *UserIdentityRepository) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (user_id, channel, external_id, verified, verification_code, last_inbound_at)
+		VALUES (:user_id, :channel, :external_id, :verified, :verification_code, :last_inbound_at)
+		RETURNING id, created_at, updated_at`
+
+	return namedGetContext(ctx, r.db, identity, query, identity)
+}
+
+// GetByChannelID returns the verified identity with the ID on the channel.
+// Unverified claims on it are only found by FindClaim.
+func (r *UserIdentityRepository) GetByChannelID(ctx context.Context, channel domain.Channel, externalID string) (*domain.UserIdentity, error) {
+	var identity domain.UserIdentity
+	query := `
+		SELECT ` + userIdentityColumns + `
+		FROM user_identities
+		WHERE channel = $1 AND external_id = $2 AND verified`
+
+	err := r.db.GetContext(ctx, &identity, query, channel, externalID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &identity, nil
+}
+
+// FindClaim returns the unverified identity with the ID on the channel that
+// was sent code, if any.
+func (r *UserIdentityRepository) FindClaim(ctx context.Context, channel domain.Channel, externalID, code string) (*domain.UserIdentity, error) {
+	var identity domain.UserIdentity
+	query := `
+		SELECT ` + userIdentityColumns + `
+		FROM user_identities
+		WHERE channel = $1 AND external_id = $2 AND NOT verified AND verification_code = $3
+		ORDER BY id
+		LIMIT 1`
+
+	err := r.db.GetContext(ctx, &identity, query, channel, externalID, code)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &identity, nil
+}
+
+// ListByUserID returns the identities of a user, oldest first.
+func (r *UserIdentityRepository) ListByUserID(ctx context.Context, userID int) ([]domain.UserIdentity, error) {
+	identities := []domain.UserIdentity{}
+	query := `
+		SELECT ` + userIdentityColumns + `
+		FROM user_identities
+		WHERE user_id = $1
+		ORDER BY id ASC`
+
+	if err := r.db.SelectContext(ctx, &identities, query, userID); err != nil {
+		return nil, err
+	}
+
+	return identities, nil
+}
+
+func (r *UserIdentityRepository) MarkVerified(ctx context.Context, id int) error {
+	query := "UPDATE user_identities SET verified = true, verification_code = NULL, updated_at = NOW() WHERE id = $1"
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// RecordInbound stores when the last message from the identity arrived.
+func (r *UserIdentityRepository) RecordInbound(ctx context.Context, id int, at time.Time) error {
+	query := "UPDATE user_identities SET last_inbound_at = $2, updated_at = NOW() WHERE id = $1"
+	_, err := r.db.ExecContext(ctx, query, id, at)
+	return err
+}
+
+func (r *UserIdentityRepository) Delete(ctx context.Context, userID, id int) error {
+	query := "DELETE FROM user_identities WHERE user_id = $1 AND id = $2"
+	_, err := r.db.ExecContext(ctx, query, userID, id)
+	return err
+}
@@ -4,11 +4,31 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/alarm-agent/internal/domain"
 	"github.com/alarm-agent/internal/ports"
 )
 
+var userColumns = `id, ` + userContactColumns("users", "") + `, preferred_channel, name, timezone, locale, default_remind_before_minutes,
+		       default_remind_frequency_minutes, default_require_confirmation,
+		       default_reminder_offsets, daily_digest_enabled, daily_digest_time,
+		       weekly_digest_enabled, weekly_digest_time, last_daily_digest_at, last_weekly_digest_at, quiet_hours,
+		       notify_no_response, default_escalation_policy, llm_provider, llm_model, rate_limit_per_minute, is_active, created_at, updated_at`
+
+// userContactColumns selects, for the users row named table, the WhatsApp
+// number and the contact ID on the preferred channel out of its verified
+// identities, aliased with prefix for struct scanning.
+func userContactColumns(table, prefix string) string {
+	return fmt.Sprintf(`COALESCE((SELECT external_id FROM user_identities
+		                 WHERE user_id = %[1]s.id AND channel = 'whatsapp' AND verified
+		                 ORDER BY id LIMIT 1), '') as "%[2]swa_number",
+		       COALESCE((SELECT external_id FROM user_identities
+		                 WHERE user_id = %[1]s.id AND channel = %[1]s.preferred_channel AND verified
+		                 ORDER BY id LIMIT 1), '') as "%[2]scontact_id"`, table, prefix)
+}
+
 type UserRepository struct {
 	db QueryExecutor
 }
@@ -18,16 +38,18 @@ func NewUserRepository(db QueryExecutor) ports.UserRepository {
 }
 
 func (r *UserRepository) GetByWANumber(ctx context.Context, waNumber string) (*domain.User, error) {
+	return r.GetByIdentity(ctx, domain.ChannelWhatsApp, waNumber)
+}
+
+func (r *UserRepository) GetByIdentity(ctx context.Context, channel domain.Channel, externalID string) (*domain.User, error) {
 	var user domain.User
 	query := `
-		SELECT id, wa_number, name, timezone, default_remind_before_minutes, 
-		       default_remind_frequency_minutes, default_require_confirmation, 
-		       llm_provider, llm_model, rate_limit_per_minute, is_active,
-		       created_at, updated_at
-		FROM users 
-		WHERE wa_number = $1`
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE id = (SELECT user_id FROM user_identities
+		            WHERE channel = $1 AND external_id = $2 AND verified)`
 
-	err := r.db.GetContext(ctx, &user, query, waNumber)
+	err := r.db.GetContext(ctx, &user, query, channel, externalID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -41,10 +63,7 @@ func (r *UserRepository) GetByWANumber(ctx context.Context, waNumber string) (*d
 func (r *UserRepository) GetByID(ctx context.Context, userID int) (*domain.User, error) {
 	var user domain.User
 	query := `
-		SELECT id, wa_number, name, timezone, default_remind_before_minutes, 
-		       default_remind_frequency_minutes, default_require_confirmation, 
-		       llm_provider, llm_model, rate_limit_per_minute, is_active,
-		       created_at, updated_at
+		SELECT ` + userColumns + `
 		FROM users 
 		WHERE id = $1`
 
@@ -60,35 +79,32 @@ func (r *UserRepository) GetByID(ctx context.Context, userID int) (*domain.User,
 }
 
 func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
+	user.PreferredChannel = user.PreferredChannel.OrDefault()
 	query := `
-		INSERT INTO users (wa_number, name, timezone, default_remind_before_minutes, 
+		INSERT INTO users (preferred_channel, name, timezone, locale, default_remind_before_minutes, 
 		                   default_remind_frequency_minutes, default_require_confirmation,
-		                   llm_provider, llm_model, rate_limit_per_minute, is_active)
-		VALUES (:wa_number, :name, :timezone, :default_remind_before_minutes, 
+		                   default_reminder_offsets, llm_provider, llm_model, rate_limit_per_minute, is_active)
+		VALUES (:preferred_channel, :name, :timezone, :locale, :default_remind_before_minutes, 
 		        :default_remind_frequency_minutes, :default_require_confirmation,
-		        :llm_provider, :llm_model, :rate_limit_per_minute, :is_active)
-		RETURNING id, created_at, updated_at`
-
-	rows, err := r.db.NamedExecContext(ctx, query, user)
-	if err != nil {
-		return err
-	}
-
-	id, err := rows.LastInsertId()
-	if err == nil && id > 0 {
-		user.ID = int(id)
-	}
+		        :default_reminder_offsets, :llm_provider, :llm_model, :rate_limit_per_minute, :is_active)
+		RETURNING id, daily_digest_time, weekly_digest_time, quiet_hours, notify_no_response,
+		          default_escalation_policy, created_at, updated_at`
 
-	return nil
+	return namedGetContext(ctx, r.db, user, query, user)
 }
 
 func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	query := `
 		UPDATE users 
-		SET name = :name, timezone = :timezone, 
+		SET name = :name, timezone = :timezone, locale = :locale,
 		    default_remind_before_minutes = :default_remind_before_minutes,
 		    default_remind_frequency_minutes = :default_remind_frequency_minutes,
 		    default_require_confirmation = :default_require_confirmation,
+		    default_reminder_offsets = :default_reminder_offsets,
+		    daily_digest_enabled = :daily_digest_enabled, daily_digest_time = :daily_digest_time,
+		    weekly_digest_enabled = :weekly_digest_enabled, weekly_digest_time = :weekly_digest_time,
+		    quiet_hours = :quiet_hours, notify_no_response = :notify_no_response,
+		    default_escalation_policy = :default_escalation_policy,
 		    llm_provider = :llm_provider, llm_model = :llm_model,
 		    rate_limit_per_minute = :rate_limit_per_minute, is_active = :is_active,
 		    updated_at = NOW()
@@ -107,12 +123,52 @@ func (r *UserRepository) UpdateConfig(ctx context.Context, userID int, config *d
 		    default_require_confirmation = $6,
 		    llm_provider = $7, llm_model = $8,
 		    rate_limit_per_minute = $9, is_active = $10,
+		    default_reminder_offsets = $11,
+		    daily_digest_enabled = $12, daily_digest_time = $13,
+		    weekly_digest_enabled = $14, weekly_digest_time = $15,
+		    quiet_hours = $16, notify_no_response = $17,
+		    default_escalation_policy = $18, locale = $19,
+		    preferred_channel = $20,
 		    updated_at = NOW()
 		WHERE id = $1`
 
 	_, err := r.db.ExecContext(ctx, query, userID, config.Name, config.Timezone,
 		config.DefaultRemindBeforeMinutes, config.DefaultRemindFrequencyMinutes,
 		config.DefaultRequireConfirmation, config.LLMProvider, config.LLMModel,
-		config.RateLimitPerMinute, config.IsActive)
+		config.RateLimitPerMinute, config.IsActive, config.DefaultReminderOffsets,
+		config.DailyDigestEnabled, config.DailyDigestTime,
+		config.WeeklyDigestEnabled, config.WeeklyDigestTime, config.QuietHours,
+		config.NotifyNoResponse, config.DefaultEscalationPolicy, config.Locale,
+		config.PreferredChannel.OrDefault())
+	return err
+}
+
+// ListWithDigests returns the active users that opted into any digest.
+func (r *UserRepository) ListWithDigests(ctx context.Context) ([]domain.User, error) {
+	var users []domain.User
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE is_active = true
+		  AND (daily_digest_enabled OR weekly_digest_enabled)
+		ORDER BY id`
+
+	err := r.db.SelectContext(ctx, &users, query)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []domain.User{}, nil
+		}
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) MarkDigestSent(ctx context.Context, userID int, kind domain.DigestKind, sentAt time.Time) error {
+	query := "UPDATE users SET last_daily_digest_at = $2 WHERE id = $1"
+	if kind == domain.DigestWeekly {
+		query = "UPDATE users SET last_weekly_digest_at = $2 WHERE id = $1"
+	}
+	_, err := r.db.ExecContext(ctx, query, userID, sentAt)
 	return err
 }
@@ -0,0 +1,143 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/alarm-agent/internal/domain"
+)
+
+// requestTimeout bounds every call but getUpdates, which waits as long as
+// it is asked to.
+const requestTimeout = 30 * time.Second
+
+// Client talks to the Telegram Bot API as the configured bot.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		baseURL:    baseURL,
+		token:      token,
+		httpClient: &http.Client{},
+	}
+}
+
+type sendMessageRequest struct {
+	ChatID string `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+type getUpdatesRequest struct {
+	Offset         int      `json:"offset,omitempty"`
+	Timeout        int      `json:"timeout"`
+	AllowedUpdates []string `json:"allowed_updates"`
+}
+
+func (c *Client) Channel() domain.Channel {
+	return domain.ChannelTelegram
+}
+
+// SendText sends a message to a chat; to is the chat ID.
+func (c *Client) SendText(ctx context.Context, to, text string) error {
+	return c.call(ctx, "sendMessage", requestTimeout, sendMessageRequest{ChatID: to, Text: text}, nil)
+}
+
+// SendInteractive sends the options as part of the text, to be answered by
+// typing one.
+func (c *Client) SendInteractive(ctx context.Context, to, text string, interactive domain.Interactive) error {
+	return c.SendText(ctx, to, interactive.AsText(text))
+}
+
+// SendTemplate fails: templates only exist on WhatsApp, and Telegram has no
+// session window to need them.
+func (c *Client) SendTemplate(ctx context.Context, to string, template domain.TemplateMessage) error {
+	return fmt.Errorf("telegram does not support template %s", template.Name)
+}
+
+// GetUpdates long polls for the updates after offset, waiting up to timeout
+// for one to arrive.
+func (c *Client) GetUpdates(ctx context.Context, offset int, timeout time.Duration) ([]Update, error) {
+	var updates []Update
+	request := getUpdatesRequest{
+		Offset:         offset,
+		Timeout:        int(timeout.Seconds()),
+		AllowedUpdates: []string{"message"},
+	}
+	if err := c.call(ctx, "getUpdates", timeout+requestTimeout, request, &updates); err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+func (c *Client) call(ctx context.Context, method string, timeout time.Duration, request, result interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/bot%s/%s", c.baseURL, c.token, method)
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", method, withoutURL(err))
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send %s request: %w", method, withoutURL(err))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("telegram API error %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	var response apiResponse
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	if !response.OK {
+		return fmt.Errorf("telegram API error: %s", response.Description)
+	}
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("failed to parse %s result: %w", method, err)
+	}
+
+	return nil
+}
+
+// withoutURL drops the request URL, which holds the bot token, from err, so
+// it never reaches the logs or the last_error of outbound messages.
+func withoutURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
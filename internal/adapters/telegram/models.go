@@ -0,0 +1,102 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alarm-agent/internal/adapters/whatsapp"
+	"github.com/alarm-agent/internal/domain"
+)
+
+// Update is what the Bot API posts to the webhook or returns from
+// getUpdates.
+type Update struct {
+	UpdateID int      `json:"update_id"`
+	Message  *Message `json:"message,omitempty"`
+}
+
+type Message struct {
+	MessageID int         `json:"message_id"`
+	From      *User       `json:"from,omitempty"`
+	Chat      Chat        `json:"chat"`
+	Date      int64       `json:"date"`
+	Text      string      `json:"text,omitempty"`
+	Caption   string      `json:"caption,omitempty"`
+	Photo     []PhotoSize `json:"photo,omitempty"`
+	Location  *Location   `json:"location,omitempty"`
+}
+
+type User struct {
+	ID        int64  `json:"id"`
+	IsBot     bool   `json:"is_bot"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name,omitempty"`
+	Username  string `json:"username,omitempty"`
+}
+
+type Chat struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"`
+}
+
+type PhotoSize struct {
+	FileID string `json:"file_id"`
+}
+
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// apiResponse wraps every Bot API response.
+type apiResponse struct {
+	OK          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	Description string          `json:"description,omitempty"`
+}
+
+// ExtractMessage turns the update into the message the use cases process,
+// with the chat ID as sender since that is where replies go. Updates without
+// a new message, such as edits, and messages sent by bots are skipped.
+func (u Update) ExtractMessage() (whatsapp.ParsedMessage, bool) {
+	message := u.Message
+	if message == nil || (message.From != nil && message.From.IsBot) {
+		return whatsapp.ParsedMessage{}, false
+	}
+
+	chatID := strconv.FormatInt(message.Chat.ID, 10)
+	parsed := whatsapp.ParsedMessage{
+		ID:        fmt.Sprintf("telegram:%s:%d", chatID, message.MessageID),
+		Channel:   domain.ChannelTelegram,
+		From:      chatID,
+		Timestamp: time.Unix(message.Date, 0).UTC(),
+	}
+
+	switch {
+	case message.Text != "":
+		parsed.Type = "TEXT"
+		parsed.Text = message.Text
+	case len(message.Photo) > 0:
+		parsed.Type = "IMAGE"
+		parsed.Text = message.Caption
+	case message.Location != nil:
+		parsed.Type = "LOCATION"
+		locationJSON, _ := json.Marshal(map[string]interface{}{
+			"latitude":  message.Location.Latitude,
+			"longitude": message.Location.Longitude,
+		})
+		parsed.Text = string(locationJSON)
+	default:
+		parsed.Type = "UNSUPPORTED"
+		parsed.Text = "Unsupported message type"
+	}
+
+	if message.From != nil {
+		parsed.ContactName = strings.TrimSpace(message.From.FirstName + " " + message.From.LastName)
+	}
+
+	return parsed, true
+}
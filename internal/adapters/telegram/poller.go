@@ -0,0 +1,96 @@
+package telegram
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/alarm-agent/internal/adapters/whatsapp"
+)
+
+// retryDelay is how long the poller waits after getUpdates fails.
+const retryDelay = 5 * time.Second
+
+// MessageHandler processes an inbound message, as
+// MessageUseCase.ProcessInboundMessage does.
+type MessageHandler func(ctx context.Context, message whatsapp.ParsedMessage) error
+
+// Poller receives the bot's updates by long polling getUpdates, for
+// deployments Telegram cannot reach with a webhook.
+type Poller struct {
+	client  *Client
+	handler MessageHandler
+	timeout time.Duration
+	logger  *zap.Logger
+	stopCh  chan struct{}
+}
+
+func NewPoller(client *Client, handler MessageHandler, timeout time.Duration, logger *zap.Logger) *Poller {
+	return &Poller{
+		client:  client,
+		handler: handler,
+		timeout: timeout,
+		logger:  logger,
+		stopCh:  make(chan struct{}),
+	}
+}
+
+func (p *Poller) Start(ctx context.Context) error {
+	p.logger.Info("Starting Telegram poller", zap.Duration("timeout", p.timeout))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-p.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	offset := 0
+	for {
+		updates, err := p.client.GetUpdates(ctx, offset, p.timeout)
+		if err != nil {
+			select {
+			case <-p.stopCh:
+				p.logger.Info("Telegram poller stopped")
+				return nil
+			case <-ctx.Done():
+				p.logger.Info("Telegram poller stopped by context")
+				return ctx.Err()
+			default:
+			}
+
+			p.logger.Error("Failed to get Telegram updates", zap.Error(err))
+			select {
+			case <-ctx.Done():
+			case <-time.After(retryDelay):
+			}
+			continue
+		}
+
+		for _, update := range updates {
+			// Telegram forgets updates below the offset, so a failed
+			// message is logged and not retried, as webhook ones are.
+			offset = update.UpdateID + 1
+
+			message, ok := update.ExtractMessage()
+			if !ok {
+				continue
+			}
+			if err := p.handler(ctx, message); err != nil {
+				p.logger.Error("Failed to process inbound message",
+					zap.Error(err),
+					zap.String("message_id", message.ID),
+					zap.String("from", message.From),
+				)
+			}
+		}
+	}
+}
+
+func (p *Poller) Stop() {
+	close(p.stopCh)
+}
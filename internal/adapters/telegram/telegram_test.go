@@ -0,0 +1,121 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alarm-agent/internal/adapters/whatsapp"
+	"github.com/alarm-agent/internal/domain"
+)
+
+func TestParser_Parse(t *testing.T) {
+	payload := []byte(`{
+		"update_id": 10,
+		"message": {
+			"message_id": 7,
+			"from": {"id": 42, "is_bot": false, "first_name": "Ana", "last_name": "Souza"},
+			"chat": {"id": 42, "type": "private"},
+			"date": 1704103200,
+			"text": "Dentista amanhã às 14h"
+		}
+	}`)
+
+	messages, err := NewParser().Parse(payload)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+
+	assert.Equal(t, whatsapp.ParsedMessage{
+		ID:          "telegram:42:7",
+		Channel:     domain.ChannelTelegram,
+		From:        "42",
+		Timestamp:   time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		Type:        "TEXT",
+		Text:        "Dentista amanhã às 14h",
+		ContactName: "Ana Souza",
+	}, messages[0])
+
+	t.Run("skips updates without a message", func(t *testing.T) {
+		messages, err := NewParser().Parse([]byte(`{"update_id": 11, "edited_message": {"message_id": 7}}`))
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+	})
+
+	t.Run("skips messages from bots", func(t *testing.T) {
+		messages, err := NewParser().Parse([]byte(`{"update_id": 12, "message": {"message_id": 8, "from": {"id": 1, "is_bot": true}, "chat": {"id": 42}, "text": "hi"}}`))
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := NewParser().Parse([]byte("invalid json"))
+		assert.Error(t, err)
+	})
+}
+
+func TestWebhookVerifier_VerifySignature(t *testing.T) {
+	verifier := NewWebhookVerifier("secret")
+	assert.True(t, verifier.VerifySignature(nil, "secret"))
+	assert.False(t, verifier.VerifySignature(nil, "wrong"))
+	assert.False(t, verifier.VerifySignature(nil, ""))
+
+	assert.False(t, NewWebhookVerifier("").VerifySignature(nil, ""), "no secret rejects every update")
+}
+
+func TestClient(t *testing.T) {
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var request map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &request))
+		request["path"] = r.URL.Path
+		requests = append(requests, request)
+
+		switch r.URL.Path {
+		case "/bottoken/sendMessage":
+			_, _ = w.Write([]byte(`{"ok": true, "result": {"message_id": 1}}`))
+		case "/bottoken/getUpdates":
+			_, _ = w.Write([]byte(`{"ok": true, "result": [{"update_id": 10, "message": {"message_id": 7, "chat": {"id": 42}, "text": "oi"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"ok": false, "description": "Not Found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token")
+	assert.Equal(t, domain.ChannelTelegram, client.Channel())
+
+	require.NoError(t, client.SendText(context.Background(), "42", "Olá"))
+	assert.Equal(t, map[string]interface{}{"path": "/bottoken/sendMessage", "chat_id": "42", "text": "Olá"}, requests[0])
+
+	updates, err := client.GetUpdates(context.Background(), 10, time.Second)
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	assert.Equal(t, "oi", updates[0].Message.Text)
+	assert.Equal(t, float64(10), requests[1]["offset"])
+	assert.Equal(t, float64(1), requests[1]["timeout"])
+
+	assert.Error(t, NewClient(server.URL, "other").SendText(context.Background(), "42", "Olá"))
+}
+
+func TestClient_ErrorsOmitToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	err := NewClient(server.URL, "123456:secret-token").SendText(context.Background(), "42", "Olá")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send sendMessage request")
+	assert.NotContains(t, err.Error(), "secret-token")
+
+	err = NewClient("http://bad host", "123456:secret-token").SendText(context.Background(), "42", "Olá")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret-token")
+}
@@ -0,0 +1,135 @@
+package templates
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+//go:embed messages
+var defaultMessages embed.FS
+
+var funcs = template.FuncMap{
+	"join": strings.Join,
+}
+
+// Renderer renders messages in the user's language from the embedded
+// templates of each locale, redefined by the files of a directory, if any,
+// and by the overrides stored per user or globally, which product can change
+// without a deploy.
+type Renderer struct {
+	bases     map[domain.Locale]*template.Template
+	overrides ports.MessageTemplateRepository
+}
+
+// NewRenderer loads the embedded templates and then the *.tmpl files in dir,
+// when set: those at its top redefine the default locale and those in a
+// subdirectory named after a locale, e.g. "en", redefine that one. Overrides
+// may be nil to only use the files.
+func NewRenderer(overrides ports.MessageTemplateRepository, dir string) (ports.MessageRenderer, error) {
+	bases := make(map[domain.Locale]*template.Template, len(domain.Locales))
+	for _, locale := range domain.Locales {
+		base, err := template.New("messages").
+			Funcs(funcs).
+			Option("missingkey=error").
+			ParseFS(defaultMessages, path.Join("messages", string(locale), "*.tmpl"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse embedded %s templates: %w", locale, err)
+		}
+
+		if dir != "" {
+			if locale == domain.DefaultLocale {
+				if base, err = parseDir(base, dir); err != nil {
+					return nil, err
+				}
+			}
+			if base, err = parseDir(base, filepath.Join(dir, string(locale))); err != nil {
+				return nil, err
+			}
+		}
+
+		bases[locale] = base
+	}
+
+	return &Renderer{bases: bases, overrides: overrides}, nil
+}
+
+// Render executes the named template in the user's language with their
+// override, or the global one, when there is one. An override that fails to
+// execute, for instance by using a value the message does not have, falls
+// back to the default wording rather than leaving the user without a
+// message.
+func (r *Renderer) Render(ctx context.Context, user *domain.User, name string, data domain.MessageData) (string, error) {
+	locale := user.Language()
+	if r.overrides != nil && user != nil {
+		override, err := r.overrides.Find(ctx, user.ID, locale, name)
+		if err != nil {
+			return "", fmt.Errorf("failed to find template override: %w", err)
+		}
+		if override != nil {
+			if tmpl, err := r.parse(locale, name, override.Body); err == nil {
+				if text, err := execute(tmpl, name, data); err == nil {
+					return text, nil
+				}
+			}
+		}
+	}
+
+	return execute(r.bases[locale], name, data)
+}
+
+// Validate reports whether body parses as a replacement of a known template
+// in locale.
+func (r *Renderer) Validate(locale domain.Locale, name, body string) error {
+	base, ok := r.bases[locale]
+	if !ok {
+		return fmt.Errorf("unsupported locale %q", locale)
+	}
+	if base.Lookup(name) == nil {
+		return fmt.Errorf("unknown template %q", name)
+	}
+	_, err := r.parse(locale, name, body)
+	return err
+}
+
+// parse redefines the named template in a copy of the defaults of locale, so
+// the override can still use the shared blocks.
+func (r *Renderer) parse(locale domain.Locale, name, body string) (*template.Template, error) {
+	tmpl, err := r.bases[locale].Clone()
+	if err != nil {
+		return nil, err
+	}
+	return tmpl.New(name).Parse(body)
+}
+
+// parseDir redefines base with the *.tmpl files in dir, if any.
+func parseDir(base *template.Template, dir string) (*template.Template, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates in %s: %w", dir, err)
+	}
+	if len(files) == 0 {
+		return base, nil
+	}
+	base, err = base.ParseFiles(files...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse templates in %s: %w", dir, err)
+	}
+	return base, nil
+}
+
+func execute(tmpl *template.Template, name string, data domain.MessageData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
@@ -0,0 +1,293 @@
+package templates
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+// stubOverrides serves overrides of the default locale from memory, keyed
+// by template name.
+type stubOverrides struct {
+	ports.MessageTemplateRepository
+	user   map[string]string
+	global map[string]string
+}
+
+func (s *stubOverrides) Find(ctx context.Context, userID int, locale domain.Locale, name string) (*domain.MessageTemplate, error) {
+	if locale != domain.DefaultLocale {
+		return nil, nil
+	}
+	if body, ok := s.user[name]; ok {
+		return &domain.MessageTemplate{UserID: &userID, Locale: locale, Name: name, Body: body}, nil
+	}
+	if body, ok := s.global[name]; ok {
+		return &domain.MessageTemplate{Locale: locale, Name: name, Body: body}, nil
+	}
+	return nil, nil
+}
+
+func TestRenderer_DefinesEveryTemplate(t *testing.T) {
+	messages, err := NewRenderer(nil, "")
+	require.NoError(t, err)
+
+	names := []string{
+		domain.TemplateGreeting, domain.TemplateNotUnderstood, domain.TemplateInvalidEventData,
+		domain.TemplateUnknownEvent, domain.TemplateEventCreated, domain.TemplateEventCreateFailed,
+		domain.TemplateEventUpdated, domain.TemplateEventUpdateFailed, domain.TemplateEventCanceled,
+		domain.TemplateEventCancelFailed, domain.TemplateEventList, domain.TemplateEventListEmpty,
+		domain.TemplateEventListFailed, domain.TemplateEventConfirmed, domain.TemplateEventConfirmFailed,
+		domain.TemplateEventSnoozed, domain.TemplateEventSnoozeFailed, domain.TemplateInvalidSnooze,
+		domain.TemplateDigestSettings, domain.TemplateInvalidDigest, domain.TemplateInvalidDigestTime,
+		domain.TemplateConflictHeld, domain.TemplateConflictKept, domain.TemplateConflictDiscarded,
+		domain.TemplateConflictAnswerFailed, domain.TemplateInvitation, domain.TemplateInvitationAnswered,
+		domain.TemplateInvitationFailed, domain.TemplateRSVP, domain.TemplateReminder,
+		domain.TemplateConfirmationRequest, domain.TemplateConfirmButton, domain.TemplateCancelButton,
+		domain.TemplateSnoozeButton, domain.TemplateNoResponse, domain.TemplateLateNotice,
+		domain.TemplateMissedSummary, domain.TemplateEscalation, domain.TemplateEscalationCanceled,
+		domain.TemplateIdentityVerification, domain.TemplateIdentityVerified,
+		domain.TemplateDailyDigest, domain.TemplateWeeklyDigest,
+	}
+	for _, locale := range domain.Locales {
+		for _, name := range names {
+			assert.NoError(t, messages.Validate(locale, name, "ok"), "%s %s", locale, name)
+		}
+	}
+}
+
+func TestRenderer_RendersDefaults(t *testing.T) {
+	messages, err := NewRenderer(nil, "")
+	require.NoError(t, err)
+
+	location := "Clínica"
+	event := &domain.Event{Title: "Dentista", Location: &location, RemindBeforeMinutes: 30}
+
+	tests := []struct {
+		name     string
+		template string
+		data     domain.MessageData
+		want     string
+	}{
+		{
+			name:     "created with invitations",
+			template: domain.TemplateEventCreated,
+			data: domain.MessageData{
+				"Event":      event,
+				"When":       "amanhã às 14h",
+				"Reminders":  "",
+				"Recurrence": "toda semana",
+				"Invited":    []string{"Ana"},
+				"Skipped":    []string(nil),
+			},
+			want: "✅ Evento criado: Dentista em amanhã às 14h em Clínica. Lembrete: 30 minutos antes.\n🔁 Repete toda semana.\n📨 Convites enviados para: Ana.",
+		},
+		{
+			name:     "reminder with countdown",
+			template: domain.TemplateReminder,
+			data: domain.MessageData{
+				"Event":     event,
+				"When":      "hoje às 14h",
+				"Countdown": domain.MessageData{"Hours": 0, "Minutes": 30},
+			},
+			want: "⏰ *Lembrete de Compromisso*\n📅 Dentista\n🕐 hoje às 14h\n📍 Clínica\n⏱️ Começa em 30 minutos",
+		},
+		{
+			name:     "updated with conflicts",
+			template: domain.TemplateEventUpdated,
+			data: domain.MessageData{
+				"Event":         event,
+				"When":          "amanhã às 14h",
+				"Occurrence":    false,
+				"Conflicts":     []domain.MessageData{{"Event": &domain.Event{Title: "Reunião"}, "When": "amanhã às 14h"}},
+				"MoreConflicts": 2,
+			},
+			want: "✏️ Evento atualizado: Dentista em amanhã às 14h\n⚠️ Atenção, conflita com:\n• Reunião (amanhã às 14h)\n• e mais 2",
+		},
+		{
+			name:     "empty weekly digest",
+			template: domain.TemplateWeeklyDigest,
+			data:     domain.MessageData{"Start": "09/03", "End": "16/03", "Days": nil, "More": 0},
+			want:     "🗓️ *Sua semana (09/03 a 16/03)*\n\nNenhum compromisso na agenda. Boa semana!",
+		},
+		{
+			name:     "weekly digest",
+			template: domain.TemplateWeeklyDigest,
+			data: domain.MessageData{
+				"Start": "09/03",
+				"End":   "16/03",
+				"Days": []domain.MessageData{
+					{"Label": "Segunda 10/03", "Events": []domain.MessageData{{"Event": event, "Time": "14:00"}}},
+					{"Label": "Terça 11/03", "Events": []domain.MessageData{{"Event": &domain.Event{Title: "Feriado", AllDay: true}, "Time": "00:00"}}},
+				},
+				"More": 1,
+			},
+			want: "🗓️ *Sua semana (09/03 a 16/03)*\n\n*Segunda 10/03*\n• 14:00 Dentista - Clínica\n\n*Terça 11/03*\n• Dia inteiro: Feriado\n\n… e mais 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := messages.Render(context.Background(), &domain.User{ID: 1}, tt.template, tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, text)
+		})
+	}
+}
+
+func TestRenderer_RendersPriority(t *testing.T) {
+	messages, err := NewRenderer(nil, "")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	critical := &domain.Event{Title: "Exame", Priority: domain.PriorityCritical}
+
+	text, err := messages.Render(ctx, nil, domain.TemplateReminder, domain.MessageData{"Event": critical, "When": "hoje às 14h", "Countdown": nil})
+	require.NoError(t, err)
+	assert.Equal(t, "⏰ *Lembrete de Compromisso* 🔴 *Urgente*\n📅 Exame\n🕐 hoje às 14h", text)
+
+	text, err = messages.Render(ctx, &domain.User{Locale: domain.LocaleEnglish}, domain.TemplateEventList, domain.MessageData{
+		"Events": []domain.MessageData{
+			{"Number": 1, "Event": &domain.Event{Title: "Gym", Priority: domain.PriorityLow}, "When": "today at 6pm"},
+			{"Number": 2, "Event": &domain.Event{Title: "Dentist", Priority: domain.PriorityNormal}, "When": "tomorrow at 2pm"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "📅 *Your upcoming events:*\n\n1. Gym ⚪ _No rush_\n📅 today at 6pm\n\n2. Dentist\n📅 tomorrow at 2pm", text)
+}
+
+func TestRenderer_RendersInUserLocale(t *testing.T) {
+	messages, err := NewRenderer(nil, "")
+	require.NoError(t, err)
+
+	location := "Clinic"
+	event := &domain.Event{Title: "Dentist", Location: &location}
+	data := domain.MessageData{
+		"Event":     event,
+		"When":      "today at 2pm",
+		"Countdown": domain.MessageData{"Hours": 0, "Minutes": 30},
+	}
+
+	tests := []struct {
+		locale domain.Locale
+		want   string
+	}{
+		{locale: domain.LocaleEnglish, want: "⏰ *Reminder*\n📅 Dentist\n🕐 today at 2pm\n📍 Clinic\n⏱️ Starts in 30 minutes"},
+		{locale: domain.LocaleSpanish, want: "⏰ *Recordatorio*\n📅 Dentist\n🕐 today at 2pm\n📍 Clinic\n⏱️ Empieza en 30 minutos"},
+		// Users whose language is not known yet get the default one.
+		{locale: "", want: "⏰ *Lembrete de Compromisso*\n📅 Dentist\n🕐 today at 2pm\n📍 Clinic\n⏱️ Começa em 30 minutos"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.locale), func(t *testing.T) {
+			text, err := messages.Render(context.Background(), &domain.User{ID: 1, Locale: tt.locale}, domain.TemplateReminder, data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, text)
+		})
+	}
+}
+
+func TestRenderer_MissingValue(t *testing.T) {
+	messages, err := NewRenderer(nil, "")
+	require.NoError(t, err)
+
+	_, err = messages.Render(context.Background(), nil, domain.TemplateEventSnoozed, domain.MessageData{"Event": &domain.Event{}})
+	assert.Error(t, err)
+}
+
+func TestRenderer_DirectoryOverrides(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "greeting.tmpl"), []byte(`{{define "greeting"}}Oi!{{end}}`), 0o644))
+
+	messages, err := NewRenderer(nil, dir)
+	require.NoError(t, err)
+
+	text, err := messages.Render(context.Background(), nil, domain.TemplateGreeting, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Oi!", text)
+
+	text, err = messages.Render(context.Background(), nil, domain.TemplateNotUnderstood, nil)
+	require.NoError(t, err)
+	assert.Contains(t, text, "não consegui entender")
+}
+
+func TestRenderer_LocaleDirectoryOverrides(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "en"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "en", "greeting.tmpl"), []byte(`{{define "greeting"}}Hey!{{end}}`), 0o644))
+
+	messages, err := NewRenderer(nil, dir)
+	require.NoError(t, err)
+
+	text, err := messages.Render(context.Background(), &domain.User{Locale: domain.LocaleEnglish}, domain.TemplateGreeting, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Hey!", text)
+
+	text, err = messages.Render(context.Background(), &domain.User{Locale: domain.LocaleSpanish}, domain.TemplateGreeting, nil)
+	require.NoError(t, err)
+	assert.Contains(t, text, "¡Hola!")
+}
+
+func TestRenderer_StoredOverrides(t *testing.T) {
+	overrides := &stubOverrides{
+		user: map[string]string{
+			domain.TemplateGreeting: "E aí, {{.Name}}!",
+			// Uses a value the message does not have.
+			domain.TemplateEventListEmpty: "Nada para {{.Day}}.",
+		},
+		global: map[string]string{
+			domain.TemplateNotUnderstood: "Hã?",
+			domain.TemplateEventSnoozed:  "{{template \"event\" .}}",
+		},
+	}
+	messages, err := NewRenderer(overrides, "")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	user := &domain.User{ID: 7}
+
+	text, err := messages.Render(ctx, user, domain.TemplateGreeting, domain.MessageData{"Name": "Ana"})
+	require.NoError(t, err)
+	assert.Equal(t, "E aí, Ana!", text)
+
+	text, err = messages.Render(ctx, user, domain.TemplateNotUnderstood, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Hã?", text)
+
+	text, err = messages.Render(ctx, user, domain.TemplateEventListEmpty, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Você não tem nenhum evento agendado.", text)
+
+	// Overrides are written for one locale.
+	text, err = messages.Render(ctx, &domain.User{ID: 7, Locale: domain.LocaleEnglish}, domain.TemplateNotUnderstood, nil)
+	require.NoError(t, err)
+	assert.Contains(t, text, "could not understand")
+
+	// Overrides can use the shared blocks.
+	snoozedUntil := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	text, err = messages.Render(ctx, user, domain.TemplateEventSnoozed, domain.MessageData{
+		"Event": &domain.Event{Title: "Dentista", SnoozedUntil: &snoozedUntil},
+		"When":  "hoje às 14h",
+		"Until": "hoje às 14h",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "📅 Dentista\n🕐 hoje às 14h", text)
+}
+
+func TestRenderer_Validate(t *testing.T) {
+	messages, err := NewRenderer(nil, "")
+	require.NoError(t, err)
+
+	assert.NoError(t, messages.Validate(domain.LocalePortuguese, domain.TemplateGreeting, "Oi, {{.User.DisplayName}}"))
+	assert.NoError(t, messages.Validate(domain.LocaleEnglish, domain.TemplateReminder, "{{template \"event\" .}}"))
+	assert.Error(t, messages.Validate(domain.LocalePortuguese, "unknown", "Oi"))
+	assert.Error(t, messages.Validate(domain.LocalePortuguese, domain.TemplateGreeting, "{{if}}"))
+	assert.Error(t, messages.Validate("fr", domain.TemplateGreeting, "Salut"))
+}
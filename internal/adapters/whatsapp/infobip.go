@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/alarm-agent/internal/domain"
 	"github.com/alarm-agent/internal/ports"
 )
 
@@ -22,7 +23,7 @@ type InfobipClient struct {
 	httpClient *http.Client
 }
 
-func NewInfobipClient(baseURL, apiKey, sender string) ports.WhatsAppSender {
+func NewInfobipClient(baseURL, apiKey, sender string) ports.ChannelSender {
 	return &InfobipClient{
 		baseURL: baseURL,
 		apiKey:  apiKey,
@@ -47,6 +48,84 @@ type InfobipSendRequest struct {
 	Messages []InfobipTextMessage `json:"messages"`
 }
 
+type InfobipTemplateRequest struct {
+	Messages []InfobipTemplateMessage `json:"messages"`
+}
+
+type InfobipTemplateMessage struct {
+	From    string                 `json:"from"`
+	To      string                 `json:"to"`
+	Content InfobipTemplateContent `json:"content"`
+}
+
+type InfobipTemplateContent struct {
+	TemplateName string              `json:"templateName"`
+	TemplateData InfobipTemplateData `json:"templateData"`
+	Language     string              `json:"language"`
+}
+
+type InfobipTemplateData struct {
+	Body    InfobipTemplateBody     `json:"body"`
+	Buttons []InfobipTemplateButton `json:"buttons,omitempty"`
+}
+
+type InfobipTemplateBody struct {
+	Placeholders []string `json:"placeholders"`
+}
+
+// InfobipTemplateButton sets the payload a quick reply button of the
+// template sends back.
+type InfobipTemplateButton struct {
+	Type      string `json:"type"`
+	Parameter string `json:"parameter"`
+}
+
+// InfobipInteractiveMessage is a message with reply buttons or a list
+// picker, depending on the endpoint it is sent to.
+type InfobipInteractiveMessage struct {
+	From    string                    `json:"from"`
+	To      string                    `json:"to"`
+	Content InfobipInteractiveContent `json:"content"`
+}
+
+type InfobipInteractiveContent struct {
+	Body   InfobipInteractiveBody   `json:"body"`
+	Action InfobipInteractiveAction `json:"action"`
+}
+
+type InfobipInteractiveBody struct {
+	Text string `json:"text"`
+}
+
+// InfobipInteractiveAction holds the buttons of a buttons message, or the
+// title of the button opening the list and its sections for a list one.
+type InfobipInteractiveAction struct {
+	Buttons  []InfobipReplyButton `json:"buttons,omitempty"`
+	Title    string               `json:"title,omitempty"`
+	Sections []InfobipListSection `json:"sections,omitempty"`
+}
+
+type InfobipReplyButton struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type InfobipListSection struct {
+	Title string           `json:"title,omitempty"`
+	Rows  []InfobipListRow `json:"rows"`
+}
+
+type InfobipListRow struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+func (c *InfobipClient) Channel() domain.Channel {
+	return domain.ChannelWhatsApp
+}
+
 func (c *InfobipClient) SendText(ctx context.Context, to, text string) error {
 	request := InfobipSendRequest{
 		Messages: []InfobipTextMessage{
@@ -60,12 +139,86 @@ func (c *InfobipClient) SendText(ctx context.Context, to, text string) error {
 		},
 	}
 
+	return c.post(ctx, "/whatsapp/1/message/text", request)
+}
+
+// SendInteractive sends text with reply buttons or, when the options come
+// as a list, with a list picker.
+func (c *InfobipClient) SendInteractive(ctx context.Context, to, text string, interactive domain.Interactive) error {
+	if interactive.List != nil {
+		return c.post(ctx, "/whatsapp/1/message/interactive/list", InfobipInteractiveMessage{
+			From: c.sender,
+			To:   to,
+			Content: InfobipInteractiveContent{
+				Body:   InfobipInteractiveBody{Text: text},
+				Action: newInfobipListAction(interactive.List),
+			},
+		})
+	}
+
+	buttons := make([]InfobipReplyButton, len(interactive.Buttons))
+	for i, option := range interactive.Buttons {
+		buttons[i] = InfobipReplyButton{Type: "REPLY", ID: option.ID, Title: option.Title}
+	}
+	return c.post(ctx, "/whatsapp/1/message/interactive/buttons", InfobipInteractiveMessage{
+		From: c.sender,
+		To:   to,
+		Content: InfobipInteractiveContent{
+			Body:   InfobipInteractiveBody{Text: text},
+			Action: InfobipInteractiveAction{Buttons: buttons},
+		},
+	})
+}
+
+func (c *InfobipClient) SendTemplate(ctx context.Context, to string, template domain.TemplateMessage) error {
+	placeholders := template.Params
+	if placeholders == nil {
+		placeholders = []string{}
+	}
+	buttons := make([]InfobipTemplateButton, len(template.ButtonPayloads))
+	for i, payload := range template.ButtonPayloads {
+		buttons[i] = InfobipTemplateButton{Type: "QUICK_REPLY", Parameter: payload}
+	}
+
+	request := InfobipTemplateRequest{
+		Messages: []InfobipTemplateMessage{
+			{
+				From: c.sender,
+				To:   to,
+				Content: InfobipTemplateContent{
+					TemplateName: template.Name,
+					TemplateData: InfobipTemplateData{
+						Body:    InfobipTemplateBody{Placeholders: placeholders},
+						Buttons: buttons,
+					},
+					Language: template.Language,
+				},
+			},
+		},
+	}
+
+	return c.post(ctx, "/whatsapp/1/message/template", request)
+}
+
+func newInfobipListAction(list *domain.OptionList) InfobipInteractiveAction {
+	sections := make([]InfobipListSection, len(list.Sections))
+	for i, section := range list.Sections {
+		rows := make([]InfobipListRow, len(section.Options))
+		for j, option := range section.Options {
+			rows[j] = InfobipListRow{ID: option.ID, Title: option.Title, Description: option.Description}
+		}
+		sections[i] = InfobipListSection{Title: section.Title, Rows: rows}
+	}
+	return InfobipInteractiveAction{Title: list.Button, Sections: sections}
+}
+
+func (c *InfobipClient) post(ctx context.Context, path string, request interface{}) error {
 	body, err := json.Marshal(request)
 	if err != nil {
 		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	url := fmt.Sprintf("%s/whatsapp/1/message/text", c.baseURL)
+	url := c.baseURL + path
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
@@ -0,0 +1,157 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alarm-agent/internal/domain"
+)
+
+func TestInfobipParser_Parse_Replies(t *testing.T) {
+	payload := []byte(`{
+		"results": [
+			{
+				"messageId": "ABGGFlA5FpafAgo6EhsVvB3xN7bA",
+				"from": "5511999999999",
+				"to": "5511888888888",
+				"receivedAt": "2024-01-01T10:00:00Z",
+				"message": {"type": "INTERACTIVE_BUTTON_REPLY", "id": "confirm:42", "title": "✅ Confirmar"},
+				"contact": {"name": "Ana"}
+			},
+			{
+				"messageId": "ABGGFlA5FpafAgo6EhsVvB3xN7bB",
+				"from": "5511999999999",
+				"to": "5511888888888",
+				"receivedAt": "2024-01-01T10:01:00Z",
+				"message": {"type": "INTERACTIVE_LIST_REPLY", "id": "snooze:42", "title": "⏰ Adiar", "description": "10 minutos"}
+			},
+			{
+				"messageId": "ABGGFlA5FpafAgo6EhsVvB3xN7bC",
+				"from": "5511999999999",
+				"to": "5511888888888",
+				"receivedAt": "2024-01-01T10:02:00Z",
+				"message": {"type": "BUTTON", "text": "Confirmar", "payload": "confirm:42"}
+			}
+		]
+	}`)
+
+	messages, err := NewInfobipParser().Parse(payload)
+	require.NoError(t, err)
+	require.Len(t, messages, 3)
+
+	assert.Equal(t, ParsedMessage{
+		ID:          "ABGGFlA5FpafAgo6EhsVvB3xN7bA",
+		Channel:     domain.ChannelWhatsApp,
+		From:        "5511999999999",
+		To:          "5511888888888",
+		Timestamp:   time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		Type:        "INTERACTIVE_BUTTON_REPLY",
+		Text:        "✅ Confirmar",
+		Payload:     "confirm:42",
+		ContactName: "Ana",
+	}, messages[0])
+	assert.Equal(t, "snooze:42", messages[1].Payload)
+	assert.Equal(t, "⏰ Adiar", messages[1].Text)
+	assert.Equal(t, "confirm:42", messages[2].Payload, "quick reply of a template")
+	assert.Equal(t, "Confirmar", messages[2].Text)
+}
+
+func TestInfobipClient_SendInteractive(t *testing.T) {
+	requests := make(map[string]map[string]interface{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "App key", r.Header.Get("Authorization"))
+		body, _ := io.ReadAll(r.Body)
+		var request map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &request))
+		requests[r.URL.Path] = request
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewInfobipClient(server.URL, "key", "5511888888888")
+
+	err := client.SendInteractive(context.Background(), "5511999999999", "Confirma?", domain.Interactive{
+		Buttons: []domain.ReplyOption{{ID: "confirm:42", Title: "Confirmar"}, {ID: "cancel:42", Title: "Cancelar"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"from": "5511888888888",
+		"to":   "5511999999999",
+		"content": map[string]interface{}{
+			"body": map[string]interface{}{"text": "Confirma?"},
+			"action": map[string]interface{}{
+				"buttons": []interface{}{
+					map[string]interface{}{"type": "REPLY", "id": "confirm:42", "title": "Confirmar"},
+					map[string]interface{}{"type": "REPLY", "id": "cancel:42", "title": "Cancelar"},
+				},
+			},
+		},
+	}, requests["/whatsapp/1/message/interactive/buttons"])
+
+	err = client.SendInteractive(context.Background(), "5511999999999", "Qual evento?", domain.Interactive{
+		List: &domain.OptionList{
+			Button: "Eventos",
+			Sections: []domain.OptionSection{{
+				Options: []domain.ReplyOption{{ID: "confirm:42", Title: "Dentista", Description: "Amanhã às 14h"}},
+			}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"title": "Eventos",
+		"sections": []interface{}{
+			map[string]interface{}{
+				"rows": []interface{}{
+					map[string]interface{}{"id": "confirm:42", "title": "Dentista", "description": "Amanhã às 14h"},
+				},
+			},
+		},
+	}, requests["/whatsapp/1/message/interactive/list"]["content"].(map[string]interface{})["action"])
+}
+
+func TestInfobipClient_SendTemplate(t *testing.T) {
+	var path string
+	var request map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &request))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewInfobipClient(server.URL, "key", "5511888888888")
+	err := client.SendTemplate(context.Background(), "5511999999999", domain.TemplateMessage{
+		Name:           "alarm_confirmation",
+		Language:       "pt_BR",
+		Params:         []string{"Dentista", "amanhã às 14h"},
+		ButtonPayloads: []string{"confirm:42"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/whatsapp/1/message/template", path)
+	assert.Equal(t, map[string]interface{}{
+		"messages": []interface{}{
+			map[string]interface{}{
+				"from": "5511888888888",
+				"to":   "5511999999999",
+				"content": map[string]interface{}{
+					"templateName": "alarm_confirmation",
+					"templateData": map[string]interface{}{
+						"body":    map[string]interface{}{"placeholders": []interface{}{"Dentista", "amanhã às 14h"}},
+						"buttons": []interface{}{map[string]interface{}{"type": "QUICK_REPLY", "parameter": "confirm:42"}},
+					},
+					"language": "pt_BR",
+				},
+			},
+		},
+	}, request)
+}
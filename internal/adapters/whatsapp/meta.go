@@ -0,0 +1,201 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+// MetaClient sends messages through the Meta WhatsApp Cloud API.
+type MetaClient struct {
+	baseURL       string
+	accessToken   string
+	phoneNumberID string
+	httpClient    *http.Client
+}
+
+func NewMetaClient(baseURL, accessToken, phoneNumberID string) ports.ChannelSender {
+	return &MetaClient{
+		baseURL:       baseURL,
+		accessToken:   accessToken,
+		phoneNumberID: phoneNumberID,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+type MetaTextMessage struct {
+	MessagingProduct string          `json:"messaging_product"`
+	RecipientType    string          `json:"recipient_type"`
+	To               string          `json:"to"`
+	Type             string          `json:"type"`
+	Text             MetaTextContent `json:"text"`
+}
+
+type MetaTextContent struct {
+	Body       string `json:"body"`
+	PreviewURL bool   `json:"preview_url"`
+}
+
+type MetaTemplateMessage struct {
+	MessagingProduct string              `json:"messaging_product"`
+	RecipientType    string              `json:"recipient_type"`
+	To               string              `json:"to"`
+	Type             string              `json:"type"`
+	Template         MetaTemplateContent `json:"template"`
+}
+
+type MetaTemplateContent struct {
+	Name       string                  `json:"name"`
+	Language   MetaTemplateLanguage    `json:"language"`
+	Components []MetaTemplateComponent `json:"components,omitempty"`
+}
+
+type MetaTemplateLanguage struct {
+	Code string `json:"code"`
+}
+
+// MetaTemplateComponent fills the body placeholders or, for a quick reply
+// button, sets the payload it sends back.
+type MetaTemplateComponent struct {
+	Type       string                  `json:"type"`
+	SubType    string                  `json:"sub_type,omitempty"`
+	Index      string                  `json:"index,omitempty"`
+	Parameters []MetaTemplateParameter `json:"parameters"`
+}
+
+type MetaTemplateParameter struct {
+	Type    string `json:"type"`
+	Text    string `json:"text,omitempty"`
+	Payload string `json:"payload,omitempty"`
+}
+
+func (c *MetaClient) Channel() domain.Channel {
+	return domain.ChannelWhatsApp
+}
+
+func (c *MetaClient) SendText(ctx context.Context, to, text string) error {
+	return c.send(ctx, MetaTextMessage{
+		MessagingProduct: "whatsapp",
+		RecipientType:    "individual",
+		To:               strings.TrimPrefix(to, "+"),
+		Type:             "text",
+		Text:             MetaTextContent{Body: text},
+	})
+}
+
+// SendInteractive sends the options as part of the text; interactive
+// messages are only implemented for Infobip.
+func (c *MetaClient) SendInteractive(ctx context.Context, to, text string, interactive domain.Interactive) error {
+	return c.SendText(ctx, to, interactive.AsText(text))
+}
+
+func (c *MetaClient) SendTemplate(ctx context.Context, to string, template domain.TemplateMessage) error {
+	var components []MetaTemplateComponent
+	if len(template.Params) > 0 {
+		parameters := make([]MetaTemplateParameter, len(template.Params))
+		for i, param := range template.Params {
+			parameters[i] = MetaTemplateParameter{Type: "text", Text: param}
+		}
+		components = append(components, MetaTemplateComponent{Type: "body", Parameters: parameters})
+	}
+	for i, payload := range template.ButtonPayloads {
+		components = append(components, MetaTemplateComponent{
+			Type:       "button",
+			SubType:    "quick_reply",
+			Index:      strconv.Itoa(i),
+			Parameters: []MetaTemplateParameter{{Type: "payload", Payload: payload}},
+		})
+	}
+
+	return c.send(ctx, MetaTemplateMessage{
+		MessagingProduct: "whatsapp",
+		RecipientType:    "individual",
+		To:               strings.TrimPrefix(to, "+"),
+		Type:             "template",
+		Template: MetaTemplateContent{
+			Name:       template.Name,
+			Language:   MetaTemplateLanguage{Code: template.Language},
+			Components: components,
+		},
+	})
+}
+
+func (c *MetaClient) send(ctx context.Context, message interface{}) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/%s/messages", c.baseURL, c.phoneNumberID)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.accessToken))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("meta API error %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
+// MetaWebhookVerifier checks the X-Hub-Signature-256 of the payloads Meta
+// posts, signed with the app secret, and answers the handshake Meta makes
+// when the webhook is subscribed.
+type MetaWebhookVerifier struct {
+	appSecret   string
+	verifyToken string
+}
+
+func NewMetaWebhookVerifier(appSecret, verifyToken string) ports.WhatsAppWebhookVerifier {
+	return &MetaWebhookVerifier{appSecret: appSecret, verifyToken: verifyToken}
+}
+
+// VerifySignature checks a "sha256=<hex>" signature. Unlike Infobip, a
+// missing signature is rejected once an app secret is configured.
+func (v *MetaWebhookVerifier) VerifySignature(payload []byte, signature string) bool {
+	if v.appSecret == "" {
+		return true // Skip verification if no secret configured
+	}
+
+	mac := hmac.New(sha256.New, []byte(v.appSecret))
+	mac.Write(payload)
+	expectedSignature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(signature), []byte(expectedSignature))
+}
+
+// VerifySubscription accepts the hub.mode and hub.verify_token of the
+// subscription handshake when the token is the configured one.
+func (v *MetaWebhookVerifier) VerifySubscription(mode, token string) bool {
+	if mode != "subscribe" || v.verifyToken == "" {
+		return false
+	}
+	return hmac.Equal([]byte(token), []byte(v.verifyToken))
+}
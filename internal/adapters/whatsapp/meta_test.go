@@ -0,0 +1,82 @@
+package whatsapp
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+func TestMetaWebhookVerifier_VerifySignature(t *testing.T) {
+	payload := []byte(`{"object":"whatsapp_business_account"}`)
+	mac := hmac.New(sha256.New, []byte("app-secret"))
+	mac.Write(payload)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	verifier := NewMetaWebhookVerifier("app-secret", "verify-token")
+	assert.True(t, verifier.VerifySignature(payload, signature))
+	assert.False(t, verifier.VerifySignature(payload, "sha256=00"))
+	assert.False(t, verifier.VerifySignature(payload, ""))
+
+	assert.True(t, NewMetaWebhookVerifier("", "").VerifySignature(payload, ""))
+}
+
+func TestMetaWebhookVerifier_VerifySubscription(t *testing.T) {
+	verifier, ok := NewMetaWebhookVerifier("app-secret", "verify-token").(ports.WhatsAppSubscriptionVerifier)
+	require.True(t, ok)
+
+	assert.True(t, verifier.VerifySubscription("subscribe", "verify-token"))
+	assert.False(t, verifier.VerifySubscription("subscribe", "wrong"))
+	assert.False(t, verifier.VerifySubscription("unsubscribe", "verify-token"))
+}
+
+func TestMetaParser_Parse(t *testing.T) {
+	payload := []byte(`{
+		"object": "whatsapp_business_account",
+		"entry": [{
+			"id": "1",
+			"changes": [{
+				"field": "messages",
+				"value": {
+					"messaging_product": "whatsapp",
+					"metadata": {"display_phone_number": "5511888888888", "phone_number_id": "123"},
+					"contacts": [{"wa_id": "5511999999999", "profile": {"name": "Ana"}}],
+					"messages": [
+						{"id": "wamid.1", "from": "5511999999999", "timestamp": "1704103200", "type": "text", "text": {"body": "Dentista amanhã às 14h"}},
+						{"id": "wamid.2", "from": "5511999999999", "timestamp": "1704103260", "type": "sticker"},
+						{"id": "wamid.3", "from": "5511999999999", "timestamp": "1704103320", "type": "button", "button": {"text": "Confirmar", "payload": "confirm:42"}}
+					],
+					"statuses": [{"id": "wamid.0", "status": "delivered"}]
+				}
+			}]
+		}]
+	}`)
+
+	messages, err := NewMetaParser().Parse(payload)
+	require.NoError(t, err)
+	require.Len(t, messages, 3)
+
+	assert.Equal(t, ParsedMessage{
+		ID:          "wamid.1",
+		Channel:     domain.ChannelWhatsApp,
+		From:        "5511999999999",
+		To:          "5511888888888",
+		Timestamp:   time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		Type:        "TEXT",
+		Text:        "Dentista amanhã às 14h",
+		ContactName: "Ana",
+	}, messages[0])
+	assert.Equal(t, "Unsupported message type: sticker", messages[1].Text)
+	assert.Equal(t, "BUTTON", messages[2].Type)
+	assert.Equal(t, "confirm:42", messages[2].Payload)
+
+	_, err = NewMetaParser().Parse([]byte("invalid json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,168 @@
+package whatsapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/alarm-agent/internal/domain"
+)
+
+type MetaWebhookRequest struct {
+	Object string      `json:"object"`
+	Entry  []MetaEntry `json:"entry"`
+}
+
+type MetaEntry struct {
+	ID      string       `json:"id"`
+	Changes []MetaChange `json:"changes"`
+}
+
+type MetaChange struct {
+	Field string          `json:"field"`
+	Value MetaChangeValue `json:"value"`
+}
+
+type MetaChangeValue struct {
+	MessagingProduct string               `json:"messaging_product"`
+	Metadata         MetaMetadata         `json:"metadata"`
+	Contacts         []MetaContact        `json:"contacts,omitempty"`
+	Messages         []MetaInboundMessage `json:"messages,omitempty"`
+}
+
+type MetaMetadata struct {
+	DisplayPhoneNumber string `json:"display_phone_number"`
+	PhoneNumberID      string `json:"phone_number_id"`
+}
+
+type MetaContact struct {
+	WAID    string `json:"wa_id"`
+	Profile struct {
+		Name string `json:"name"`
+	} `json:"profile"`
+}
+
+type MetaInboundMessage struct {
+	ID        string               `json:"id"`
+	From      string               `json:"from"`
+	Timestamp string               `json:"timestamp"`
+	Type      string               `json:"type"`
+	Text      *MetaInboundText     `json:"text,omitempty"`
+	Image     *MetaMediaContent    `json:"image,omitempty"`
+	Location  *MetaLocationContent `json:"location,omitempty"`
+	Button    *MetaButtonReply     `json:"button,omitempty"`
+}
+
+// MetaButtonReply is the quick reply button of a template the user tapped.
+type MetaButtonReply struct {
+	Text    string `json:"text"`
+	Payload string `json:"payload"`
+}
+
+type MetaInboundText struct {
+	Body string `json:"body"`
+}
+
+type MetaMediaContent struct {
+	ID       string  `json:"id"`
+	MimeType string  `json:"mime_type"`
+	Caption  *string `json:"caption,omitempty"`
+}
+
+type MetaLocationContent struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Name      *string `json:"name,omitempty"`
+	Address   *string `json:"address,omitempty"`
+}
+
+// ExtractMessages flattens the messages of every change. Status updates of
+// sent messages come in the same webhook and are skipped. Types are upper
+// cased to match the ones Infobip reports.
+func (r *MetaWebhookRequest) ExtractMessages() []ParsedMessage {
+	var messages []ParsedMessage
+
+	for _, entry := range r.Entry {
+		for _, change := range entry.Changes {
+			if change.Field != "messages" {
+				continue
+			}
+
+			names := make(map[string]string, len(change.Value.Contacts))
+			for _, contact := range change.Value.Contacts {
+				names[contact.WAID] = contact.Profile.Name
+			}
+
+			for _, inbound := range change.Value.Messages {
+				message := ParsedMessage{
+					ID:          inbound.ID,
+					Channel:     domain.ChannelWhatsApp,
+					From:        inbound.From,
+					To:          change.Value.Metadata.DisplayPhoneNumber,
+					Timestamp:   parseMetaTimestamp(inbound.Timestamp),
+					ContactName: names[inbound.From],
+				}
+
+				switch inbound.Type {
+				case "text":
+					message.Type = "TEXT"
+					if inbound.Text != nil {
+						message.Text = inbound.Text.Body
+					}
+				case "image":
+					message.Type = "IMAGE"
+					if inbound.Image != nil && inbound.Image.Caption != nil {
+						message.Text = *inbound.Image.Caption
+					}
+				case "location":
+					message.Type = "LOCATION"
+					if inbound.Location != nil {
+						locationJSON, _ := json.Marshal(inbound.Location)
+						message.Text = string(locationJSON)
+					}
+				case "button":
+					message.Type = "BUTTON"
+					if inbound.Button != nil {
+						message.Text = inbound.Button.Text
+						message.Payload = inbound.Button.Payload
+					}
+				default:
+					message.Type = inbound.Type
+					message.Text = fmt.Sprintf("Unsupported message type: %s", inbound.Type)
+				}
+
+				messages = append(messages, message)
+			}
+		}
+	}
+
+	return messages
+}
+
+// parseMetaTimestamp reads the Unix seconds Meta sends as a string.
+func parseMetaTimestamp(value string) time.Time {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0).UTC()
+}
+
+type MetaParser struct{}
+
+func NewMetaParser() InboundParser {
+	return MetaParser{}
+}
+
+func (MetaParser) SignatureHeader() string {
+	return "X-Hub-Signature-256"
+}
+
+func (MetaParser) Parse(payload []byte) ([]ParsedMessage, error) {
+	var request MetaWebhookRequest
+	if err := json.Unmarshal(payload, &request); err != nil {
+		return nil, fmt.Errorf("failed to parse meta payload: %w", err)
+	}
+	return request.ExtractMessages(), nil
+}
@@ -0,0 +1,59 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+// OutboxSender queues messages in the outbox instead of calling the provider,
+// so they are delivered by the outbox dispatcher with retries. wake, when
+// set, lets the dispatcher pick them up without waiting for its next tick.
+type OutboxSender struct {
+	outbox       ports.OutboundMessageRepository
+	timeProvider ports.TimeProvider
+	wake         func()
+}
+
+func NewOutboxSender(outbox ports.OutboundMessageRepository, timeProvider ports.TimeProvider, wake func()) ports.MessageSender {
+	return &OutboxSender{
+		outbox:       outbox,
+		timeProvider: timeProvider,
+		wake:         wake,
+	}
+}
+
+func (s *OutboxSender) SendText(ctx context.Context, to, text string) error {
+	return s.queue(ctx, domain.NewOutboundMessage(to, text, s.timeProvider.Now()))
+}
+
+func (s *OutboxSender) SendInteractive(ctx context.Context, to, text string, interactive domain.Interactive) error {
+	message := domain.NewOutboundMessage(to, text, s.timeProvider.Now())
+	message.Interactive = &interactive
+	return s.queue(ctx, message)
+}
+
+// SendTemplate queues a message without text, so it is always delivered as
+// the template.
+func (s *OutboxSender) SendTemplate(ctx context.Context, to string, template domain.TemplateMessage) error {
+	message := domain.NewOutboundMessage(to, "", s.timeProvider.Now())
+	message.Template = &template
+	return s.queue(ctx, message)
+}
+
+func (s *OutboxSender) SendToUser(ctx context.Context, user *domain.User, text string) error {
+	return s.queue(ctx, domain.NewUserMessage(user, text, s.timeProvider.Now()))
+}
+
+func (s *OutboxSender) queue(ctx context.Context, message *domain.OutboundMessage) error {
+	if err := s.outbox.Create(ctx, message); err != nil {
+		return fmt.Errorf("failed to queue message: %w", err)
+	}
+
+	if s.wake != nil {
+		s.wake()
+	}
+	return nil
+}
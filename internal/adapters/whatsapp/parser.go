@@ -0,0 +1,33 @@
+package whatsapp
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// InboundParser reads the messages out of the webhook payloads of a
+// provider, so the webhook handler does not depend on any one of them.
+type InboundParser interface {
+	// SignatureHeader names the request header carrying the payload
+	// signature.
+	SignatureHeader() string
+	Parse(payload []byte) ([]ParsedMessage, error)
+}
+
+type InfobipParser struct{}
+
+func NewInfobipParser() InboundParser {
+	return InfobipParser{}
+}
+
+func (InfobipParser) SignatureHeader() string {
+	return "X-Signature-256"
+}
+
+func (InfobipParser) Parse(payload []byte) ([]ParsedMessage, error) {
+	var request InfobipWebhookRequest
+	if err := json.Unmarshal(payload, &request); err != nil {
+		return nil, fmt.Errorf("failed to parse infobip payload: %w", err)
+	}
+	return request.ExtractMessages(), nil
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/alarm-agent/internal/domain"
 )
 
 type InfobipWebhookRequest struct {
@@ -21,8 +23,13 @@ type InfobipInboundMessage struct {
 }
 
 type InfobipMessageContent struct {
-	Type     string                  `json:"type"`
-	Text     *string                 `json:"text,omitempty"`
+	Type string  `json:"type"`
+	Text *string `json:"text,omitempty"`
+	// ID and Title are those of the option chosen in a reply to buttons or
+	// a list, and Payload that of the template button tapped.
+	ID       *string                 `json:"id,omitempty"`
+	Title    *string                 `json:"title,omitempty"`
+	Payload  *string                 `json:"payload,omitempty"`
 	Image    *InfobipMediaContent    `json:"image,omitempty"`
 	Document *InfobipMediaContent    `json:"document,omitempty"`
 	Audio    *InfobipMediaContent    `json:"audio,omitempty"`
@@ -57,6 +64,7 @@ func (r *InfobipWebhookRequest) ExtractMessages() []ParsedMessage {
 	for _, result := range r.Results {
 		message := ParsedMessage{
 			ID:        result.MessageID,
+			Channel:   domain.ChannelWhatsApp,
 			From:      result.From,
 			To:        result.To,
 			Timestamp: result.ReceivedAt,
@@ -91,6 +99,20 @@ func (r *InfobipWebhookRequest) ExtractMessages() []ParsedMessage {
 				locationJSON, _ := json.Marshal(locationData)
 				message.Text = string(locationJSON)
 			}
+		case "BUTTON":
+			if result.Message.Payload != nil {
+				message.Payload = *result.Message.Payload
+			}
+			if result.Message.Text != nil {
+				message.Text = *result.Message.Text
+			}
+		case "INTERACTIVE_BUTTON_REPLY", "INTERACTIVE_LIST_REPLY":
+			if result.Message.ID != nil {
+				message.Payload = *result.Message.ID
+			}
+			if result.Message.Title != nil {
+				message.Text = *result.Message.Title
+			}
 		default:
 			message.Text = fmt.Sprintf("Unsupported message type: %s", result.Message.Type)
 		}
@@ -105,13 +127,18 @@ func (r *InfobipWebhookRequest) ExtractMessages() []ParsedMessage {
 	return messages
 }
 
+// ParsedMessage is an inbound message of any channel; From is the sender's
+// ID on Channel. Replies to interactive messages and template buttons carry
+// the ID or payload of the chosen option in Payload and its title in Text.
 type ParsedMessage struct {
-	ID          string    `json:"id"`
-	From        string    `json:"from"`
-	To          string    `json:"to"`
-	Timestamp   time.Time `json:"timestamp"`
-	Type        string    `json:"type"`
-	Text        string    `json:"text"`
-	MediaURL    string    `json:"media_url,omitempty"`
-	ContactName string    `json:"contact_name,omitempty"`
+	ID          string         `json:"id"`
+	Channel     domain.Channel `json:"channel,omitempty"`
+	From        string         `json:"from"`
+	To          string         `json:"to"`
+	Timestamp   time.Time      `json:"timestamp"`
+	Type        string         `json:"type"`
+	Text        string         `json:"text"`
+	MediaURL    string         `json:"media_url,omitempty"`
+	Payload     string         `json:"payload,omitempty"`
+	ContactName string         `json:"contact_name,omitempty"`
 }
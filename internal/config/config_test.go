@@ -52,6 +52,91 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			expectedErr: "INFOBIP_WHATSAPP_SENDER is required",
 		},
+		{
+			name: "invalid catch-up policy",
+			config: Config{
+				Infobip: InfobipConfig{
+					APIKey:         "test-key",
+					WhatsAppSender: "test-sender",
+				},
+				Worker: WorkerConfig{
+					CatchUpMode: "resend",
+				},
+			},
+			expectedErr: "REMINDER_CATCHUP_POLICY",
+		},
+		{
+			name: "meta provider",
+			config: Config{
+				WhatsApp: WhatsAppConfig{Provider: WhatsAppProviderMeta},
+				Meta: MetaConfig{
+					AccessToken:   "test-token",
+					PhoneNumberID: "123456",
+				},
+			},
+			expectedErr: "",
+		},
+		{
+			name: "missing meta phone number id",
+			config: Config{
+				WhatsApp: WhatsAppConfig{Provider: WhatsAppProviderMeta},
+				Meta: MetaConfig{
+					AccessToken: "test-token",
+				},
+			},
+			expectedErr: "META_PHONE_NUMBER_ID is required",
+		},
+		{
+			name: "unknown provider",
+			config: Config{
+				WhatsApp: WhatsAppConfig{Provider: "twilio"},
+			},
+			expectedErr: "WHATSAPP_PROVIDER",
+		},
+		{
+			name: "telegram polling",
+			config: Config{
+				Infobip: InfobipConfig{
+					APIKey:         "test-key",
+					WhatsAppSender: "test-sender",
+				},
+				Telegram: TelegramConfig{BotToken: "123:abc", Mode: TelegramModePolling},
+			},
+			expectedErr: "",
+		},
+		{
+			name: "telegram webhook",
+			config: Config{
+				Infobip: InfobipConfig{
+					APIKey:         "test-key",
+					WhatsAppSender: "test-sender",
+				},
+				Telegram: TelegramConfig{BotToken: "123:abc", Mode: TelegramModeWebhook, WebhookSecret: "secret"},
+			},
+			expectedErr: "",
+		},
+		{
+			name: "telegram webhook without secret",
+			config: Config{
+				Infobip: InfobipConfig{
+					APIKey:         "test-key",
+					WhatsAppSender: "test-sender",
+				},
+				Telegram: TelegramConfig{BotToken: "123:abc", Mode: TelegramModeWebhook},
+			},
+			expectedErr: "TELEGRAM_WEBHOOK_SECRET",
+		},
+		{
+			name: "unknown telegram mode",
+			config: Config{
+				Infobip: InfobipConfig{
+					APIKey:         "test-key",
+					WhatsAppSender: "test-sender",
+				},
+				Telegram: TelegramConfig{BotToken: "123:abc", Mode: "push"},
+			},
+			expectedErr: "TELEGRAM_MODE",
+		},
 		{
 			name: "no llm validation needed",
 			config: Config{
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// CatchUpMode is what happens to reminders that became due while no worker
+// was running to send them.
+type CatchUpMode string
+
+const (
+	// CatchUpLate sends each missed reminder, marked as late.
+	CatchUpLate CatchUpMode = "late"
+	// CatchUpSummary sends each user a single message listing their missed
+	// reminders.
+	CatchUpSummary CatchUpMode = "summary"
+	// CatchUpDrop discards missed reminders of events that already started
+	// and sends the others late.
+	CatchUpDrop CatchUpMode = "drop"
+)
+
+func ParseCatchUpMode(value string) (CatchUpMode, error) {
+	switch mode := CatchUpMode(value); mode {
+	case CatchUpLate, CatchUpSummary, CatchUpDrop:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid catch-up mode %q: expected late, summary or drop", value)
+	}
+}
+
+// CatchUpPolicy decides which reminders count as missed and what is done
+// with them.
+type CatchUpPolicy struct {
+	Mode CatchUpMode
+	// Grace is how long after its due time a reminder is still sent as
+	// usual, covering the gap between worker ticks.
+	Grace time.Duration
+}
+
+// IsLate reports whether a reminder due at dueAt was missed by now.
+func (p CatchUpPolicy) IsLate(dueAt, now time.Time) bool {
+	return now.Sub(dueAt) > p.Grace
+}
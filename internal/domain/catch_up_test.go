@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCatchUpMode(t *testing.T) {
+	for _, value := range []string{"late", "summary", "drop"} {
+		mode, err := ParseCatchUpMode(value)
+		assert.NoError(t, err)
+		assert.Equal(t, CatchUpMode(value), mode)
+	}
+
+	_, err := ParseCatchUpMode("resend")
+	assert.Error(t, err)
+}
+
+func TestCatchUpPolicy_IsLate(t *testing.T) {
+	due := time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC)
+	policy := CatchUpPolicy{Mode: CatchUpLate, Grace: 2 * time.Minute}
+
+	assert.False(t, policy.IsLate(due, due))
+	assert.False(t, policy.IsLate(due, due.Add(2*time.Minute)))
+	assert.True(t, policy.IsLate(due, due.Add(3*time.Minute)))
+}
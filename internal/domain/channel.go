@@ -0,0 +1,31 @@
+package domain
+
+import "fmt"
+
+// Channel is a messaging service users talk to the agent on.
+type Channel string
+
+const (
+	ChannelWhatsApp Channel = "whatsapp"
+	ChannelTelegram Channel = "telegram"
+	ChannelSMS      Channel = "sms"
+	ChannelEmail    Channel = "email"
+)
+
+// ParseChannel validates a channel name.
+func ParseChannel(value string) (Channel, error) {
+	switch channel := Channel(value); channel {
+	case ChannelWhatsApp, ChannelTelegram, ChannelSMS, ChannelEmail:
+		return channel, nil
+	default:
+		return "", fmt.Errorf("invalid channel %q: expected whatsapp, telegram, sms or email", value)
+	}
+}
+
+// OrDefault is the channel, or WhatsApp when it is unset.
+func (c Channel) OrDefault() Channel {
+	if c == "" {
+		return ChannelWhatsApp
+	}
+	return c
+}
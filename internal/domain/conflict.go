@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ScheduleConflictError is returned when an event would overlap other
+// scheduled or confirmed events of the same user.
+type ScheduleConflictError struct {
+	Conflicts []Event
+}
+
+func (e *ScheduleConflictError) Error() string {
+	return fmt.Sprintf("event conflicts with %d existing event(s)", len(e.Conflicts))
+}
+
+// Overlaps reports whether two events share any time. Events without an end
+// are treated as instants, and all-day events never conflict since they
+// usually mark the day rather than block it.
+func (e *Event) Overlaps(other *Event) bool {
+	if e.AllDay || other.AllDay {
+		return false
+	}
+
+	start, end := e.span()
+	otherStart, otherEnd := other.span()
+	if start.Equal(otherStart) {
+		return true
+	}
+	return start.Before(otherEnd) && otherStart.Before(end)
+}
+
+func (e *Event) span() (time.Time, time.Time) {
+	if e.EndsAt != nil {
+		return e.StartsAt, *e.EndsAt
+	}
+	return e.StartsAt, e.StartsAt
+}
+
+// IsActive reports whether the event still blocks time on the calendar.
+func (e *Event) IsActive() bool {
+	return e.Status == EventStatusScheduled || e.Status == EventStatusConfirmed
+}
+
+// FindConflicts returns the active events in existing that overlap any of
+// the candidates. Occurrences of the candidates' own series and of the
+// ignored event IDs are skipped.
+func FindConflicts(candidates, existing []Event, ignore ...int) []Event {
+	skip := make(map[int]bool, len(ignore))
+	for _, id := range ignore {
+		skip[id] = true
+	}
+	for _, candidate := range candidates {
+		if candidate.ID != 0 {
+			skip[candidate.ID] = true
+		}
+	}
+
+	var conflicts []Event
+	for i := range existing {
+		if skip[existing[i].ID] || !existing[i].IsActive() {
+			continue
+		}
+		for j := range candidates {
+			if candidates[j].Overlaps(&existing[i]) {
+				conflicts = append(conflicts, existing[i])
+				break
+			}
+		}
+	}
+
+	return conflicts
+}
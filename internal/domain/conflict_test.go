@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_Overlaps(t *testing.T) {
+	base := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	at := func(minutes int) *time.Time {
+		v := base.Add(time.Duration(minutes) * time.Minute)
+		return &v
+	}
+
+	tests := []struct {
+		name string
+		a    Event
+		b    Event
+		want bool
+	}{
+		{name: "overlapping spans", a: Event{StartsAt: *at(0), EndsAt: at(60)}, b: Event{StartsAt: *at(30), EndsAt: at(90)}, want: true},
+		{name: "back to back", a: Event{StartsAt: *at(0), EndsAt: at(60)}, b: Event{StartsAt: *at(60), EndsAt: at(90)}, want: false},
+		{name: "instant inside span", a: Event{StartsAt: *at(30)}, b: Event{StartsAt: *at(0), EndsAt: at(60)}, want: true},
+		{name: "instant at end of span", a: Event{StartsAt: *at(60)}, b: Event{StartsAt: *at(0), EndsAt: at(60)}, want: false},
+		{name: "same instant", a: Event{StartsAt: *at(0)}, b: Event{StartsAt: *at(0)}, want: true},
+		{name: "different instants", a: Event{StartsAt: *at(0)}, b: Event{StartsAt: *at(30)}, want: false},
+		{name: "all day", a: Event{StartsAt: *at(-840), EndsAt: at(600), AllDay: true}, b: Event{StartsAt: *at(0), EndsAt: at(60)}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.a.Overlaps(&tt.b))
+			assert.Equal(t, tt.want, tt.b.Overlaps(&tt.a))
+		})
+	}
+}
+
+func TestFindConflicts(t *testing.T) {
+	start := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	candidate := Event{ID: 1, StartsAt: start, EndsAt: &end}
+
+	existing := []Event{
+		{ID: 1, Title: "itself", StartsAt: start, Status: EventStatusScheduled},
+		{ID: 2, Title: "scheduled", StartsAt: start.Add(30 * time.Minute), Status: EventStatusScheduled},
+		{ID: 3, Title: "canceled", StartsAt: start, Status: EventStatusCanceled},
+		{ID: 4, Title: "confirmed", StartsAt: start, Status: EventStatusConfirmed},
+		{ID: 5, Title: "ignored", StartsAt: start, Status: EventStatusScheduled},
+		{ID: 6, Title: "later", StartsAt: end, Status: EventStatusScheduled},
+	}
+
+	conflicts := FindConflicts([]Event{candidate}, existing, 5)
+
+	var titles []string
+	for _, conflict := range conflicts {
+		titles = append(titles, conflict.Title)
+	}
+	assert.Equal(t, []string{"scheduled", "confirmed"}, titles)
+}
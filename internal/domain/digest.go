@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type DigestKind string
+
+const (
+	DigestDaily  DigestKind = "daily"
+	DigestWeekly DigestKind = "weekly"
+)
+
+// digestSendWindow is how long after its send time a digest is still sent,
+// so a restart shortly after the hour does not skip it while a long outage
+// does not deliver a morning agenda in the evening.
+const digestSendWindow = 3 * time.Hour
+
+// weeklyDigestDay is the day the week overview is sent, covering the
+// following Monday to Sunday.
+const weeklyDigestDay = time.Sunday
+
+const (
+	DefaultDailyDigestTime  = "07:00"
+	DefaultWeeklyDigestTime = "19:00"
+)
+
+// ParseClock parses a local time of day in HH:MM format.
+func ParseClock(value string) (int, int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time of day %q, expected HH:MM", value)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// DigestSchedule tells when a user's digest of the given kind goes out.
+type DigestSchedule struct {
+	Kind       DigestKind
+	Enabled    bool
+	Time       string
+	LastSentAt *time.Time
+}
+
+// Digest returns the user's schedule for the given digest kind.
+func (u *User) Digest(kind DigestKind) DigestSchedule {
+	if kind == DigestWeekly {
+		return DigestSchedule{Kind: kind, Enabled: u.WeeklyDigestEnabled, Time: u.WeeklyDigestTime, LastSentAt: u.LastWeeklyDigestAt}
+	}
+	return DigestSchedule{Kind: kind, Enabled: u.DailyDigestEnabled, Time: u.DailyDigestTime, LastSentAt: u.LastDailyDigestAt}
+}
+
+// DueAt returns the most recent send time of the digest at or before now in
+// loc, and whether the digest should be sent now.
+func (s DigestSchedule) DueAt(now time.Time, loc *time.Location) (time.Time, bool) {
+	if !s.Enabled {
+		return time.Time{}, false
+	}
+	hour, minute, err := ParseClock(s.Time)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	local := now.In(loc)
+	due := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, loc)
+	if due.After(now) {
+		due = due.AddDate(0, 0, -1)
+	}
+	if s.Kind == DigestWeekly && due.Weekday() != weeklyDigestDay {
+		return time.Time{}, false
+	}
+
+	if now.Sub(due) >= digestSendWindow {
+		return due, false
+	}
+	if s.LastSentAt != nil && !s.LastSentAt.Before(due) {
+		return due, false
+	}
+	return due, true
+}
+
+// Period returns the range of events a digest sent at due covers: the rest
+// of that day for daily digests, or the next Monday to Sunday for weekly ones.
+func (s DigestSchedule) Period(due time.Time, loc *time.Location) (time.Time, time.Time) {
+	day := StartOfDay(due, loc)
+	if s.Kind == DigestWeekly {
+		start := day.AddDate(0, 0, 1)
+		return start, start.AddDate(0, 0, 7).Add(-time.Nanosecond)
+	}
+	return day, day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
+
+// DigestSettings are the digest preferences a user can change by message.
+type DigestSettings struct {
+	DailyDigest      *bool   `json:"daily_digest"`
+	DailyDigestTime  *string `json:"daily_digest_time"`
+	WeeklyDigest     *bool   `json:"weekly_digest"`
+	WeeklyDigestTime *string `json:"weekly_digest_time"`
+}
+
+// Apply validates the settings and copies the given ones onto config.
+func (s *DigestSettings) Apply(config *UserConfig) error {
+	if s.DailyDigestTime != nil {
+		if _, _, err := ParseClock(*s.DailyDigestTime); err != nil {
+			return err
+		}
+		config.DailyDigestTime = *s.DailyDigestTime
+	}
+	if s.WeeklyDigestTime != nil {
+		if _, _, err := ParseClock(*s.WeeklyDigestTime); err != nil {
+			return err
+		}
+		config.WeeklyDigestTime = *s.WeeklyDigestTime
+	}
+	if s.DailyDigest != nil {
+		config.DailyDigestEnabled = *s.DailyDigest
+	}
+	if s.WeeklyDigest != nil {
+		config.WeeklyDigestEnabled = *s.WeeklyDigest
+	}
+	return nil
+}
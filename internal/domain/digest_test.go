@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigestSchedule_DueAt(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	// Sunday, 9 March 2025.
+	sunday := func(hour, minute int) time.Time {
+		return time.Date(2025, 3, 9, hour, minute, 0, 0, loc)
+	}
+	sentEarlier := sunday(6, 0)
+	sentToday := sunday(7, 1)
+
+	tests := []struct {
+		name     string
+		schedule DigestSchedule
+		now      time.Time
+		want     bool
+	}{
+		{name: "disabled", schedule: DigestSchedule{Kind: DigestDaily, Time: "07:00"}, now: sunday(7, 5)},
+		{name: "before send time", schedule: DigestSchedule{Kind: DigestDaily, Enabled: true, Time: "07:00"}, now: sunday(6, 59)},
+		{name: "at send time", schedule: DigestSchedule{Kind: DigestDaily, Enabled: true, Time: "07:00"}, now: sunday(7, 0), want: true},
+		{name: "sent yesterday", schedule: DigestSchedule{Kind: DigestDaily, Enabled: true, Time: "07:00", LastSentAt: &sentEarlier}, now: sunday(7, 5), want: true},
+		{name: "already sent", schedule: DigestSchedule{Kind: DigestDaily, Enabled: true, Time: "07:00", LastSentAt: &sentToday}, now: sunday(7, 5)},
+		{name: "window passed", schedule: DigestSchedule{Kind: DigestDaily, Enabled: true, Time: "07:00"}, now: sunday(10, 0)},
+		{name: "weekly on sunday", schedule: DigestSchedule{Kind: DigestWeekly, Enabled: true, Time: "19:00"}, now: sunday(19, 30), want: true},
+		{name: "weekly on monday", schedule: DigestSchedule{Kind: DigestWeekly, Enabled: true, Time: "19:00"}, now: sunday(19, 30).AddDate(0, 0, 1)},
+		{name: "invalid time", schedule: DigestSchedule{Kind: DigestDaily, Enabled: true, Time: "7h"}, now: sunday(7, 5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, due := tt.schedule.DueAt(tt.now, loc)
+			assert.Equal(t, tt.want, due)
+		})
+	}
+}
+
+func TestDigestSchedule_Period(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	due := time.Date(2025, 3, 9, 19, 0, 0, 0, loc)
+
+	start, end := DigestSchedule{Kind: DigestDaily}.Period(due, loc)
+	assert.Equal(t, time.Date(2025, 3, 9, 0, 0, 0, 0, loc), start)
+	assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, loc).Add(-time.Nanosecond), end)
+
+	start, end = DigestSchedule{Kind: DigestWeekly}.Period(due, loc)
+	assert.Equal(t, time.Monday, start.Weekday())
+	assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, loc), start)
+	assert.Equal(t, time.Date(2025, 3, 17, 0, 0, 0, 0, loc).Add(-time.Nanosecond), end)
+}
+
+func TestDigestSettings_Apply(t *testing.T) {
+	enabled := true
+	valid := "06:45"
+	invalid := "25:00"
+
+	config := &UserConfig{DailyDigestTime: DefaultDailyDigestTime, WeeklyDigestTime: DefaultWeeklyDigestTime}
+	require.NoError(t, (&DigestSettings{DailyDigest: &enabled, DailyDigestTime: &valid}).Apply(config))
+	assert.True(t, config.DailyDigestEnabled)
+	assert.Equal(t, "06:45", config.DailyDigestTime)
+	assert.False(t, config.WeeklyDigestEnabled)
+	assert.Equal(t, DefaultWeeklyDigestTime, config.WeeklyDigestTime)
+
+	assert.Error(t, (&DigestSettings{WeeklyDigestTime: &invalid}).Apply(config))
+}
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// maxEscalationSteps bounds how long an escalation chain can be.
+const maxEscalationSteps = 5
+
+// EscalationStep tells the user's allowed contacts that an event is still
+// unconfirmed once AfterReminders reminders went unanswered. Contacts picks
+// which of the allowed contacts are told; empty means all of them.
+type EscalationStep struct {
+	AfterReminders int      `json:"after_reminders"`
+	Contacts       []string `json:"contacts,omitempty"`
+}
+
+// EscalationPolicy is a chain of escalation steps, each one reached after
+// more unanswered reminders than the previous one.
+type EscalationPolicy []EscalationStep
+
+// NewEscalationPolicy validates the steps.
+func NewEscalationPolicy(steps []EscalationStep) (EscalationPolicy, error) {
+	if len(steps) > maxEscalationSteps {
+		return nil, fmt.Errorf("at most %d escalation steps are allowed", maxEscalationSteps)
+	}
+
+	previous := 0
+	for _, step := range steps {
+		if step.AfterReminders < 1 {
+			return nil, fmt.Errorf("escalation steps must follow at least one reminder")
+		}
+		if step.AfterReminders <= previous {
+			return nil, fmt.Errorf("escalation steps must follow an increasing number of reminders")
+		}
+		for _, contact := range step.Contacts {
+			if contact == "" {
+				return nil, fmt.Errorf("escalation contacts must not be empty")
+			}
+		}
+		previous = step.AfterReminders
+	}
+
+	return EscalationPolicy(steps), nil
+}
+
+// DueSteps returns the indexes of the steps past level, the number of steps
+// already taken, that unanswered reminders have reached.
+func (p EscalationPolicy) DueSteps(level, unanswered int) []int {
+	var due []int
+	for i := level; i < len(p) && p[i].AfterReminders <= unanswered; i++ {
+		due = append(due, i)
+	}
+	return due
+}
+
+// Recipients returns the numbers of the allowed contacts the step tells.
+// Numbers that are no longer allowed contacts are skipped.
+func (s EscalationStep) Recipients(allowed []UserAllowedContact) []string {
+	var numbers []string
+	for _, contact := range allowed {
+		if len(s.Contacts) == 0 {
+			numbers = append(numbers, contact.ContactNumber)
+			continue
+		}
+		for _, number := range s.Contacts {
+			if number == contact.ContactNumber {
+				numbers = append(numbers, number)
+				break
+			}
+		}
+	}
+	return numbers
+}
+
+// Scan reads a JSONB escalation policy column.
+func (p *EscalationPolicy) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*p = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into EscalationPolicy", src)
+	}
+	return json.Unmarshal(data, (*[]EscalationStep)(p))
+}
+
+// Value writes the steps as JSON, using an empty array when there are none.
+func (p EscalationPolicy) Value() (driver.Value, error) {
+	if p == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]EscalationStep(p))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// EscalationChain is the policy the event follows: its own when set, the
+// user's default otherwise. Low priority events only escalate with their own
+// policy, and critical ones fall back to telling every allowed contact when
+// the user has no default.
+func (e *Event) EscalationChain(user *User) EscalationPolicy {
+	if e.EscalationPolicy != nil {
+		return *e.EscalationPolicy
+	}
+	nagging := e.Priority.nagging()
+	if !nagging.escalates {
+		return nil
+	}
+	if len(user.DefaultEscalationPolicy) == 0 {
+		return nagging.escalation
+	}
+	return user.DefaultEscalationPolicy
+}
+
+type EscalationStatus string
+
+const (
+	// EscalationNotified marks a contact that was told about the missing
+	// confirmation.
+	EscalationNotified EscalationStatus = "notified"
+	// EscalationCanceled marks an escalation the user confirmed after.
+	EscalationCanceled EscalationStatus = "canceled"
+)
+
+// EventEscalation records that a contact was told an occurrence of an event
+// was still unconfirmed.
+type EventEscalation struct {
+	ID                  int              `json:"id" db:"id"`
+	EventID             int              `json:"event_id" db:"event_id"`
+	UserID              int              `json:"user_id" db:"user_id"`
+	OccurrenceStartsAt  time.Time        `json:"occurrence_starts_at" db:"occurrence_starts_at"`
+	Step                int              `json:"step" db:"step"`
+	ContactNumber       string           `json:"contact_number" db:"contact_number"`
+	UnansweredReminders int              `json:"unanswered_reminders" db:"unanswered_reminders"`
+	Status              EscalationStatus `json:"status" db:"status"`
+	NotifiedAt          time.Time        `json:"notified_at" db:"notified_at"`
+	CanceledAt          *time.Time       `json:"canceled_at,omitempty" db:"canceled_at"`
+	CreatedAt           time.Time        `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEscalationPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   []EscalationStep
+		wantErr bool
+	}{
+		{name: "empty", steps: []EscalationStep{}},
+		{name: "chain", steps: []EscalationStep{{AfterReminders: 2, Contacts: []string{"+5511900000002"}}, {AfterReminders: 3}}},
+		{name: "before any reminder", steps: []EscalationStep{{AfterReminders: 0}}, wantErr: true},
+		{name: "not increasing", steps: []EscalationStep{{AfterReminders: 2}, {AfterReminders: 2}}, wantErr: true},
+		{name: "empty contact", steps: []EscalationStep{{AfterReminders: 1, Contacts: []string{""}}}, wantErr: true},
+		{name: "too many steps", steps: []EscalationStep{{AfterReminders: 1}, {AfterReminders: 2}, {AfterReminders: 3}, {AfterReminders: 4}, {AfterReminders: 5}, {AfterReminders: 6}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewEscalationPolicy(tt.steps)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEscalationPolicy_DueSteps(t *testing.T) {
+	policy := EscalationPolicy{{AfterReminders: 1}, {AfterReminders: 2}, {AfterReminders: 4}}
+
+	assert.Empty(t, policy.DueSteps(0, 0))
+	assert.Equal(t, []int{0}, policy.DueSteps(0, 1))
+	assert.Equal(t, []int{0, 1}, policy.DueSteps(0, 3))
+	assert.Equal(t, []int{1}, policy.DueSteps(1, 3))
+	assert.Equal(t, []int{2}, policy.DueSteps(2, 5))
+	assert.Empty(t, policy.DueSteps(3, 9))
+}
+
+func TestEscalationStep_Recipients(t *testing.T) {
+	allowed := []UserAllowedContact{
+		{ContactNumber: "+5511900000002"},
+		{ContactNumber: "+5511900000003"},
+	}
+
+	assert.Equal(t, []string{"+5511900000002", "+5511900000003"}, EscalationStep{AfterReminders: 1}.Recipients(allowed))
+	assert.Equal(t, []string{"+5511900000003"}, EscalationStep{AfterReminders: 1, Contacts: []string{"+5511900000003", "+5511900000009"}}.Recipients(allowed))
+}
+
+func TestEvent_EscalationChain(t *testing.T) {
+	user := &User{DefaultEscalationPolicy: EscalationPolicy{{AfterReminders: 2}}}
+	event := &Event{}
+	assert.Equal(t, user.DefaultEscalationPolicy, event.EscalationChain(user))
+
+	disabled := EscalationPolicy{}
+	event.EscalationPolicy = &disabled
+	assert.Empty(t, event.EscalationChain(user))
+}
+
+func TestEvent_EscalationChainByPriority(t *testing.T) {
+	user := &User{DefaultEscalationPolicy: EscalationPolicy{{AfterReminders: 3}}}
+	own := EscalationPolicy{{AfterReminders: 1}}
+
+	assert.Empty(t, (&Event{Priority: PriorityLow}).EscalationChain(user))
+	assert.Equal(t, own, (&Event{Priority: PriorityLow, EscalationPolicy: &own}).EscalationChain(user))
+	assert.Equal(t, user.DefaultEscalationPolicy, (&Event{Priority: PriorityCritical}).EscalationChain(user))
+
+	// Critical events escalate even when the user has no default chain.
+	assert.Empty(t, (&Event{Priority: PriorityHigh}).EscalationChain(&User{}))
+	assert.Equal(t, EscalationPolicy{{AfterReminders: 2}}, (&Event{Priority: PriorityCritical}).EscalationChain(&User{}))
+}
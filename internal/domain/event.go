@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"sort"
 	"time"
 )
 
@@ -11,26 +12,393 @@ const (
 	EventStatusConfirmed EventStatus = "confirmed"
 	EventStatusCanceled  EventStatus = "canceled"
 	EventStatusCompleted EventStatus = "completed"
+	// EventStatusTentative holds an event that conflicts with others until
+	// the user decides whether to keep it.
+	EventStatusTentative EventStatus = "tentative"
+	// EventStatusNoResponse marks an event that required confirmation and
+	// started without getting one.
+	EventStatusNoResponse EventStatus = "no_response"
+)
+
+// EditScope selects which part of a recurring series an action applies to.
+type EditScope string
+
+const (
+	EditScopeOccurrence EditScope = "occurrence"
+	EditScopeFollowing  EditScope = "following"
+	EditScopeSeries     EditScope = "series"
 )
 
 type Event struct {
-	ID                     int         `json:"id" db:"id"`
-	UserID                 int         `json:"user_id" db:"user_id"`
-	Title                  string      `json:"title" db:"title"`
-	Location               *string     `json:"location,omitempty" db:"location"`
-	StartsAt               time.Time   `json:"starts_at" db:"starts_at"`
-	RemindBeforeMinutes    int         `json:"remind_before_minutes" db:"remind_before_minutes"`
-	RemindFrequencyMinutes int         `json:"remind_frequency_minutes" db:"remind_frequency_minutes"`
-	RequireConfirmation    bool        `json:"require_confirmation" db:"require_confirmation"`
-	MaxNotifications       int         `json:"max_notifications" db:"max_notifications"`
-	Status                 EventStatus `json:"status" db:"status"`
-	NotificationsSent      int         `json:"notifications_sent" db:"notifications_sent"`
-	LastNotifiedAt         *time.Time  `json:"last_notified_at,omitempty" db:"last_notified_at"`
-	CreatedAt              time.Time   `json:"created_at" db:"created_at"`
-	UpdatedAt              time.Time   `json:"updated_at" db:"updated_at"`
+	ID                     int              `json:"id" db:"id"`
+	UserID                 int              `json:"user_id" db:"user_id"`
+	Title                  string           `json:"title" db:"title"`
+	Location               *string          `json:"location,omitempty" db:"location"`
+	StartsAt               time.Time        `json:"starts_at" db:"starts_at"`
+	EndsAt                 *time.Time       `json:"ends_at,omitempty" db:"ends_at"`
+	AllDay                 bool             `json:"all_day" db:"all_day"`
+	RecurrenceRule         *string          `json:"recurrence_rule,omitempty" db:"recurrence_rule"`
+	NextOccurrenceAt       *time.Time       `json:"next_occurrence_at,omitempty" db:"next_occurrence_at"`
+	RemindBeforeMinutes    int              `json:"remind_before_minutes" db:"remind_before_minutes"`
+	ReminderOffsets        ReminderSchedule `json:"reminder_offsets,omitempty" db:"reminder_offsets"`
+	RemindFrequencyMinutes int              `json:"remind_frequency_minutes" db:"remind_frequency_minutes"`
+	RequireConfirmation    bool             `json:"require_confirmation" db:"require_confirmation"`
+	MaxNotifications       int              `json:"max_notifications" db:"max_notifications"`
+	Status                 EventStatus      `json:"status" db:"status"`
+	NotificationsSent      int              `json:"notifications_sent" db:"notifications_sent"`
+	LastNotifiedAt         *time.Time       `json:"last_notified_at,omitempty" db:"last_notified_at"`
+	SnoozedUntil           *time.Time       `json:"snoozed_until,omitempty" db:"snoozed_until"`
+	// DeferredUntil holds the reminder due during the user's quiet hours
+	// until they end. Unlike a snooze, the user did not ask for it, so the
+	// reminder still counts towards MaxNotifications.
+	DeferredUntil    *time.Time `json:"deferred_until,omitempty" db:"deferred_until"`
+	IgnoreQuietHours bool       `json:"ignore_quiet_hours" db:"ignore_quiet_hours"`
+	// Priority sets the defaults of how insistently the event is reminded.
+	Priority Priority `json:"priority" db:"priority"`
+	// EscalationPolicy overrides the user's default chain when set; an
+	// empty policy turns escalation off for the event.
+	EscalationPolicy *EscalationPolicy `json:"escalation_policy,omitempty" db:"escalation_policy"`
+	// EscalationLevel counts the escalation steps taken for the current
+	// occurrence.
+	EscalationLevel int       `json:"escalation_level" db:"escalation_level"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+
+	// OriginalStartsAt is set on occurrences expanded from a recurring
+	// series and holds the nominal start of that occurrence.
+	OriginalStartsAt *time.Time `json:"original_starts_at,omitempty" db:"-"`
+
+	// Exceptions are the per-occurrence overrides of a recurring series.
+	Exceptions []EventException `json:"-" db:"-"`
+
+	Participants []EventParticipant `json:"participants,omitempty" db:"-"`
+}
+
+// EventException overrides a single occurrence of a recurring series,
+// identified by its nominal start. A nil field keeps the series value.
+type EventException struct {
+	ID               int          `json:"id" db:"id"`
+	EventID          int          `json:"event_id" db:"event_id"`
+	OriginalStartsAt time.Time    `json:"original_starts_at" db:"original_starts_at"`
+	Status           *EventStatus `json:"status,omitempty" db:"status"`
+	StartsAt         *time.Time   `json:"starts_at,omitempty" db:"starts_at"`
+	Title            *string      `json:"title,omitempty" db:"title"`
+	Location         *string      `json:"location,omitempty" db:"location"`
+	CreatedAt        time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+func (x *EventException) IsCanceled() bool {
+	return x.Status != nil && *x.Status == EventStatusCanceled
 }
 
 type EventWithUser struct {
 	Event
 	User User `json:"user"`
 }
+
+func (e *Event) IsRecurring() bool {
+	return e.RecurrenceRule != nil && *e.RecurrenceRule != ""
+}
+
+// IsOccurrence reports whether the event is a single expanded occurrence
+// rather than the series row itself.
+func (e *Event) IsOccurrence() bool {
+	return e.OriginalStartsAt != nil
+}
+
+// OccurrenceStartsAt is the start of the occurrence reminders are tracking:
+// the current occurrence for recurring events, StartsAt otherwise.
+func (e *Event) OccurrenceStartsAt() time.Time {
+	if e.IsRecurring() && e.NextOccurrenceAt != nil && !e.IsOccurrence() {
+		return *e.NextOccurrenceAt
+	}
+	return e.StartsAt
+}
+
+// HasReminderSchedule reports whether the event uses multi-stage reminders
+// instead of RemindBeforeMinutes repeated every RemindFrequencyMinutes.
+func (e *Event) HasReminderSchedule() bool {
+	return len(e.ReminderOffsets) > 0
+}
+
+// AwaitsReminders reports whether reminders are still owed for the
+// occurrence at now: until its reminder anchor, or until it ends when it has
+// an end.
+func (e *Event) AwaitsReminders(now time.Time) bool {
+	return now.Before(e.ReminderAnchor()) || e.IsOngoingOrUpcoming(now)
+}
+
+// DueReminderOffset returns the latest stage of the reminder schedule that is
+// due at now and not yet covered by a previous notification. Stages missed
+// while nothing was sent collapse into the most recent one.
+func (e *Event) DueReminderOffset(now time.Time) (int, bool) {
+	if !e.AwaitsReminders(now) {
+		return 0, false
+	}
+	start := e.ReminderAnchor()
+
+	for i := len(e.ReminderOffsets) - 1; i >= 0; i-- {
+		offset := e.ReminderOffsets[i]
+		due := start.Add(-time.Duration(offset) * time.Minute)
+		if due.After(now) {
+			continue
+		}
+		if e.LastNotifiedAt != nil && !due.After(*e.LastNotifiedAt) {
+			return 0, false
+		}
+		return offset, true
+	}
+
+	return 0, false
+}
+
+// ReminderDueAt returns when the reminder the event currently owes became
+// due: the end of a snooze, the latest unsent stage of its schedule, or the
+// next repetition of its RemindBeforeMinutes reminder, held until
+// DeferredUntil when it was deferred. It returns false when no reminder is
+// due at now.
+func (e *Event) ReminderDueAt(now time.Time) (time.Time, bool) {
+	if e.DeferredUntil != nil && now.Before(*e.DeferredUntil) {
+		return time.Time{}, false
+	}
+	dueAt, due := e.owedReminderAt(now)
+	if due && e.DeferredUntil != nil && dueAt.Before(*e.DeferredUntil) {
+		dueAt = *e.DeferredUntil
+	}
+	return dueAt, due
+}
+
+func (e *Event) owedReminderAt(now time.Time) (time.Time, bool) {
+	if e.SnoozedUntil != nil {
+		if now.Before(*e.SnoozedUntil) {
+			return time.Time{}, false
+		}
+		return *e.SnoozedUntil, true
+	}
+
+	if e.HasReminderSchedule() {
+		offset, due := e.DueReminderOffset(now)
+		if !due {
+			return time.Time{}, false
+		}
+		return e.ReminderAnchor().Add(-time.Duration(offset) * time.Minute), true
+	}
+
+	if !e.AwaitsReminders(now) || e.NotificationsSent >= e.MaxNotifications {
+		return time.Time{}, false
+	}
+	dueAt := e.ReminderAnchor().Add(-time.Duration(e.RemindBeforeMinutes) * time.Minute)
+	if e.LastNotifiedAt != nil {
+		if next := e.LastNotifiedAt.Add(time.Duration(e.RemindFrequencyMinutes) * time.Minute); next.After(dueAt) {
+			dueAt = next
+		}
+	}
+	if now.Before(dueAt) {
+		return time.Time{}, false
+	}
+	return dueAt, true
+}
+
+func (e *Event) Recurrence() (*RecurrenceRule, error) {
+	if !e.IsRecurring() {
+		return nil, nil
+	}
+	return ParseRecurrenceRule(*e.RecurrenceRule)
+}
+
+func (e *Event) ExceptionFor(original time.Time) *EventException {
+	for i := range e.Exceptions {
+		if e.Exceptions[i].OriginalStartsAt.Equal(original) {
+			return &e.Exceptions[i]
+		}
+	}
+	return nil
+}
+
+// Occurrence builds the occurrence of the series with the given nominal
+// start, applying its exception if any. It returns false when the
+// occurrence was canceled.
+func (e *Event) Occurrence(original time.Time) (Event, bool) {
+	occurrence := *e
+	occurrence.Exceptions = nil
+	occurrence.StartsAt = original
+	occurrence.EndsAt = e.endFor(original)
+	occurrence.OriginalStartsAt = &original
+
+	// A canceled series keeps every occurrence canceled.
+	seriesCanceled := e.Status == EventStatusCanceled
+
+	if e.NextOccurrenceAt == nil || !e.NextOccurrenceAt.Equal(e.effectiveStart(original)) {
+		// Per-occurrence state only applies to the occurrence being reminded.
+		if !seriesCanceled {
+			occurrence.Status = EventStatusScheduled
+			if e.NextOccurrenceAt != nil && e.effectiveStart(original).Before(*e.NextOccurrenceAt) {
+				// The series has moved past this occurrence.
+				occurrence.Status = EventStatusCompleted
+			}
+		}
+		occurrence.NotificationsSent = 0
+		occurrence.LastNotifiedAt = nil
+		occurrence.SnoozedUntil = nil
+		occurrence.DeferredUntil = nil
+	}
+
+	exception := e.ExceptionFor(original)
+	if exception == nil {
+		return occurrence, true
+	}
+	if exception.IsCanceled() {
+		return occurrence, false
+	}
+	if exception.Status != nil && !seriesCanceled {
+		occurrence.Status = *exception.Status
+	}
+	if exception.StartsAt != nil {
+		occurrence.StartsAt = *exception.StartsAt
+		occurrence.EndsAt = e.endFor(occurrence.StartsAt)
+	}
+	if exception.Title != nil {
+		occurrence.Title = *exception.Title
+	}
+	if exception.Location != nil {
+		occurrence.Location = exception.Location
+	}
+
+	return occurrence, true
+}
+
+func (e *Event) effectiveStart(original time.Time) time.Time {
+	if exception := e.ExceptionFor(original); exception != nil && exception.StartsAt != nil {
+		return *exception.StartsAt
+	}
+	return original
+}
+
+// NextOccurrence returns the first non-canceled occurrence that starts at
+// or after from, with exceptions applied.
+func (e *Event) NextOccurrence(from time.Time, loc *time.Location) (Event, bool) {
+	rule, err := e.Recurrence()
+	if err != nil || rule == nil {
+		return Event{}, false
+	}
+
+	var next Event
+	found := false
+	// Moved occurrences are skipped here and compared below, since their
+	// effective start can fall before or after their nominal slot.
+	rule.iterate(e.StartsAt, loc, func(original time.Time) bool {
+		if original.Before(from) {
+			return true
+		}
+		if exception := e.ExceptionFor(original); exception != nil && exception.StartsAt != nil {
+			return true
+		}
+		occurrence, ok := e.Occurrence(original)
+		if !ok {
+			return true
+		}
+		next = occurrence
+		found = true
+		return false
+	})
+
+	for _, exception := range e.Exceptions {
+		if exception.StartsAt == nil || exception.StartsAt.Before(from) || exception.IsCanceled() {
+			continue
+		}
+		if found && !exception.StartsAt.Before(next.StartsAt) {
+			continue
+		}
+		if occurrence, ok := e.Occurrence(exception.OriginalStartsAt); ok {
+			next = occurrence
+			found = true
+		}
+	}
+
+	return next, found
+}
+
+// OccurrencesBetween lists the non-canceled occurrences of the series whose
+// effective start falls within [from, to], including occurrences moved into
+// the range from outside it.
+func (e *Event) OccurrencesBetween(from, to time.Time, loc *time.Location) []Event {
+	rule, err := e.Recurrence()
+	if err != nil || rule == nil {
+		return nil
+	}
+
+	originals := rule.Between(e.StartsAt, from, to, loc)
+	for _, exception := range e.Exceptions {
+		if exception.StartsAt == nil || exception.StartsAt.Before(from) || exception.StartsAt.After(to) {
+			continue
+		}
+		if exception.OriginalStartsAt.Before(from) || exception.OriginalStartsAt.After(to) {
+			originals = append(originals, exception.OriginalStartsAt)
+		}
+	}
+
+	var occurrences []Event
+	for _, original := range originals {
+		occurrence, ok := e.Occurrence(original)
+		if !ok || occurrence.StartsAt.Before(from) || occurrence.StartsAt.After(to) {
+			continue
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+
+	return occurrences
+}
+
+// ResolveOccurrence narrows a recurring series to the occurrence an
+// identifier points at, either by exact start or by the date hint
+// (YYYY-MM-DD, in loc).
+func (e *Event) ResolveOccurrence(identifier *EventIdentifier, loc *time.Location) (Event, bool) {
+	if identifier == nil || !e.IsRecurring() {
+		return Event{}, false
+	}
+
+	if identifier.OccurrenceStartsAt != nil {
+		at := *identifier.OccurrenceStartsAt
+		if occurrences := e.OccurrencesBetween(at, at, loc); len(occurrences) > 0 {
+			return occurrences[0], true
+		}
+		// The caller may also refer to a moved occurrence by its nominal start.
+		if rule, err := e.Recurrence(); err == nil && len(rule.Between(e.StartsAt, at, at, loc)) > 0 {
+			return e.Occurrence(at)
+		}
+		return Event{}, false
+	}
+
+	if identifier.DateHint != nil {
+		day, err := time.ParseInLocation("2006-01-02", *identifier.DateHint, loc)
+		if err != nil {
+			return Event{}, false
+		}
+		occurrences := e.OccurrencesBetween(day, day.AddDate(0, 0, 1).Add(-time.Nanosecond), loc)
+		if len(occurrences) > 0 {
+			return occurrences[0], true
+		}
+	}
+
+	return Event{}, false
+}
+
+// ExpandOccurrences replaces recurring events with one copy per occurrence
+// in [from, to]. Non-recurring events are kept as they are.
+func ExpandOccurrences(events []Event, from, to time.Time, loc *time.Location) []Event {
+	expanded := make([]Event, 0, len(events))
+	for _, event := range events {
+		if _, err := event.Recurrence(); err != nil || !event.IsRecurring() {
+			expanded = append(expanded, event)
+			continue
+		}
+		expanded = append(expanded, event.OccurrencesBetween(from, to, loc)...)
+	}
+
+	sort.SliceStable(expanded, func(i, j int) bool {
+		return expanded[i].StartsAt.Before(expanded[j].StartsAt)
+	})
+
+	return expanded
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// UserIdentity is one way of reaching a user: their ID on a channel, such as
+// a WhatsApp number, a Telegram chat ID or an email address. Identities the
+// user wrote from are verified; ones linked through the API are not until
+// VerificationCode, sent to them, is echoed back from them. Until then they
+// neither identify whoever writes from them nor receive the user's messages.
+// LastInboundAt is when the last message from the identity arrived.
+type UserIdentity struct {
+	ID               int        `json:"id" db:"id"`
+	UserID           int        `json:"user_id" db:"user_id"`
+	Channel          Channel    `json:"channel" db:"channel"`
+	ExternalID       string     `json:"external_id" db:"external_id"`
+	Verified         bool       `json:"verified" db:"verified"`
+	VerificationCode *string    `json:"-" db:"verification_code"`
+	LastInboundAt    *time.Time `json:"last_inbound_at,omitempty" db:"last_inbound_at"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+const verificationCodeDigits = 6
+
+// NewVerificationCode returns a random code of six digits to verify an
+// identity with.
+func NewVerificationCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate verification code: %w", err)
+	}
+	return fmt.Sprintf("%0*d", verificationCodeDigits, n.Int64()), nil
+}
+
+// IsVerificationCode reports whether text has the shape of a code made by
+// NewVerificationCode.
+func IsVerificationCode(text string) bool {
+	if len(text) != verificationCodeDigits {
+		return false
+	}
+	for _, r := range text {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// InSession reports whether WhatsApp still delivers free-form messages to
+// the identity at now, that is, whether the user wrote from it within
+// SessionWindow.
+func (i *UserIdentity) InSession(now time.Time) bool {
+	return i.LastInboundAt != nil && now.Before(i.LastInboundAt.Add(SessionWindow))
+}
+
+// Contact is the channel and ID messages to the user are delivered to: the
+// identity they are writing from, their verified identity on the preferred
+// channel, or their WhatsApp number when they have none there.
+func (u *User) Contact() (Channel, string) {
+	if u.Via != nil {
+		return u.Via.Channel, u.Via.ExternalID
+	}
+	if u.ContactID != "" {
+		return u.PreferredChannel.OrDefault(), u.ContactID
+	}
+	return ChannelWhatsApp, u.WANumber
+}
+
+// HasVerifiedIdentity reports whether any of the identities is a verified one
+// on the channel.
+func HasVerifiedIdentity(identities []UserIdentity, channel Channel) bool {
+	for _, identity := range identities {
+		if identity.Channel == channel && identity.Verified {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUser_Contact(t *testing.T) {
+	user := &User{WANumber: "+5511900000001"}
+	channel, contact := user.Contact()
+	assert.Equal(t, ChannelWhatsApp, channel)
+	assert.Equal(t, "+5511900000001", contact)
+
+	user.PreferredChannel = ChannelEmail
+	user.ContactID = "ana@example.com"
+	channel, contact = user.Contact()
+	assert.Equal(t, ChannelEmail, channel)
+	assert.Equal(t, "ana@example.com", contact)
+
+	user.Via = &UserIdentity{Channel: ChannelTelegram, ExternalID: "42"}
+	channel, contact = user.Contact()
+	assert.Equal(t, ChannelTelegram, channel)
+	assert.Equal(t, "42", contact)
+}
+
+func TestHasVerifiedIdentity(t *testing.T) {
+	identities := []UserIdentity{
+		{Channel: ChannelWhatsApp, ExternalID: "+5511900000001", Verified: true},
+		{Channel: ChannelTelegram, ExternalID: "42"},
+	}
+
+	assert.True(t, HasVerifiedIdentity(identities, ChannelWhatsApp))
+	assert.False(t, HasVerifiedIdentity(identities, ChannelTelegram))
+	assert.False(t, HasVerifiedIdentity(identities, ChannelEmail))
+}
+
+func TestNewVerificationCode(t *testing.T) {
+	code, err := NewVerificationCode()
+	assert.NoError(t, err)
+	assert.True(t, IsVerificationCode(code), "code %q", code)
+
+	for _, text := range []string{"", "12345", "1234567", "12345a", " 123456"} {
+		assert.False(t, IsVerificationCode(text), "text %q", text)
+	}
+}
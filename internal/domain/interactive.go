@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Interactive holds the options a message offers to be answered with a tap:
+// reply buttons, up to three, or a list to pick from. The ID of the chosen
+// option comes back with the reply.
+type Interactive struct {
+	Buttons []ReplyOption `json:"buttons,omitempty"`
+	List    *OptionList   `json:"list,omitempty"`
+}
+
+type ReplyOption struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+// OptionList is a list picker, opened with a button labeled Button.
+type OptionList struct {
+	Button   string          `json:"button"`
+	Sections []OptionSection `json:"sections"`
+}
+
+type OptionSection struct {
+	Title   string        `json:"title,omitempty"`
+	Options []ReplyOption `json:"options"`
+}
+
+// Options returns every option offered, in order.
+func (i Interactive) Options() []ReplyOption {
+	options := append([]ReplyOption(nil), i.Buttons...)
+	if i.List != nil {
+		for _, section := range i.List.Sections {
+			options = append(options, section.Options...)
+		}
+	}
+	return options
+}
+
+// AsText appends the options to text, one per line, for channels without
+// interactive messages, where the user answers by typing one of them.
+func (i Interactive) AsText(text string) string {
+	options := i.Options()
+	if len(options) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	b.WriteString(text)
+	b.WriteString("\n")
+	for _, option := range options {
+		b.WriteString("\n• ")
+		b.WriteString(option.Title)
+	}
+	return b.String()
+}
+
+// Scan reads a JSONB interactive column.
+func (i *Interactive) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Interactive", src)
+	}
+	return json.Unmarshal(data, i)
+}
+
+func (i Interactive) Value() (driver.Value, error) {
+	data, err := json.Marshal(i)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// EventReply is what an option offered about an event does when chosen.
+type EventReply string
+
+const (
+	EventReplyConfirm EventReply = "confirm"
+	EventReplyCancel  EventReply = "cancel"
+	EventReplySnooze  EventReply = "snooze"
+)
+
+// EventReplyID is the ID of an option doing action on the event, e.g.
+// "confirm:42".
+func EventReplyID(action EventReply, eventID int) string {
+	return fmt.Sprintf("%s:%d", action, eventID)
+}
+
+// ParseEventReplyID reads an ID made by EventReplyID. It reports false for
+// any other ID.
+func ParseEventReplyID(id string) (EventReply, int, bool) {
+	action, rawEventID, ok := strings.Cut(id, ":")
+	if !ok {
+		return "", 0, false
+	}
+
+	switch EventReply(action) {
+	case EventReplyConfirm, EventReplyCancel, EventReplySnooze:
+	default:
+		return "", 0, false
+	}
+
+	eventID, err := strconv.Atoi(rawEventID)
+	if err != nil || eventID <= 0 {
+		return "", 0, false
+	}
+	return EventReply(action), eventID, true
+}
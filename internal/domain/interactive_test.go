@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEventReplyID(t *testing.T) {
+	action, eventID, ok := ParseEventReplyID(EventReplyID(EventReplySnooze, 42))
+	assert.True(t, ok)
+	assert.Equal(t, EventReplySnooze, action)
+	assert.Equal(t, 42, eventID)
+
+	for _, id := range []string{"", "confirm", "confirm:", "confirm:abc", "confirm:0", "delete:42"} {
+		_, _, ok := ParseEventReplyID(id)
+		assert.False(t, ok, "id %q", id)
+	}
+}
+
+func TestInteractive_AsText(t *testing.T) {
+	interactive := Interactive{
+		Buttons: []ReplyOption{{ID: "confirm:1", Title: "Confirmar"}},
+		List: &OptionList{
+			Button:   "Mais",
+			Sections: []OptionSection{{Options: []ReplyOption{{ID: "snooze:1", Title: "Adiar"}}}},
+		},
+	}
+
+	assert.Equal(t, "Confirma?\n\n• Confirmar\n• Adiar", interactive.AsText("Confirma?"))
+	assert.Equal(t, "Confirma?", Interactive{}.AsText("Confirma?"))
+}
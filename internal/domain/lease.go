@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// Lease is a time-limited claim a worker instance holds on the rows it is
+// processing, so replicas running the same worker skip them. A lease left
+// behind by a crashed instance lapses at ExpiresAt.
+type Lease struct {
+	Owner     string
+	ExpiresAt time.Time
+}
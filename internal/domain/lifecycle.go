@@ -0,0 +1,104 @@
+package domain
+
+import "time"
+
+// IsClosed reports whether the event reached a final status.
+func (e *Event) IsClosed() bool {
+	return e.Status == EventStatusCompleted || e.Status == EventStatusNoResponse
+}
+
+// LifecycleStatus returns the status the occurrence reminders are tracking
+// moves to at now: no_response once it is due without the confirmation it
+// required, completed once it has finished. It returns false while the
+// event keeps its current status.
+func (e *Event) LifecycleStatus(now time.Time) (EventStatus, bool) {
+	switch e.Status {
+	case EventStatusScheduled:
+		if e.RequireConfirmation && !now.Before(e.ReminderAnchor()) {
+			return EventStatusNoResponse, true
+		}
+	case EventStatusConfirmed:
+	default:
+		return "", false
+	}
+
+	if !e.IsOngoingOrUpcoming(now) {
+		return EventStatusCompleted, true
+	}
+	return "", false
+}
+
+// NextActionAt returns the earliest instant the reminder worker has
+// something to do for the event: send a reminder, close it or move a
+// recurring series on to its next occurrence. It returns false for events
+// that get no reminders anymore.
+func (e *Event) NextActionAt() (time.Time, bool) {
+	if e.Status != EventStatusScheduled && e.Status != EventStatusConfirmed {
+		return time.Time{}, false
+	}
+
+	next := e.lifecycleActionAt()
+	if at, ok := e.nextReminderAt(); ok && at.Before(next) {
+		next = at
+	}
+	return next, true
+}
+
+// lifecycleActionAt is when the occurrence reminders are tracking moves on:
+// recurring series advance once it starts, single events close once it is
+// due without a required confirmation or once it ends.
+func (e *Event) lifecycleActionAt() time.Time {
+	if e.IsRecurring() {
+		return e.OccurrenceStartsAt()
+	}
+	if e.Status == EventStatusScheduled && e.RequireConfirmation {
+		return e.ReminderAnchor()
+	}
+	if end := e.OccurrenceEndsAt(); end != nil {
+		return *end
+	}
+	return e.OccurrenceStartsAt()
+}
+
+// nextReminderAt is when the next reminder of the event is due, which may
+// already be in the past, or when its deferral ends.
+func (e *Event) nextReminderAt() (time.Time, bool) {
+	next, ok := e.owedReminderFrom()
+	if ok && e.DeferredUntil != nil && next.Before(*e.DeferredUntil) {
+		next = *e.DeferredUntil
+	}
+	return next, ok
+}
+
+func (e *Event) owedReminderFrom() (time.Time, bool) {
+	if e.SnoozedUntil != nil {
+		return *e.SnoozedUntil, true
+	}
+
+	anchor := e.ReminderAnchor()
+	if e.HasReminderSchedule() {
+		var next time.Time
+		found := false
+		for _, offset := range e.ReminderOffsets {
+			due := anchor.Add(-time.Duration(offset) * time.Minute)
+			if e.LastNotifiedAt != nil && !due.After(*e.LastNotifiedAt) {
+				continue
+			}
+			if !found || due.Before(next) {
+				next, found = due, true
+			}
+		}
+		return next, found
+	}
+
+	if e.NotificationsSent >= e.MaxNotifications {
+		return time.Time{}, false
+	}
+	due := anchor.Add(-time.Duration(e.RemindBeforeMinutes) * time.Minute)
+	if e.LastNotifiedAt != nil {
+		if next := e.LastNotifiedAt.Add(time.Duration(e.RemindFrequencyMinutes) * time.Minute); next.After(due) {
+			due = next
+		}
+	}
+	return due, true
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_LifecycleStatus(t *testing.T) {
+	start := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	allDayEnd := time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC)
+	midnight := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		event      Event
+		now        time.Time
+		wantStatus EventStatus
+		wantOK     bool
+	}{
+		{name: "upcoming", event: Event{StartsAt: start, Status: EventStatusScheduled}, now: start.Add(-time.Minute)},
+		{name: "started without end", event: Event{StartsAt: start, Status: EventStatusScheduled}, now: start, wantStatus: EventStatusCompleted, wantOK: true},
+		{name: "ongoing", event: Event{StartsAt: start, EndsAt: &end, Status: EventStatusConfirmed}, now: start.Add(30 * time.Minute)},
+		{name: "finished", event: Event{StartsAt: start, EndsAt: &end, Status: EventStatusConfirmed}, now: end, wantStatus: EventStatusCompleted, wantOK: true},
+		{name: "unconfirmed at start", event: Event{StartsAt: start, EndsAt: &end, Status: EventStatusScheduled, RequireConfirmation: true}, now: start, wantStatus: EventStatusNoResponse, wantOK: true},
+		{name: "confirmed at start", event: Event{StartsAt: start, EndsAt: &end, Status: EventStatusConfirmed, RequireConfirmation: true}, now: start},
+		{name: "all-day before anchor", event: Event{StartsAt: midnight, EndsAt: &allDayEnd, AllDay: true, Status: EventStatusScheduled, RequireConfirmation: true}, now: midnight.Add(8 * time.Hour)},
+		{name: "all-day at anchor", event: Event{StartsAt: midnight, EndsAt: &allDayEnd, AllDay: true, Status: EventStatusScheduled, RequireConfirmation: true}, now: midnight.Add(9 * time.Hour), wantStatus: EventStatusNoResponse, wantOK: true},
+		{name: "canceled", event: Event{StartsAt: start, Status: EventStatusCanceled}, now: end},
+		{name: "tentative", event: Event{StartsAt: start, Status: EventStatusTentative}, now: end},
+		{name: "already closed", event: Event{StartsAt: start, Status: EventStatusNoResponse, RequireConfirmation: true}, now: end},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, ok := tt.event.LifecycleStatus(tt.now)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantStatus, status)
+		})
+	}
+}
+
+func TestEvent_OccurrenceStatus(t *testing.T) {
+	rule := "FREQ=DAILY"
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	current := start.AddDate(0, 0, 2)
+	noResponse := EventStatusNoResponse
+
+	series := Event{
+		StartsAt:         start,
+		RecurrenceRule:   &rule,
+		NextOccurrenceAt: &current,
+		Status:           EventStatusConfirmed,
+		Exceptions: []EventException{
+			{OriginalStartsAt: start, Status: &noResponse},
+		},
+	}
+
+	occurrences := series.OccurrencesBetween(start, current.AddDate(0, 0, 1), time.UTC)
+	var statuses []EventStatus
+	for _, occurrence := range occurrences {
+		statuses = append(statuses, occurrence.Status)
+	}
+
+	assert.Equal(t, []EventStatus{
+		EventStatusNoResponse,
+		EventStatusCompleted,
+		EventStatusConfirmed,
+		EventStatusScheduled,
+	}, statuses)
+}
+
+func TestEvent_NextActionAt(t *testing.T) {
+	start := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	snoozedUntil := start.Add(-10 * time.Minute)
+	notified := start.Add(-time.Hour)
+	rule := "FREQ=DAILY"
+	next := start.AddDate(0, 0, 1)
+
+	tests := []struct {
+		name   string
+		event  Event
+		want   time.Time
+		wantOK bool
+	}{
+		{name: "first stage", event: Event{StartsAt: start, EndsAt: &end, ReminderOffsets: ReminderSchedule{60, 15}, Status: EventStatusScheduled}, want: start.Add(-time.Hour), wantOK: true},
+		{name: "next unsent stage", event: Event{StartsAt: start, EndsAt: &end, ReminderOffsets: ReminderSchedule{60, 15}, LastNotifiedAt: &notified, Status: EventStatusScheduled}, want: start.Add(-15 * time.Minute), wantOK: true},
+		{name: "stages sent, waits for end", event: Event{StartsAt: start, EndsAt: &end, ReminderOffsets: ReminderSchedule{60}, LastNotifiedAt: &notified, Status: EventStatusConfirmed}, want: end, wantOK: true},
+		{name: "waits for confirmation deadline", event: Event{StartsAt: start, EndsAt: &end, ReminderOffsets: ReminderSchedule{60}, LastNotifiedAt: &notified, RequireConfirmation: true, Status: EventStatusScheduled}, want: start, wantOK: true},
+		{name: "snoozed", event: Event{StartsAt: start, ReminderOffsets: ReminderSchedule{60}, SnoozedUntil: &snoozedUntil, Status: EventStatusScheduled}, want: snoozedUntil, wantOK: true},
+		{name: "repetition", event: Event{StartsAt: start, RemindBeforeMinutes: 90, RemindFrequencyMinutes: 20, MaxNotifications: 3, NotificationsSent: 1, LastNotifiedAt: &notified, Status: EventStatusScheduled}, want: notified.Add(20 * time.Minute), wantOK: true},
+		{name: "repetitions exhausted", event: Event{StartsAt: start, RemindBeforeMinutes: 30, MaxNotifications: 1, NotificationsSent: 1, Status: EventStatusScheduled}, want: start, wantOK: true},
+		{name: "recurring advances at occurrence start", event: Event{StartsAt: start, RecurrenceRule: &rule, NextOccurrenceAt: &next, ReminderOffsets: ReminderSchedule{15}, LastNotifiedAt: &notified, Status: EventStatusScheduled}, want: next.Add(-15 * time.Minute), wantOK: true},
+		{name: "canceled", event: Event{StartsAt: start, ReminderOffsets: ReminderSchedule{60}, Status: EventStatusCanceled}},
+		{name: "completed", event: Event{StartsAt: start, ReminderOffsets: ReminderSchedule{60}, Status: EventStatusCompleted}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.event.NextActionAt()
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.True(t, tt.want.Equal(got), "got %s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Locale is the language the agent talks to a user in.
+type Locale string
+
+const (
+	LocalePortuguese Locale = "pt-BR"
+	LocaleEnglish    Locale = "en"
+	LocaleSpanish    Locale = "es"
+)
+
+// DefaultLocale is used until the user's language is known.
+const DefaultLocale = LocalePortuguese
+
+// Locales lists the supported locales, the default first.
+var Locales = []Locale{LocalePortuguese, LocaleEnglish, LocaleSpanish}
+
+// ParseLocale matches a language tag such as "pt-BR", "en-US" or "es_MX" to
+// a supported locale by its language.
+func ParseLocale(value string) (Locale, error) {
+	language := strings.ToLower(value)
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+
+	switch language {
+	case "pt":
+		return LocalePortuguese, nil
+	case "en":
+		return LocaleEnglish, nil
+	case "es":
+		return LocaleSpanish, nil
+	default:
+		return "", fmt.Errorf("unsupported locale %q: expected pt-BR, en or es", value)
+	}
+}
+
+// OrDefault is the locale, or the default one when it is unset or not
+// supported.
+func (l Locale) OrDefault() Locale {
+	for _, locale := range Locales {
+		if l == locale {
+			return l
+		}
+	}
+	return DefaultLocale
+}
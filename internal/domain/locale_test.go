@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Locale
+		wantErr bool
+	}{
+		{value: "pt-BR", want: LocalePortuguese},
+		{value: "pt", want: LocalePortuguese},
+		{value: "en", want: LocaleEnglish},
+		{value: "en-US", want: LocaleEnglish},
+		{value: "es_MX", want: LocaleSpanish},
+		{value: "ES", want: LocaleSpanish},
+		{value: "fr", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseLocale(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUser_Language(t *testing.T) {
+	assert.Equal(t, LocaleSpanish, (&User{Locale: LocaleSpanish}).Language())
+	assert.Equal(t, DefaultLocale, (&User{}).Language())
+	assert.Equal(t, DefaultLocale, (&User{Locale: "fr"}).Language())
+	assert.Equal(t, DefaultLocale, (*User)(nil).Language())
+}
@@ -23,6 +23,8 @@ const (
 	IntentListEvents   LLMIntent = "list_events"
 	IntentConfirmEvent LLMIntent = "confirm_event"
 	IntentDeclineEvent LLMIntent = "decline_event"
+	IntentSnooze       LLMIntent = "snooze_event"
+	IntentDigest       LLMIntent = "configure_digest"
 	IntentSmallTalk    LLMIntent = "small_talk"
 	IntentUnknown      LLMIntent = "unknown"
 )
@@ -33,22 +35,39 @@ type LLMResponse struct {
 	Confidence       float64                `json:"confidence"`
 	FollowUpQuestion *string                `json:"follow_up_question"`
 	Notes            *string                `json:"notes"`
+	Language         *string                `json:"language"`
 }
 
 type EventEntities struct {
-	Title                  *string          `json:"title"`
-	StartsAt               *time.Time       `json:"starts_at"`
-	Location               *string          `json:"location"`
-	Participants           []string         `json:"participants"`
-	RemindBeforeMinutes    *int             `json:"remind_before_minutes"`
-	RemindFrequencyMinutes *int             `json:"remind_frequency_minutes"`
-	RequireConfirmation    *bool            `json:"require_confirmation"`
-	MaxNotifications       *int             `json:"max_notifications"`
-	Identifier             *EventIdentifier `json:"identifier"`
+	Title                  *string           `json:"title"`
+	StartsAt               *time.Time        `json:"starts_at"`
+	EndsAt                 *time.Time        `json:"ends_at"`
+	DurationMinutes        *int              `json:"duration_minutes"`
+	AllDay                 *bool             `json:"all_day"`
+	Location               *string           `json:"location"`
+	Participants           []string          `json:"participants"`
+	RemindBeforeMinutes    *int              `json:"remind_before_minutes"`
+	ReminderOffsets        []int             `json:"reminder_offsets"`
+	RemindFrequencyMinutes *int              `json:"remind_frequency_minutes"`
+	RequireConfirmation    *bool             `json:"require_confirmation"`
+	MaxNotifications       *int              `json:"max_notifications"`
+	Recurrence             *string           `json:"recurrence"`
+	SnoozeMinutes          *int              `json:"snooze_minutes"`
+	SnoozeUntil            *time.Time        `json:"snooze_until"`
+	IgnoreQuietHours       *bool             `json:"ignore_quiet_hours"`
+	Priority               *string           `json:"priority"`
+	EscalationPolicy       *EscalationPolicy `json:"escalation_policy"`
+	Identifier             *EventIdentifier  `json:"identifier"`
+
+	// Force skips the schedule conflict check. It is never read from the LLM.
+	Force bool `json:"-"`
 }
 
 type EventIdentifier struct {
 	EventID  *int    `json:"event_id"`
 	Title    *string `json:"title"`
 	DateHint *string `json:"date_hint"`
+	// Scope and OccurrenceStartsAt only apply to recurring events.
+	Scope              *EditScope `json:"scope"`
+	OccurrenceStartsAt *time.Time `json:"occurrence_starts_at"`
 }
@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+// Names of the templates every message sent to users is rendered from.
+const (
+	TemplateGreeting             = "greeting"
+	TemplateNotUnderstood        = "not_understood"
+	TemplateInvalidEventData     = "invalid_event_data"
+	TemplateUnknownEvent         = "unknown_event"
+	TemplateEventCreated         = "event_created"
+	TemplateEventCreateFailed    = "event_create_failed"
+	TemplateEventUpdated         = "event_updated"
+	TemplateEventUpdateFailed    = "event_update_failed"
+	TemplateEventCanceled        = "event_canceled"
+	TemplateEventCancelFailed    = "event_cancel_failed"
+	TemplateEventList            = "event_list"
+	TemplateEventListEmpty       = "event_list_empty"
+	TemplateEventListFailed      = "event_list_failed"
+	TemplateEventConfirmed       = "event_confirmed"
+	TemplateEventConfirmFailed   = "event_confirm_failed"
+	TemplateEventSnoozed         = "event_snoozed"
+	TemplateEventSnoozeFailed    = "event_snooze_failed"
+	TemplateInvalidSnooze        = "invalid_snooze"
+	TemplateDigestSettings       = "digest_settings"
+	TemplateInvalidDigest        = "invalid_digest"
+	TemplateInvalidDigestTime    = "invalid_digest_time"
+	TemplateConflictHeld         = "conflict_held"
+	TemplateConflictKept         = "conflict_kept"
+	TemplateConflictDiscarded    = "conflict_discarded"
+	TemplateConflictAnswerFailed = "conflict_answer_failed"
+	TemplateInvitation           = "invitation"
+	TemplateInvitationAnswered   = "invitation_answered"
+	TemplateInvitationFailed     = "invitation_failed"
+	TemplateRSVP                 = "rsvp"
+	TemplateReminder             = "reminder"
+	TemplateConfirmationRequest  = "confirmation_request"
+	TemplateConfirmButton        = "confirm_button"
+	TemplateCancelButton         = "cancel_button"
+	TemplateSnoozeButton         = "snooze_button"
+	TemplateNoResponse           = "no_response"
+	TemplateLateNotice           = "late_notice"
+	TemplateMissedSummary        = "missed_summary"
+	TemplateEscalation           = "escalation"
+	TemplateEscalationCanceled   = "escalation_canceled"
+	TemplateIdentityVerification = "identity_verification"
+	TemplateIdentityVerified     = "identity_verified"
+	TemplateDailyDigest          = "daily_digest"
+	TemplateWeeklyDigest         = "weekly_digest"
+)
+
+// MessageData holds the values a message template is rendered with.
+type MessageData map[string]interface{}
+
+// MessageTemplate overrides the wording of a named template in a locale, for
+// a single user or, without a user, for everyone.
+type MessageTemplate struct {
+	ID        int       `json:"id" db:"id"`
+	UserID    *int      `json:"user_id,omitempty" db:"user_id"`
+	Locale    Locale    `json:"locale" db:"locale"`
+	Name      string    `json:"name" db:"name"`
+	Body      string    `json:"body" db:"body"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
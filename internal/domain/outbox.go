@@ -0,0 +1,111 @@
+package domain
+
+import "time"
+
+type OutboundMessageStatus string
+
+const (
+	OutboundMessagePending OutboundMessageStatus = "pending"
+	OutboundMessageSent    OutboundMessageStatus = "sent"
+	// OutboundMessageDead marks a message that ran out of delivery attempts.
+	OutboundMessageDead OutboundMessageStatus = "dead"
+)
+
+// maxOutboundErrorLength keeps stored provider errors to a readable size.
+const maxOutboundErrorLength = 1000
+
+// OutboundMessage is a message waiting in the outbox. It is written in the
+// same transaction as the change that caused it and delivered later by the
+// outbox dispatcher on its channel, where ToNumber is the recipient's ID.
+// Interactive, when set, holds the options offered along the body. Template,
+// when set, is sent instead of the body once the recipient's WhatsApp session
+// window is closed, or always when there is no body.
+type OutboundMessage struct {
+	ID            int                   `json:"id" db:"id"`
+	Channel       Channel               `json:"channel" db:"channel"`
+	ToNumber      string                `json:"to_number" db:"to_number"`
+	Body          string                `json:"body" db:"body"`
+	Interactive   *Interactive          `json:"interactive,omitempty" db:"interactive"`
+	Template      *TemplateMessage      `json:"template,omitempty" db:"template"`
+	Status        OutboundMessageStatus `json:"status" db:"status"`
+	Attempts      int                   `json:"attempts" db:"attempts"`
+	NextAttemptAt time.Time             `json:"next_attempt_at" db:"next_attempt_at"`
+	LastError     *string               `json:"last_error,omitempty" db:"last_error"`
+	SentAt        *time.Time            `json:"sent_at,omitempty" db:"sent_at"`
+	CreatedAt     time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at" db:"updated_at"`
+}
+
+// RetryPolicy bounds how often and how far apart delivery is retried.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Backoff is the wait after the given failed attempt (1-based): BaseBackoff
+// doubled for every earlier failure, capped at MaxBackoff.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := p.BaseBackoff
+	for i := 1; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return backoff
+}
+
+// NewOutboundMessage queues body for delivery to a WhatsApp number as soon as
+// possible.
+func NewOutboundMessage(number, body string, now time.Time) *OutboundMessage {
+	return &OutboundMessage{
+		Channel:       ChannelWhatsApp,
+		ToNumber:      number,
+		Body:          body,
+		Status:        OutboundMessagePending,
+		NextAttemptAt: now,
+	}
+}
+
+// NewUserMessage queues body for delivery to the user on their preferred
+// channel as soon as possible.
+func NewUserMessage(user *User, body string, now time.Time) *OutboundMessage {
+	channel, contact := user.Contact()
+	message := NewOutboundMessage(contact, body, now)
+	message.Channel = channel
+	return message
+}
+
+// MarkSent records a successful delivery.
+func (m *OutboundMessage) MarkSent(now time.Time) {
+	m.Attempts++
+	m.Status = OutboundMessageSent
+	m.SentAt = &now
+	m.LastError = nil
+}
+
+// MarkFailed records a failed delivery and schedules the next attempt, or
+// moves the message to the dead letters once the policy's attempts are used
+// up.
+func (m *OutboundMessage) MarkFailed(err error, now time.Time, policy RetryPolicy) {
+	m.Attempts++
+	message := err.Error()
+	if len(message) > maxOutboundErrorLength {
+		message = message[:maxOutboundErrorLength]
+	}
+	m.LastError = &message
+
+	if m.Attempts >= policy.MaxAttempts {
+		m.Status = OutboundMessageDead
+		return
+	}
+	m.NextAttemptAt = now.Add(policy.Backoff(m.Attempts))
+}
+
+// Requeue gives a dead message a fresh set of attempts starting at now.
+func (m *OutboundMessage) Requeue(now time.Time) {
+	m.Status = OutboundMessagePending
+	m.Attempts = 0
+	m.NextAttemptAt = now
+}
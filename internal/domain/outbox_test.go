@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 8, BaseBackoff: 30 * time.Second, MaxBackoff: 5 * time.Minute}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 30 * time.Second},
+		{attempt: 2, want: time.Minute},
+		{attempt: 3, want: 2 * time.Minute},
+		{attempt: 4, want: 4 * time.Minute},
+		{attempt: 5, want: 5 * time.Minute},
+		{attempt: 50, want: 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, policy.Backoff(tt.attempt), "attempt %d", tt.attempt)
+	}
+}
+
+func TestNewUserMessage_UsesUserChannel(t *testing.T) {
+	now := time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC)
+
+	message := NewUserMessage(&User{WANumber: "+5511900000001"}, "Olá", now)
+	assert.Equal(t, ChannelWhatsApp, message.Channel)
+	assert.Equal(t, "+5511900000001", message.ToNumber)
+
+	message = NewUserMessage(&User{WANumber: "+5511900000001", PreferredChannel: ChannelTelegram, ContactID: "42"}, "Olá", now)
+	assert.Equal(t, ChannelTelegram, message.Channel)
+	assert.Equal(t, "42", message.ToNumber)
+}
+
+func TestOutboundMessage_Lifecycle(t *testing.T) {
+	now := time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC)
+	policy := RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Minute, MaxBackoff: time.Hour}
+
+	message := NewOutboundMessage("+5511900000001", "Olá", now)
+	assert.Equal(t, OutboundMessagePending, message.Status)
+	assert.Equal(t, now, message.NextAttemptAt)
+
+	message.MarkFailed(errors.New(strings.Repeat("x", 2000)), now, policy)
+	assert.Equal(t, OutboundMessagePending, message.Status)
+	assert.Equal(t, now.Add(time.Minute), message.NextAttemptAt)
+	require.NotNil(t, message.LastError)
+	assert.Len(t, *message.LastError, maxOutboundErrorLength)
+
+	message.MarkFailed(errors.New("timeout"), now.Add(time.Minute), policy)
+	assert.Equal(t, OutboundMessageDead, message.Status)
+	assert.Equal(t, 2, message.Attempts)
+
+	later := now.Add(time.Hour)
+	message.Requeue(later)
+	assert.Equal(t, OutboundMessagePending, message.Status)
+	assert.Equal(t, 0, message.Attempts)
+	assert.Equal(t, later, message.NextAttemptAt)
+
+	message.MarkSent(later)
+	assert.Equal(t, OutboundMessageSent, message.Status)
+	assert.Nil(t, message.LastError)
+	require.NotNil(t, message.SentAt)
+}
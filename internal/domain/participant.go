@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+type ParticipantStatus string
+
+const (
+	ParticipantStatusPending  ParticipantStatus = "pending"
+	ParticipantStatusAccepted ParticipantStatus = "accepted"
+	ParticipantStatusDeclined ParticipantStatus = "declined"
+)
+
+// NormalizeNumber is the form participant numbers are stored and looked up
+// in: without the leading +, as the providers send the numbers of inbound
+// messages.
+func NormalizeNumber(number string) string {
+	return strings.TrimPrefix(number, "+")
+}
+
+// EventParticipant is someone the organizer added to an event. Only
+// participants with a WhatsApp number in the organizer's allowed contacts
+// are invited; InvitedAt stays nil for the others.
+type EventParticipant struct {
+	ID          int               `json:"id" db:"id"`
+	EventID     int               `json:"event_id" db:"event_id"`
+	Name        *string           `json:"name,omitempty" db:"name"`
+	WANumber    *string           `json:"wa_number,omitempty" db:"wa_number"`
+	Status      ParticipantStatus `json:"status" db:"status"`
+	InvitedAt   *time.Time        `json:"invited_at,omitempty" db:"invited_at"`
+	RespondedAt *time.Time        `json:"responded_at,omitempty" db:"responded_at"`
+	CreatedAt   time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+func (p *EventParticipant) IsInvited() bool {
+	return p.InvitedAt != nil
+}
+
+// DisplayName is the participant's name, or their number when unnamed.
+func (p *EventParticipant) DisplayName() string {
+	if p.Name != nil && *p.Name != "" {
+		return *p.Name
+	}
+	if p.WANumber != nil {
+		return *p.WANumber
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+package domain
+
+import "fmt"
+
+// Priority is how important an event is to the user, which sets how
+// insistently its reminders nag.
+type Priority string
+
+const (
+	PriorityLow      Priority = "low"
+	PriorityNormal   Priority = "normal"
+	PriorityHigh     Priority = "high"
+	PriorityCritical Priority = "critical"
+)
+
+// nagging is how the reminders of a priority behave when the event does not
+// set its own values.
+type nagging struct {
+	// frequencyMinutes caps the user's default reminder frequency; zero
+	// keeps it.
+	frequencyMinutes int
+	maxNotifications int
+	bypassQuietHours bool
+	// escalates is false for priorities that ignore the user's default
+	// escalation chain.
+	escalates bool
+	// escalation is the chain followed when the user has no default one.
+	escalation EscalationPolicy
+}
+
+var priorityNagging = map[Priority]nagging{
+	PriorityLow:    {maxNotifications: 1},
+	PriorityNormal: {maxNotifications: 3, escalates: true},
+	PriorityHigh:   {frequencyMinutes: 10, maxNotifications: 5, escalates: true},
+	PriorityCritical: {
+		frequencyMinutes: 5,
+		maxNotifications: 10,
+		bypassQuietHours: true,
+		escalates:        true,
+		escalation:       EscalationPolicy{{AfterReminders: 2}},
+	},
+}
+
+// ParsePriority validates a priority; empty values are normal.
+func ParsePriority(value string) (Priority, error) {
+	if value == "" {
+		return PriorityNormal, nil
+	}
+	switch priority := Priority(value); priority {
+	case PriorityLow, PriorityNormal, PriorityHigh, PriorityCritical:
+		return priority, nil
+	default:
+		return "", fmt.Errorf("invalid priority %q: expected low, normal, high or critical", value)
+	}
+}
+
+// nagging returns how reminders of the priority behave, normal ones for an
+// unset priority.
+func (p Priority) nagging() nagging {
+	return priorityNagging[p.OrDefault()]
+}
+
+// RemindFrequencyMinutes is how often repeating reminders are sent by
+// default, given the user's default: high and critical events nag more
+// often.
+func (p Priority) RemindFrequencyMinutes(userDefault int) int {
+	if frequency := p.nagging().frequencyMinutes; frequency > 0 && (userDefault <= 0 || frequency < userDefault) {
+		return frequency
+	}
+	return userDefault
+}
+
+// MaxNotifications is how many repeating reminders are sent by default.
+func (p Priority) MaxNotifications() int {
+	return p.nagging().maxNotifications
+}
+
+// BypassesQuietHours reports whether reminders are sent during the user's
+// quiet hours even when the event does not ask for it.
+func (p Priority) BypassesQuietHours() bool {
+	return p.nagging().bypassQuietHours
+}
+
+// OrDefault is the priority, or normal when it is unset.
+func (p Priority) OrDefault() Priority {
+	if _, ok := priorityNagging[p]; ok {
+		return p
+	}
+	return PriorityNormal
+}
+
+// BypassesQuietHours reports whether the event's reminders are sent during
+// the user's quiet hours, because it asks for it or is critical.
+func (e *Event) BypassesQuietHours() bool {
+	return e.IgnoreQuietHours || e.Priority.BypassesQuietHours()
+}
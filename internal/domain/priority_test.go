@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePriority(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Priority
+		wantErr bool
+	}{
+		{value: "low", want: PriorityLow},
+		{value: "normal", want: PriorityNormal},
+		{value: "high", want: PriorityHigh},
+		{value: "critical", want: PriorityCritical},
+		{value: "", want: PriorityNormal},
+		{value: "urgent", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParsePriority(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPriority_Nagging(t *testing.T) {
+	tests := []struct {
+		priority         Priority
+		frequency        int
+		maxNotifications int
+		bypass           bool
+	}{
+		{priority: PriorityLow, frequency: 15, maxNotifications: 1},
+		{priority: PriorityNormal, frequency: 15, maxNotifications: 3},
+		{priority: "", frequency: 15, maxNotifications: 3},
+		{priority: PriorityHigh, frequency: 10, maxNotifications: 5},
+		{priority: PriorityCritical, frequency: 5, maxNotifications: 10, bypass: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.priority), func(t *testing.T) {
+			assert.Equal(t, tt.frequency, tt.priority.RemindFrequencyMinutes(15))
+			assert.Equal(t, tt.maxNotifications, tt.priority.MaxNotifications())
+			assert.Equal(t, tt.bypass, (&Event{Priority: tt.priority}).BypassesQuietHours())
+		})
+	}
+
+	// A user default more insistent than the priority is kept.
+	assert.Equal(t, 3, PriorityHigh.RemindFrequencyMinutes(3))
+	assert.True(t, (&Event{Priority: PriorityLow, IgnoreQuietHours: true}).BypassesQuietHours())
+}
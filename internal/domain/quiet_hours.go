@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// maxQuietWindows bounds how many quiet-hour windows a user can set.
+const maxQuietWindows = 14
+
+// QuietWindow is a local-time range during which non-urgent reminders are
+// held back, e.g. 22:00 to 07:00. A window whose end is before its start
+// runs past midnight. Weekdays (0 = Sunday) restrict the days the window
+// starts on; empty means every day.
+type QuietWindow struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Weekdays []int  `json:"weekdays,omitempty"`
+}
+
+// QuietHours are the do-not-disturb windows of a user.
+type QuietHours []QuietWindow
+
+// NewQuietHours validates the windows.
+func NewQuietHours(windows []QuietWindow) (QuietHours, error) {
+	if len(windows) > maxQuietWindows {
+		return nil, fmt.Errorf("at most %d quiet hour windows are allowed", maxQuietWindows)
+	}
+
+	for _, window := range windows {
+		startHour, startMinute, err := ParseClock(window.Start)
+		if err != nil {
+			return nil, err
+		}
+		endHour, endMinute, err := ParseClock(window.End)
+		if err != nil {
+			return nil, err
+		}
+		if startHour == endHour && startMinute == endMinute {
+			return nil, fmt.Errorf("quiet hours must not start and end at the same time")
+		}
+		for _, weekday := range window.Weekdays {
+			if weekday < 0 || weekday > 6 {
+				return nil, fmt.Errorf("invalid weekday: %d", weekday)
+			}
+		}
+	}
+
+	return QuietHours(windows), nil
+}
+
+// Contains reports whether t falls within any quiet window in loc.
+func (q QuietHours) Contains(t time.Time, loc *time.Location) bool {
+	_, ok := q.windowEnd(t, loc)
+	return ok
+}
+
+// Until returns when the quiet period containing t ends, following windows
+// that start right as the previous one ends. It returns false when t is not
+// within quiet hours.
+func (q QuietHours) Until(t time.Time, loc *time.Location) (time.Time, bool) {
+	end, ok := q.windowEnd(t, loc)
+	if !ok {
+		return time.Time{}, false
+	}
+	// Back-to-back windows cover at most a full week.
+	for i := 0; i < 7*len(q); i++ {
+		next, ok := q.windowEnd(end, loc)
+		if !ok {
+			break
+		}
+		end = next
+	}
+	return end, true
+}
+
+func (q QuietHours) windowEnd(t time.Time, loc *time.Location) (time.Time, bool) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	for _, window := range q {
+		startHour, startMinute, err := ParseClock(window.Start)
+		if err != nil {
+			continue
+		}
+		endHour, endMinute, err := ParseClock(window.End)
+		if err != nil {
+			continue
+		}
+		start := startHour*60 + startMinute
+		end := endHour*60 + endMinute
+		endToday := time.Date(local.Year(), local.Month(), local.Day(), endHour, endMinute, 0, 0, loc)
+
+		if start < end {
+			if minute >= start && minute < end && window.onDay(today.Weekday()) {
+				return endToday, true
+			}
+			continue
+		}
+
+		// The window runs past midnight.
+		if minute >= start && window.onDay(today.Weekday()) {
+			return endToday.AddDate(0, 0, 1), true
+		}
+		if minute < end && window.onDay(today.AddDate(0, 0, -1).Weekday()) {
+			return endToday, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (w QuietWindow) onDay(weekday time.Weekday) bool {
+	if len(w.Weekdays) == 0 {
+		return true
+	}
+	for _, day := range w.Weekdays {
+		if time.Weekday(day) == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// Scan reads the JSONB quiet_hours column.
+func (q *QuietHours) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*q = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into QuietHours", src)
+	}
+	return json.Unmarshal(data, (*[]QuietWindow)(q))
+}
+
+// Value writes the windows as JSON, using an empty array when there are none.
+func (q QuietHours) Value() (driver.Value, error) {
+	if q == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]QuietWindow(q))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
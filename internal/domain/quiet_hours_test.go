@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewQuietHours(t *testing.T) {
+	tests := []struct {
+		name    string
+		windows []QuietWindow
+		wantErr bool
+	}{
+		{name: "empty", windows: nil},
+		{name: "overnight", windows: []QuietWindow{{Start: "22:00", End: "07:00"}}},
+		{name: "weekdays", windows: []QuietWindow{{Start: "13:00", End: "14:00", Weekdays: []int{0, 6}}}},
+		{name: "invalid start", windows: []QuietWindow{{Start: "22h", End: "07:00"}}, wantErr: true},
+		{name: "invalid end", windows: []QuietWindow{{Start: "22:00", End: "25:00"}}, wantErr: true},
+		{name: "empty window", windows: []QuietWindow{{Start: "22:00", End: "22:00"}}, wantErr: true},
+		{name: "invalid weekday", windows: []QuietWindow{{Start: "22:00", End: "07:00", Weekdays: []int{7}}}, wantErr: true},
+		{name: "too many", windows: make([]QuietWindow, maxQuietWindows+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewQuietHours(tt.windows)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestQuietHours_Until(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	// Friday, 7 March 2025.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 3, day, hour, minute, 0, 0, loc)
+	}
+
+	overnight := QuietHours{{Start: "22:00", End: "07:00"}}
+	weekdayNights := QuietHours{{Start: "22:00", End: "07:00", Weekdays: []int{1, 2, 3, 4, 5}}}
+	chained := QuietHours{{Start: "22:00", End: "07:00"}, {Start: "07:00", End: "09:00", Weekdays: []int{0, 6}}}
+
+	tests := []struct {
+		name  string
+		hours QuietHours
+		now   time.Time
+		want  time.Time
+		quiet bool
+	}{
+		{name: "no windows", hours: nil, now: at(7, 23, 0)},
+		{name: "same day window", hours: QuietHours{{Start: "13:00", End: "14:00"}}, now: at(7, 13, 30), want: at(7, 14, 0), quiet: true},
+		{name: "window end is exclusive", hours: QuietHours{{Start: "13:00", End: "14:00"}}, now: at(7, 14, 0)},
+		{name: "before midnight", hours: overnight, now: at(7, 23, 0), want: at(8, 7, 0), quiet: true},
+		{name: "after midnight", hours: overnight, now: at(8, 3, 0), want: at(8, 7, 0), quiet: true},
+		{name: "outside", hours: overnight, now: at(7, 12, 0)},
+		{name: "started on a listed day", hours: weekdayNights, now: at(8, 3, 0), want: at(8, 7, 0), quiet: true},
+		{name: "started on an unlisted day", hours: weekdayNights, now: at(9, 3, 0)},
+		{name: "back to back windows", hours: chained, now: at(7, 23, 0), want: at(8, 9, 0), quiet: true},
+		{name: "chain only on listed days", hours: chained, now: at(6, 23, 0), want: at(7, 7, 0), quiet: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			until, quiet := tt.hours.Until(tt.now, loc)
+			assert.Equal(t, tt.quiet, quiet)
+			assert.Equal(t, tt.quiet, tt.hours.Contains(tt.now, loc))
+			if tt.quiet {
+				assert.True(t, tt.want.Equal(until), "got %s", until)
+			}
+		})
+	}
+}
+
+func TestQuietHours_ScanValue(t *testing.T) {
+	value, err := QuietHours(nil).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "[]", value)
+
+	quiet := QuietHours{{Start: "22:00", End: "07:00", Weekdays: []int{1, 5}}}
+	value, err = quiet.Value()
+	require.NoError(t, err)
+
+	var scanned QuietHours
+	require.NoError(t, scanned.Scan([]byte(value.(string))))
+	assert.Equal(t, quiet, scanned)
+
+	require.NoError(t, scanned.Scan(nil))
+	assert.Nil(t, scanned)
+}
@@ -0,0 +1,367 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RecurrenceFrequency string
+
+const (
+	FrequencyDaily   RecurrenceFrequency = "DAILY"
+	FrequencyWeekly  RecurrenceFrequency = "WEEKLY"
+	FrequencyMonthly RecurrenceFrequency = "MONTHLY"
+	FrequencyYearly  RecurrenceFrequency = "YEARLY"
+)
+
+// maxRecurrenceIterations bounds expansion so a rule that never matches
+// (e.g. BYMONTHDAY-like skips) cannot loop forever.
+const maxRecurrenceIterations = 100000
+
+const rruleUntilLayout = "20060102T150405Z"
+
+// WeekdayNum is a BYDAY entry. Ordinal is only meaningful for MONTHLY
+// rules ("1MO" = first Monday, "-1FR" = last Friday); zero means every
+// matching weekday in the period.
+type WeekdayNum struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+// RecurrenceRule is the subset of RFC 5545 RRULE supported by the agenda:
+// FREQ (DAILY/WEEKLY/MONTHLY/YEARLY), INTERVAL, COUNT, UNTIL and BYDAY.
+type RecurrenceRule struct {
+	Frequency RecurrenceFrequency
+	Interval  int
+	Count     int
+	Until     *time.Time
+	ByDay     []WeekdayNum
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+func ParseRecurrenceRule(value string) (*RecurrenceRule, error) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(strings.ToUpper(value), "RRULE:")
+	if value == "" {
+		return nil, fmt.Errorf("empty recurrence rule")
+	}
+
+	rule := &RecurrenceRule{Interval: 1}
+
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid recurrence rule part: %s", part)
+		}
+
+		switch key {
+		case "FREQ":
+			switch RecurrenceFrequency(val) {
+			case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+				rule.Frequency = RecurrenceFrequency(val)
+			default:
+				return nil, fmt.Errorf("unsupported recurrence frequency: %s", val)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("invalid recurrence interval: %s", val)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("invalid recurrence count: %s", val)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseRRuleUntil(val)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				day, err := parseWeekdayNum(code)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "WKST":
+			// Weeks always start on Monday; accept the default explicitly.
+			if val != "MO" {
+				return nil, fmt.Errorf("unsupported recurrence week start: %s", val)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported recurrence rule part: %s", key)
+		}
+	}
+
+	if rule.Frequency == "" {
+		return nil, fmt.Errorf("recurrence rule requires FREQ")
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, fmt.Errorf("recurrence rule cannot have both COUNT and UNTIL")
+	}
+	for _, day := range rule.ByDay {
+		if day.Ordinal != 0 && rule.Frequency != FrequencyMonthly {
+			return nil, fmt.Errorf("BYDAY ordinals are only supported with FREQ=MONTHLY")
+		}
+	}
+	if len(rule.ByDay) > 0 && rule.Frequency == FrequencyYearly {
+		return nil, fmt.Errorf("BYDAY is not supported with FREQ=YEARLY")
+	}
+
+	return rule, nil
+}
+
+func parseRRuleUntil(value string) (time.Time, error) {
+	if t, err := time.Parse(rruleUntilLayout, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		// A date-only UNTIL includes the whole day.
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid recurrence until: %s", value)
+}
+
+func parseWeekdayNum(code string) (WeekdayNum, error) {
+	code = strings.TrimSpace(code)
+	if len(code) < 2 {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY value: %s", code)
+	}
+
+	weekday, ok := weekdayCodes[code[len(code)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY value: %s", code)
+	}
+
+	day := WeekdayNum{Weekday: weekday}
+	if prefix := code[:len(code)-2]; prefix != "" {
+		ordinal, err := strconv.Atoi(prefix)
+		if err != nil || ordinal == 0 || ordinal > 5 || ordinal < -5 {
+			return WeekdayNum{}, fmt.Errorf("invalid BYDAY value: %s", code)
+		}
+		day.Ordinal = ordinal
+	}
+
+	return day, nil
+}
+
+// String renders the rule in canonical RRULE form, which is what gets stored.
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			codes[i] = weekdayNames[day.Weekday]
+			if day.Ordinal != 0 {
+				codes[i] = strconv.Itoa(day.Ordinal) + codes[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(rruleUntilLayout))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Between returns the occurrences of a series starting at dtstart that fall
+// within [from, to]. Wall-clock times are kept stable in loc across DST.
+func (r *RecurrenceRule) Between(dtstart, from, to time.Time, loc *time.Location) []time.Time {
+	var occurrences []time.Time
+	r.iterate(dtstart, loc, func(t time.Time) bool {
+		if t.After(to) {
+			return false
+		}
+		if !t.Before(from) {
+			occurrences = append(occurrences, t)
+		}
+		return true
+	})
+	return occurrences
+}
+
+// NextFrom returns the first occurrence at or after the given instant.
+func (r *RecurrenceRule) NextFrom(dtstart, from time.Time, loc *time.Location) (time.Time, bool) {
+	var next time.Time
+	found := false
+	r.iterate(dtstart, loc, func(t time.Time) bool {
+		if !t.Before(from) {
+			next = t
+			found = true
+			return false
+		}
+		return true
+	})
+	return next, found
+}
+
+// iterate yields occurrences in chronological order until fn returns false
+// or the rule is exhausted by COUNT/UNTIL.
+func (r *RecurrenceRule) iterate(dtstart time.Time, loc *time.Location, fn func(time.Time) bool) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	start := dtstart.In(loc)
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	emitted := 0
+	for period := 0; period < maxRecurrenceIterations; period++ {
+		for _, candidate := range r.candidates(start, period*interval, loc) {
+			if candidate.Before(start) {
+				continue
+			}
+			if r.Until != nil && candidate.After(*r.Until) {
+				return
+			}
+			if !fn(candidate) {
+				return
+			}
+			emitted++
+			if r.Count > 0 && emitted >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+// candidates lists the instants generated by the period that is offset
+// periods away from the one containing start, sorted ascending.
+func (r *RecurrenceRule) candidates(start time.Time, offset int, loc *time.Location) []time.Time {
+	hour, minute, second := start.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, second, 0, loc)
+	}
+
+	var result []time.Time
+
+	switch r.Frequency {
+	case FrequencyDaily:
+		day := at(start.Year(), start.Month(), start.Day()+offset)
+		if r.matchesWeekday(day.Weekday()) {
+			result = append(result, day)
+		}
+
+	case FrequencyWeekly:
+		if len(r.ByDay) == 0 {
+			result = append(result, at(start.Year(), start.Month(), start.Day()+7*offset))
+			break
+		}
+		// Weeks start on Monday (WKST=MO).
+		sinceMonday := (int(start.Weekday()) + 6) % 7
+		weekStart := start.Day() - sinceMonday + 7*offset
+		for _, day := range r.ByDay {
+			dayIndex := (int(day.Weekday) + 6) % 7
+			result = append(result, at(start.Year(), start.Month(), weekStart+dayIndex))
+		}
+
+	case FrequencyMonthly:
+		firstOfMonth := time.Date(start.Year(), start.Month()+time.Month(offset), 1, 0, 0, 0, 0, loc)
+		year, month := firstOfMonth.Year(), firstOfMonth.Month()
+		daysInMonth := time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+
+		if len(r.ByDay) == 0 {
+			// Months without the start day (e.g. the 31st) are skipped.
+			if start.Day() <= daysInMonth {
+				result = append(result, at(year, month, start.Day()))
+			}
+			break
+		}
+		for _, day := range r.ByDay {
+			for _, d := range monthDaysForWeekday(year, month, daysInMonth, day, loc) {
+				result = append(result, at(year, month, d))
+			}
+		}
+
+	case FrequencyYearly:
+		year := start.Year() + offset
+		daysInMonth := time.Date(year, start.Month()+1, 0, 0, 0, 0, 0, loc).Day()
+		if start.Day() <= daysInMonth {
+			result = append(result, at(year, start.Month(), start.Day()))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+	return dedupeTimes(result)
+}
+
+func (r *RecurrenceRule) matchesWeekday(weekday time.Weekday) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, day := range r.ByDay {
+		if day.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+func monthDaysForWeekday(year int, month time.Month, daysInMonth int, day WeekdayNum, loc *time.Location) []int {
+	firstWeekday := time.Date(year, month, 1, 0, 0, 0, 0, loc).Weekday()
+	first := 1 + (int(day.Weekday)-int(firstWeekday)+7)%7
+
+	var days []int
+	for d := first; d <= daysInMonth; d += 7 {
+		days = append(days, d)
+	}
+
+	switch {
+	case day.Ordinal > 0:
+		if day.Ordinal > len(days) {
+			return nil
+		}
+		return days[day.Ordinal-1 : day.Ordinal]
+	case day.Ordinal < 0:
+		if -day.Ordinal > len(days) {
+			return nil
+		}
+		idx := len(days) + day.Ordinal
+		return days[idx : idx+1]
+	default:
+		return days
+	}
+}
+
+func dedupeTimes(times []time.Time) []time.Time {
+	if len(times) < 2 {
+		return times
+	}
+	result := times[:1]
+	for _, t := range times[1:] {
+		if !t.Equal(result[len(result)-1]) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
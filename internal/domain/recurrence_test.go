@@ -0,0 +1,223 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurrenceRule(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		expectedErr string
+	}{
+		{name: "daily", input: "FREQ=DAILY", expected: "FREQ=DAILY"},
+		{name: "prefix and lowercase", input: "rrule:freq=weekly;byday=mo,we", expected: "FREQ=WEEKLY;BYDAY=MO,WE"},
+		{name: "interval and count", input: "FREQ=MONTHLY;INTERVAL=6;COUNT=4", expected: "FREQ=MONTHLY;INTERVAL=6;COUNT=4"},
+		{name: "monthly ordinal", input: "FREQ=MONTHLY;BYDAY=-1FR", expected: "FREQ=MONTHLY;BYDAY=-1FR"},
+		{name: "until", input: "FREQ=DAILY;UNTIL=20250110T120000Z", expected: "FREQ=DAILY;UNTIL=20250110T120000Z"},
+		{name: "missing freq", input: "INTERVAL=2", expectedErr: "requires FREQ"},
+		{name: "unsupported freq", input: "FREQ=HOURLY", expectedErr: "unsupported recurrence frequency"},
+		{name: "count and until", input: "FREQ=DAILY;COUNT=2;UNTIL=20250110", expectedErr: "both COUNT and UNTIL"},
+		{name: "ordinal outside monthly", input: "FREQ=WEEKLY;BYDAY=1MO", expectedErr: "only supported with FREQ=MONTHLY"},
+		{name: "unknown part", input: "FREQ=DAILY;BYHOUR=9", expectedErr: "unsupported recurrence rule part"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.input)
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rule.String())
+		})
+	}
+}
+
+func TestRecurrenceRule_Between(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	date := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name     string
+		rule     string
+		dtstart  time.Time
+		from     time.Time
+		to       time.Time
+		expected []time.Time
+	}{
+		{
+			name:    "weekdays skip weekend",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+			dtstart: date(2025, 1, 2, 9, 30), // Thursday
+			from:    date(2025, 1, 1, 0, 0),
+			to:      date(2025, 1, 7, 23, 0),
+			expected: []time.Time{
+				date(2025, 1, 2, 9, 30), date(2025, 1, 3, 9, 30),
+				date(2025, 1, 6, 9, 30), date(2025, 1, 7, 9, 30),
+			},
+		},
+		{
+			name:    "every six months",
+			rule:    "FREQ=MONTHLY;INTERVAL=6",
+			dtstart: date(2025, 3, 10, 15, 0),
+			from:    date(2025, 1, 1, 0, 0),
+			to:      date(2026, 12, 31, 0, 0),
+			expected: []time.Time{
+				date(2025, 3, 10, 15, 0), date(2025, 9, 10, 15, 0),
+				date(2026, 3, 10, 15, 0), date(2026, 9, 10, 15, 0),
+			},
+		},
+		{
+			name:    "monthly skips short months",
+			rule:    "FREQ=MONTHLY;COUNT=3",
+			dtstart: date(2025, 1, 31, 8, 0),
+			from:    date(2025, 1, 1, 0, 0),
+			to:      date(2025, 12, 31, 0, 0),
+			expected: []time.Time{
+				date(2025, 1, 31, 8, 0), date(2025, 3, 31, 8, 0), date(2025, 5, 31, 8, 0),
+			},
+		},
+		{
+			name:    "last friday of month",
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart: date(2025, 1, 1, 18, 0),
+			from:    date(2025, 1, 1, 0, 0),
+			to:      date(2025, 3, 31, 0, 0),
+			expected: []time.Time{
+				date(2025, 1, 31, 18, 0), date(2025, 2, 28, 18, 0), date(2025, 3, 28, 18, 0),
+			},
+		},
+		{
+			name:    "daily keeps wall clock across DST",
+			rule:    "FREQ=DAILY",
+			dtstart: date(2025, 3, 8, 9, 0),
+			from:    date(2025, 3, 8, 0, 0),
+			to:      date(2025, 3, 10, 23, 0),
+			expected: []time.Time{
+				date(2025, 3, 8, 9, 0), date(2025, 3, 9, 9, 0), date(2025, 3, 10, 9, 0),
+			},
+		},
+		{
+			name:     "until is inclusive",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;UNTIL=20250115T140000Z",
+			dtstart:  date(2025, 1, 1, 9, 0),
+			from:     date(2025, 1, 1, 0, 0),
+			to:       date(2025, 3, 1, 0, 0),
+			expected: []time.Time{date(2025, 1, 1, 9, 0), date(2025, 1, 15, 9, 0)},
+		},
+		{
+			name:     "yearly leap day",
+			rule:     "FREQ=YEARLY",
+			dtstart:  date(2024, 2, 29, 10, 0),
+			from:     date(2024, 1, 1, 0, 0),
+			to:       date(2029, 1, 1, 0, 0),
+			expected: []time.Time{date(2024, 2, 29, 10, 0), date(2028, 2, 29, 10, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.rule)
+			require.NoError(t, err)
+
+			occurrences := rule.Between(tt.dtstart, tt.from, tt.to, loc)
+			require.Len(t, occurrences, len(tt.expected))
+			for i := range tt.expected {
+				assert.True(t, tt.expected[i].Equal(occurrences[i]), "occurrence %d: expected %s, got %s", i, tt.expected[i], occurrences[i])
+			}
+		})
+	}
+}
+
+func TestRecurrenceRule_NextFrom(t *testing.T) {
+	rule, err := ParseRecurrenceRule("FREQ=WEEKLY;BYDAY=MO;COUNT=2")
+	require.NoError(t, err)
+
+	dtstart := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC) // Monday
+
+	next, ok := rule.NextFrom(dtstart, dtstart.Add(time.Minute), time.UTC)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2025, 1, 13, 9, 0, 0, 0, time.UTC), next)
+
+	_, ok = rule.NextFrom(dtstart, next.Add(time.Minute), time.UTC)
+	assert.False(t, ok)
+}
+
+func TestExpandOccurrences(t *testing.T) {
+	rule := "FREQ=DAILY"
+	next := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	events := []Event{
+		{
+			ID:                1,
+			Title:             "Standup",
+			StartsAt:          time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC),
+			RecurrenceRule:    &rule,
+			NextOccurrenceAt:  &next,
+			Status:            EventStatusConfirmed,
+			NotificationsSent: 1,
+		},
+		{ID: 2, Title: "Dentist", StartsAt: time.Date(2025, 1, 2, 14, 0, 0, 0, time.UTC)},
+	}
+
+	expanded := ExpandOccurrences(events,
+		time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 3, 23, 0, 0, 0, time.UTC),
+		time.UTC,
+	)
+
+	require.Len(t, expanded, 3)
+	assert.Equal(t, 1, expanded[0].ID)
+	assert.Equal(t, next, *expanded[0].OriginalStartsAt)
+	assert.Equal(t, EventStatusConfirmed, expanded[0].Status)
+	assert.Equal(t, 2, expanded[1].ID)
+	assert.Equal(t, 1, expanded[2].ID)
+	assert.Equal(t, EventStatusScheduled, expanded[2].Status)
+	assert.Equal(t, 0, expanded[2].NotificationsSent)
+}
+
+func TestExpandOccurrences_Exceptions(t *testing.T) {
+	rule := "FREQ=DAILY"
+	canceled := EventStatusCanceled
+	moved := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
+	title := "Gym (moved)"
+	event := Event{
+		ID:             1,
+		Title:          "Gym",
+		StartsAt:       time.Date(2025, 1, 1, 7, 0, 0, 0, time.UTC),
+		RecurrenceRule: &rule,
+		Exceptions: []EventException{
+			{EventID: 1, OriginalStartsAt: time.Date(2025, 1, 2, 7, 0, 0, 0, time.UTC), Status: &canceled},
+			{EventID: 1, OriginalStartsAt: time.Date(2025, 1, 1, 7, 0, 0, 0, time.UTC), StartsAt: &moved, Title: &title},
+		},
+	}
+
+	expanded := ExpandOccurrences([]Event{event},
+		time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 5, 23, 0, 0, 0, time.UTC),
+		time.UTC,
+	)
+
+	require.Len(t, expanded, 4)
+	assert.Equal(t, time.Date(2025, 1, 3, 7, 0, 0, 0, time.UTC), expanded[0].StartsAt)
+	assert.Equal(t, time.Date(2025, 1, 4, 7, 0, 0, 0, time.UTC), expanded[1].StartsAt)
+	assert.Equal(t, time.Date(2025, 1, 5, 7, 0, 0, 0, time.UTC), expanded[2].StartsAt)
+	assert.Equal(t, moved, expanded[3].StartsAt, "occurrence moved into the range is included")
+	assert.Equal(t, title, expanded[3].Title)
+	assert.Equal(t, time.Date(2025, 1, 1, 7, 0, 0, 0, time.UTC), *expanded[3].OriginalStartsAt)
+
+	next, ok := event.NextOccurrence(time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC), time.UTC)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2025, 1, 3, 7, 0, 0, 0, time.UTC), next.StartsAt, "canceled occurrence is skipped")
+}
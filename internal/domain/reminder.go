@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MaxReminderStages bounds how many reminders a single schedule can hold.
+const MaxReminderStages = 10
+
+// maxReminderOffsetMinutes is one week, the same ceiling as remind_before_minutes.
+const maxReminderOffsetMinutes = 10080
+
+// ReminderSchedule is an ordered list of reminder offsets, in minutes before
+// the event starts, largest first (e.g. 1440, 120, 15).
+type ReminderSchedule []int
+
+// NewReminderSchedule validates the offsets and returns them deduplicated and
+// sorted largest first.
+func NewReminderSchedule(offsets []int) (ReminderSchedule, error) {
+	if len(offsets) > MaxReminderStages {
+		return nil, fmt.Errorf("at most %d reminders are allowed", MaxReminderStages)
+	}
+
+	seen := make(map[int]bool, len(offsets))
+	schedule := make(ReminderSchedule, 0, len(offsets))
+	for _, offset := range offsets {
+		if offset < 0 || offset > maxReminderOffsetMinutes {
+			return nil, fmt.Errorf("invalid reminder offset: %d minutes", offset)
+		}
+		if seen[offset] {
+			continue
+		}
+		seen[offset] = true
+		schedule = append(schedule, offset)
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(schedule)))
+	return schedule, nil
+}
+
+// Scan reads a Postgres integer array such as {1440,120,15}.
+func (s *ReminderSchedule) Scan(src interface{}) error {
+	var text string
+	switch v := src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	default:
+		return fmt.Errorf("cannot scan %T into ReminderSchedule", src)
+	}
+
+	text = strings.Trim(text, "{}")
+	if text == "" {
+		*s = nil
+		return nil
+	}
+
+	parts := strings.Split(text, ",")
+	schedule := make(ReminderSchedule, 0, len(parts))
+	for _, part := range parts {
+		offset, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return fmt.Errorf("invalid reminder offset %q: %w", part, err)
+		}
+		schedule = append(schedule, offset)
+	}
+
+	*s = schedule
+	return nil
+}
+
+// Value writes the schedule as a Postgres integer array literal.
+func (s ReminderSchedule) Value() (driver.Value, error) {
+	parts := make([]string, len(s))
+	for i, offset := range s {
+		parts[i] = strconv.Itoa(offset)
+	}
+	return "{" + strings.Join(parts, ",") + "}", nil
+}
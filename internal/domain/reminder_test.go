@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReminderSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		offsets []int
+		want    ReminderSchedule
+		wantErr bool
+	}{
+		{name: "sorted largest first", offsets: []int{15, 1440, 120}, want: ReminderSchedule{1440, 120, 15}},
+		{name: "duplicates removed", offsets: []int{60, 60, 0}, want: ReminderSchedule{60, 0}},
+		{name: "empty", offsets: []int{}, want: ReminderSchedule{}},
+		{name: "negative offset", offsets: []int{-5}, wantErr: true},
+		{name: "beyond a week", offsets: []int{10081}, wantErr: true},
+		{name: "too many stages", offsets: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewReminderSchedule(tt.offsets)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReminderSchedule_ScanValue(t *testing.T) {
+	var schedule ReminderSchedule
+	require.NoError(t, schedule.Scan([]byte("{1440,120,15}")))
+	assert.Equal(t, ReminderSchedule{1440, 120, 15}, schedule)
+
+	value, err := schedule.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "{1440,120,15}", value)
+
+	require.NoError(t, schedule.Scan([]byte("{}")))
+	assert.Empty(t, schedule)
+}
+
+func TestEvent_DueReminderOffset(t *testing.T) {
+	start := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	notifiedDayBefore := start.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name           string
+		now            time.Time
+		lastNotifiedAt *time.Time
+		want           int
+		wantDue        bool
+	}{
+		{name: "before first stage", now: start.Add(-25 * time.Hour)},
+		{name: "first stage due", now: start.Add(-24 * time.Hour), want: 1440, wantDue: true},
+		{name: "first stage already sent", now: start.Add(-3 * time.Hour), lastNotifiedAt: &notifiedDayBefore},
+		{name: "second stage due", now: start.Add(-2 * time.Hour), lastNotifiedAt: &notifiedDayBefore, want: 120, wantDue: true},
+		{name: "missed stages collapse into latest", now: start.Add(-10 * time.Minute), want: 15, wantDue: true},
+		{name: "event started", now: start},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := Event{
+				StartsAt:        start,
+				ReminderOffsets: ReminderSchedule{1440, 120, 15},
+				LastNotifiedAt:  tt.lastNotifiedAt,
+			}
+			got, due := event.DueReminderOffset(tt.now)
+			assert.Equal(t, tt.wantDue, due)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEvent_ReminderDueAt(t *testing.T) {
+	start := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	snoozedUntil := start.Add(-30 * time.Minute)
+	notified := start.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		event   Event
+		now     time.Time
+		want    time.Time
+		wantDue bool
+	}{
+		{name: "snooze pending", event: Event{StartsAt: start, SnoozedUntil: &snoozedUntil}, now: snoozedUntil.Add(-time.Minute)},
+		{name: "snooze over", event: Event{StartsAt: start, SnoozedUntil: &snoozedUntil}, now: start, want: snoozedUntil, wantDue: true},
+		{name: "stage due", event: Event{StartsAt: start, ReminderOffsets: ReminderSchedule{60, 15}}, now: start.Add(-50 * time.Minute), want: start.Add(-time.Hour), wantDue: true},
+		{name: "stage missed while ongoing", event: Event{StartsAt: start, EndsAt: &end, ReminderOffsets: ReminderSchedule{60, 15}}, now: start.Add(10 * time.Minute), want: start.Add(-15 * time.Minute), wantDue: true},
+		{name: "stage missed after end", event: Event{StartsAt: start, EndsAt: &end, ReminderOffsets: ReminderSchedule{60, 15}}, now: end},
+		{name: "before reminder time", event: Event{StartsAt: start, RemindBeforeMinutes: 30, MaxNotifications: 3}, now: start.Add(-31 * time.Minute)},
+		{name: "reminder time passed", event: Event{StartsAt: start, RemindBeforeMinutes: 30, MaxNotifications: 3}, now: start.Add(-10 * time.Minute), want: start.Add(-30 * time.Minute), wantDue: true},
+		{name: "repetition due", event: Event{StartsAt: start, RemindBeforeMinutes: 90, RemindFrequencyMinutes: 20, MaxNotifications: 3, NotificationsSent: 1, LastNotifiedAt: &notified}, now: start.Add(-30 * time.Minute), want: notified.Add(20 * time.Minute), wantDue: true},
+		{name: "repetition pending", event: Event{StartsAt: start, RemindBeforeMinutes: 90, RemindFrequencyMinutes: 20, MaxNotifications: 3, NotificationsSent: 1, LastNotifiedAt: &notified}, now: notified.Add(10 * time.Minute)},
+		{name: "notifications exhausted", event: Event{StartsAt: start, RemindBeforeMinutes: 30, MaxNotifications: 1, NotificationsSent: 1}, now: start.Add(-10 * time.Minute)},
+		{name: "started without end", event: Event{StartsAt: start, RemindBeforeMinutes: 30, MaxNotifications: 3}, now: start},
+		{name: "deferral pending", event: Event{StartsAt: start, RemindBeforeMinutes: 90, MaxNotifications: 3, DeferredUntil: &snoozedUntil}, now: snoozedUntil.Add(-time.Minute)},
+		{name: "deferral over", event: Event{StartsAt: start, RemindBeforeMinutes: 90, MaxNotifications: 3, DeferredUntil: &snoozedUntil}, now: start.Add(-10 * time.Minute), want: snoozedUntil, wantDue: true},
+		{name: "deferred past the limit", event: Event{StartsAt: start, RemindBeforeMinutes: 90, MaxNotifications: 1, NotificationsSent: 1, DeferredUntil: &snoozedUntil}, now: start.Add(-10 * time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, due := tt.event.ReminderDueAt(tt.now)
+			assert.Equal(t, tt.wantDue, due)
+			if tt.wantDue {
+				assert.True(t, tt.want.Equal(got), "got %s", got)
+			}
+		})
+	}
+}
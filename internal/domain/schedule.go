@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// allDayReminderTime is how long after midnight reminders of all-day events
+// are anchored, so "1 hour before" lands at 08:00 instead of 23:00 the day
+// before.
+const allDayReminderTime = 9 * time.Hour
+
+// StartOfDay returns local midnight of the day t falls on in loc.
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+}
+
+// AllDayBounds spans whole days in loc: from midnight of the first day to
+// midnight after the last one (exclusive end).
+func AllDayBounds(first, last time.Time, loc *time.Location) (time.Time, time.Time) {
+	start := StartOfDay(first, loc)
+	end := StartOfDay(last, loc).AddDate(0, 0, 1)
+	if !end.After(start) {
+		end = start.AddDate(0, 0, 1)
+	}
+	return start, end
+}
+
+// Duration is how long the event lasts, zero when it has no end.
+func (e *Event) Duration() time.Duration {
+	if e.EndsAt == nil {
+		return 0
+	}
+	return e.EndsAt.Sub(e.StartsAt)
+}
+
+// endFor places the event's end relative to a given start, keeping whole
+// days for all-day events so they stay aligned to midnight across DST.
+func (e *Event) endFor(start time.Time) *time.Time {
+	if e.EndsAt == nil {
+		return nil
+	}
+
+	var end time.Time
+	if e.AllDay {
+		days := int(math.Round(e.Duration().Hours() / 24))
+		end = start.AddDate(0, 0, days)
+	} else {
+		end = start.Add(e.Duration())
+	}
+	return &end
+}
+
+// OccurrenceEndsAt is the end of the occurrence reminders are tracking.
+func (e *Event) OccurrenceEndsAt() *time.Time {
+	if e.IsRecurring() && !e.IsOccurrence() {
+		return e.endFor(e.OccurrenceStartsAt())
+	}
+	return e.EndsAt
+}
+
+// ReminderAnchor is the instant reminder offsets count back from: the start
+// of timed events, or a fixed time on the first day of all-day events.
+func (e *Event) ReminderAnchor() time.Time {
+	if e.AllDay {
+		return e.OccurrenceStartsAt().Add(allDayReminderTime)
+	}
+	return e.OccurrenceStartsAt()
+}
+
+// IsOngoingOrUpcoming reports whether the event has not finished at now.
+func (e *Event) IsOngoingOrUpcoming(now time.Time) bool {
+	if end := e.OccurrenceEndsAt(); end != nil {
+		return end.After(now)
+	}
+	return e.OccurrenceStartsAt().After(now)
+}
+
+// FormatWhen renders when the event happens in loc and locale, e.g.
+// "10/03/2025 14:00", "10/03/2025 14:00–16:00" or "10/03 a 12/03/2025 (dia
+// inteiro)".
+func (e *Event) FormatWhen(loc *time.Location, locale Locale) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	words := wordsFor(locale)
+	start := e.OccurrenceStartsAt().In(loc)
+	end := e.OccurrenceEndsAt()
+
+	if e.AllDay {
+		if end == nil {
+			return fmt.Sprintf(words.allDay, start.Format(words.dateWithYear))
+		}
+		last := end.In(loc).AddDate(0, 0, -1)
+		if !last.After(start) {
+			return fmt.Sprintf(words.allDay, start.Format(words.dateWithYear))
+		}
+		days := fmt.Sprintf(words.dateRange, start.Format(words.date), last.Format(words.dateWithYear))
+		return fmt.Sprintf(words.allDay, days)
+	}
+
+	if end == nil {
+		return start.Format(words.stamp)
+	}
+
+	localEnd := end.In(loc)
+	if StartOfDay(start, loc).Equal(StartOfDay(localEnd, loc)) {
+		return fmt.Sprintf("%s–%s", start.Format(words.stamp), localEnd.Format(words.stampClock))
+	}
+	return fmt.Sprintf(words.dateRange, start.Format(words.stamp), localEnd.Format(words.stamp))
+}
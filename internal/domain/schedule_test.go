@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllDayBounds(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		first     time.Time
+		last      time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "single day",
+			first:     time.Date(2025, 6, 10, 15, 0, 0, 0, loc),
+			last:      time.Date(2025, 6, 10, 15, 0, 0, 0, loc),
+			wantStart: time.Date(2025, 6, 10, 0, 0, 0, 0, loc),
+			wantEnd:   time.Date(2025, 6, 11, 0, 0, 0, 0, loc),
+		},
+		{
+			name:      "several days",
+			first:     time.Date(2025, 6, 9, 8, 0, 0, 0, loc),
+			last:      time.Date(2025, 6, 11, 8, 0, 0, 0, loc),
+			wantStart: time.Date(2025, 6, 9, 0, 0, 0, 0, loc),
+			wantEnd:   time.Date(2025, 6, 12, 0, 0, 0, 0, loc),
+		},
+		{
+			name:      "across DST start",
+			first:     time.Date(2025, 3, 8, 12, 0, 0, 0, loc),
+			last:      time.Date(2025, 3, 9, 12, 0, 0, 0, loc),
+			wantStart: time.Date(2025, 3, 8, 0, 0, 0, 0, loc),
+			wantEnd:   time.Date(2025, 3, 10, 0, 0, 0, 0, loc),
+		},
+		{
+			name:      "last before first",
+			first:     time.Date(2025, 6, 10, 0, 0, 0, 0, loc),
+			last:      time.Date(2025, 6, 8, 0, 0, 0, 0, loc),
+			wantStart: time.Date(2025, 6, 10, 0, 0, 0, 0, loc),
+			wantEnd:   time.Date(2025, 6, 11, 0, 0, 0, 0, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := AllDayBounds(tt.first, tt.last, loc)
+			assert.True(t, tt.wantStart.Equal(start), "start %s", start)
+			assert.True(t, tt.wantEnd.Equal(end), "end %s", end)
+		})
+	}
+}
+
+func TestEvent_FormatWhen(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	at := func(day, hour, minute int) *time.Time {
+		v := time.Date(2025, 3, day, hour, minute, 0, 0, loc)
+		return &v
+	}
+
+	tests := []struct {
+		name  string
+		event Event
+		want  string
+	}{
+		{name: "start only", event: Event{StartsAt: *at(10, 14, 0)}, want: "10/03/2025 14:00"},
+		{name: "same day", event: Event{StartsAt: *at(10, 14, 0), EndsAt: at(10, 16, 30)}, want: "10/03/2025 14:00–16:30"},
+		{name: "overnight", event: Event{StartsAt: *at(10, 22, 0), EndsAt: at(11, 2, 0)}, want: "10/03/2025 22:00 a 11/03/2025 02:00"},
+		{name: "all day", event: Event{StartsAt: *at(10, 0, 0), EndsAt: at(11, 0, 0), AllDay: true}, want: "10/03/2025 (dia inteiro)"},
+		{name: "several days", event: Event{StartsAt: *at(10, 0, 0), EndsAt: at(13, 0, 0), AllDay: true}, want: "10/03 a 12/03/2025 (dia inteiro)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.event.FormatWhen(loc, LocalePortuguese))
+		})
+	}
+}
+
+func TestEvent_AllDayRecurringOccurrence(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	rule := "FREQ=WEEKLY"
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, loc)
+	end := start.AddDate(0, 0, 2)
+	event := Event{StartsAt: start, EndsAt: &end, AllDay: true, RecurrenceRule: &rule}
+
+	// The second occurrence spans the DST change but must still end at midnight.
+	occurrences := event.OccurrencesBetween(start.AddDate(0, 0, 7), start.AddDate(0, 0, 7), loc)
+	require.Len(t, occurrences, 1)
+	require.NotNil(t, occurrences[0].EndsAt)
+	assert.True(t, time.Date(2025, 3, 10, 0, 0, 0, 0, loc).Equal(*occurrences[0].EndsAt))
+	assert.True(t, time.Date(2025, 3, 8, 9, 0, 0, 0, loc).Equal(occurrences[0].ReminderAnchor()))
+}
+
+func TestEvent_IsOngoingOrUpcoming(t *testing.T) {
+	now := time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
+	end := now.Add(time.Hour)
+
+	assert.True(t, (&Event{StartsAt: now.Add(-time.Hour), EndsAt: &end}).IsOngoingOrUpcoming(now))
+	assert.False(t, (&Event{StartsAt: now.Add(-time.Hour)}).IsOngoingOrUpcoming(now))
+	assert.True(t, (&Event{StartsAt: now.Add(time.Minute)}).IsOngoingOrUpcoming(now))
+}
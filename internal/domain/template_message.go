@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SessionWindow is how long after a user's last message WhatsApp delivers
+// free-form messages to them. Outside it, only approved templates are.
+const SessionWindow = 24 * time.Hour
+
+// TemplateMessage is a message sent from a template registered with and
+// approved by WhatsApp (an HSM). Params fill the placeholders of its body in
+// order, and ButtonPayloads are what its quick reply buttons send back, in
+// the same format as the IDs of interactive options.
+type TemplateMessage struct {
+	Name           string   `json:"name"`
+	Language       string   `json:"language"`
+	Params         []string `json:"params"`
+	ButtonPayloads []string `json:"button_payloads,omitempty"`
+}
+
+// Scan reads a JSONB template column.
+func (m *TemplateMessage) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into TemplateMessage", src)
+	}
+	return json.Unmarshal(data, m)
+}
+
+func (m TemplateMessage) Value() (driver.Value, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// RegisteredTemplates maps the names of the messages sent to users, such as
+// TemplateReminder, to the names of the WhatsApp templates registered for
+// them. Each template is expected in every supported locale.
+type RegisteredTemplates map[string]string
+
+// Message returns the template registered for the named message, in locale
+// and filled with params, or nil when there is none.
+func (r RegisteredTemplates) Message(name string, locale Locale, params ...string) *TemplateMessage {
+	registered, ok := r[name]
+	if !ok || registered == "" {
+		return nil
+	}
+	return &TemplateMessage{
+		Name:     registered,
+		Language: strings.ReplaceAll(string(locale), "-", "_"),
+		Params:   params,
+	}
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisteredTemplates_Message(t *testing.T) {
+	templates := RegisteredTemplates{TemplateReminder: "alarm_reminder", TemplateConfirmationRequest: ""}
+
+	assert.Equal(t, &TemplateMessage{
+		Name:     "alarm_reminder",
+		Language: "pt_BR",
+		Params:   []string{"Dentista", "amanhã às 14h"},
+	}, templates.Message(TemplateReminder, LocalePortuguese, "Dentista", "amanhã às 14h"))
+	assert.Equal(t, "en", templates.Message(TemplateReminder, LocaleEnglish).Language)
+
+	assert.Nil(t, templates.Message(TemplateConfirmationRequest, LocalePortuguese), "empty names are not registered")
+	assert.Nil(t, RegisteredTemplates(nil).Message(TemplateReminder, LocalePortuguese))
+}
+
+func TestUserIdentity_InSession(t *testing.T) {
+	now := time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC)
+	lastInbound := now.Add(-23 * time.Hour)
+	identity := &UserIdentity{LastInboundAt: &lastInbound}
+
+	assert.True(t, identity.InSession(now))
+	assert.False(t, identity.InSession(now.Add(time.Hour)))
+	assert.False(t, (&UserIdentity{}).InSession(now), "never wrote")
+}
@@ -5,19 +5,70 @@ import (
 )
 
 type User struct {
-	ID                            int       `json:"id" db:"id"`
-	WANumber                      string    `json:"wa_number" db:"wa_number"`
-	Name                          *string   `json:"name,omitempty" db:"name"`
-	Timezone                      string    `json:"timezone" db:"timezone"`
-	DefaultRemindBeforeMinutes    int       `json:"default_remind_before_minutes" db:"default_remind_before_minutes"`
-	DefaultRemindFrequencyMinutes int       `json:"default_remind_frequency_minutes" db:"default_remind_frequency_minutes"`
-	DefaultRequireConfirmation    bool      `json:"default_require_confirmation" db:"default_require_confirmation"`
-	LLMProvider                   *string   `json:"llm_provider,omitempty" db:"llm_provider"`
-	LLMModel                      *string   `json:"llm_model,omitempty" db:"llm_model"`
-	RateLimitPerMinute            int       `json:"rate_limit_per_minute" db:"rate_limit_per_minute"`
-	IsActive                      bool      `json:"is_active" db:"is_active"`
-	CreatedAt                     time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt                     time.Time `json:"updated_at" db:"updated_at"`
+	ID int `json:"id" db:"id"`
+	// WANumber is the number of the user's WhatsApp identity, empty when
+	// they have none.
+	WANumber string `json:"wa_number" db:"wa_number"`
+	// PreferredChannel is where reminders and digests are delivered, to
+	// ContactID, the user's verified identity there.
+	PreferredChannel Channel `json:"preferred_channel" db:"preferred_channel"`
+	ContactID        string  `json:"-" db:"contact_id"`
+	// Via is the identity the message being handled came from, which
+	// replies go back to; it is never stored.
+	Via                           *UserIdentity    `json:"-" db:"-"`
+	Name                          *string          `json:"name,omitempty" db:"name"`
+	Timezone                      string           `json:"timezone" db:"timezone"`
+	Locale                        Locale           `json:"locale,omitempty" db:"locale"`
+	DefaultRemindBeforeMinutes    int              `json:"default_remind_before_minutes" db:"default_remind_before_minutes"`
+	DefaultRemindFrequencyMinutes int              `json:"default_remind_frequency_minutes" db:"default_remind_frequency_minutes"`
+	DefaultRequireConfirmation    bool             `json:"default_require_confirmation" db:"default_require_confirmation"`
+	DefaultReminderOffsets        ReminderSchedule `json:"default_reminder_offsets,omitempty" db:"default_reminder_offsets"`
+	DailyDigestEnabled            bool             `json:"daily_digest_enabled" db:"daily_digest_enabled"`
+	DailyDigestTime               string           `json:"daily_digest_time" db:"daily_digest_time"`
+	WeeklyDigestEnabled           bool             `json:"weekly_digest_enabled" db:"weekly_digest_enabled"`
+	WeeklyDigestTime              string           `json:"weekly_digest_time" db:"weekly_digest_time"`
+	LastDailyDigestAt             *time.Time       `json:"last_daily_digest_at,omitempty" db:"last_daily_digest_at"`
+	LastWeeklyDigestAt            *time.Time       `json:"last_weekly_digest_at,omitempty" db:"last_weekly_digest_at"`
+	QuietHours                    QuietHours       `json:"quiet_hours,omitempty" db:"quiet_hours"`
+	NotifyNoResponse              bool             `json:"notify_no_response" db:"notify_no_response"`
+	DefaultEscalationPolicy       EscalationPolicy `json:"default_escalation_policy,omitempty" db:"default_escalation_policy"`
+	LLMProvider                   *string          `json:"llm_provider,omitempty" db:"llm_provider"`
+	LLMModel                      *string          `json:"llm_model,omitempty" db:"llm_model"`
+	RateLimitPerMinute            int              `json:"rate_limit_per_minute" db:"rate_limit_per_minute"`
+	IsActive                      bool             `json:"is_active" db:"is_active"`
+	CreatedAt                     time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt                     time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// Location resolves the user's timezone, falling back to UTC when unset or invalid.
+func (u *User) Location() *time.Location {
+	if u.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Language is the locale messages to the user are written in: the one set
+// or detected for them, or the default while it is unknown.
+func (u *User) Language() Locale {
+	if u == nil {
+		return DefaultLocale
+	}
+	return u.Locale.OrDefault()
+}
+
+// DisplayName is how the user is named in messages to other people: their
+// name, or how they are reached when it is unknown.
+func (u *User) DisplayName() string {
+	if u.Name != nil && *u.Name != "" {
+		return *u.Name
+	}
+	_, contact := u.Contact()
+	return contact
 }
 
 type WhitelistNumber struct {
@@ -36,14 +87,50 @@ type UserAllowedContact struct {
 }
 
 type UserConfig struct {
-	UserID                        int     `json:"user_id"`
-	Name                          *string `json:"name,omitempty"`
-	Timezone                      string  `json:"timezone"`
-	DefaultRemindBeforeMinutes    int     `json:"default_remind_before_minutes"`
-	DefaultRemindFrequencyMinutes int     `json:"default_remind_frequency_minutes"`
-	DefaultRequireConfirmation    bool    `json:"default_require_confirmation"`
-	LLMProvider                   *string `json:"llm_provider,omitempty"`
-	LLMModel                      *string `json:"llm_model,omitempty"`
-	RateLimitPerMinute            int     `json:"rate_limit_per_minute"`
-	IsActive                      bool    `json:"is_active"`
+	UserID                        int              `json:"user_id"`
+	Name                          *string          `json:"name,omitempty"`
+	Timezone                      string           `json:"timezone"`
+	Locale                        Locale           `json:"locale,omitempty"`
+	PreferredChannel              Channel          `json:"preferred_channel"`
+	DefaultRemindBeforeMinutes    int              `json:"default_remind_before_minutes"`
+	DefaultRemindFrequencyMinutes int              `json:"default_remind_frequency_minutes"`
+	DefaultRequireConfirmation    bool             `json:"default_require_confirmation"`
+	DefaultReminderOffsets        ReminderSchedule `json:"default_reminder_offsets,omitempty"`
+	DailyDigestEnabled            bool             `json:"daily_digest_enabled"`
+	DailyDigestTime               string           `json:"daily_digest_time"`
+	WeeklyDigestEnabled           bool             `json:"weekly_digest_enabled"`
+	WeeklyDigestTime              string           `json:"weekly_digest_time"`
+	QuietHours                    QuietHours       `json:"quiet_hours,omitempty"`
+	NotifyNoResponse              bool             `json:"notify_no_response"`
+	DefaultEscalationPolicy       EscalationPolicy `json:"default_escalation_policy,omitempty"`
+	LLMProvider                   *string          `json:"llm_provider,omitempty"`
+	LLMModel                      *string          `json:"llm_model,omitempty"`
+	RateLimitPerMinute            int              `json:"rate_limit_per_minute"`
+	IsActive                      bool             `json:"is_active"`
+}
+
+// Config returns the user's editable settings.
+func (u *User) Config() *UserConfig {
+	return &UserConfig{
+		UserID:                        u.ID,
+		Name:                          u.Name,
+		Timezone:                      u.Timezone,
+		Locale:                        u.Locale,
+		PreferredChannel:              u.PreferredChannel.OrDefault(),
+		DefaultRemindBeforeMinutes:    u.DefaultRemindBeforeMinutes,
+		DefaultRemindFrequencyMinutes: u.DefaultRemindFrequencyMinutes,
+		DefaultRequireConfirmation:    u.DefaultRequireConfirmation,
+		DefaultReminderOffsets:        u.DefaultReminderOffsets,
+		DailyDigestEnabled:            u.DailyDigestEnabled,
+		DailyDigestTime:               u.DailyDigestTime,
+		WeeklyDigestEnabled:           u.WeeklyDigestEnabled,
+		WeeklyDigestTime:              u.WeeklyDigestTime,
+		QuietHours:                    u.QuietHours,
+		NotifyNoResponse:              u.NotifyNoResponse,
+		DefaultEscalationPolicy:       u.DefaultEscalationPolicy,
+		LLMProvider:                   u.LLMProvider,
+		LLMModel:                      u.LLMModel,
+		RateLimitPerMinute:            u.RateLimitPerMinute,
+		IsActive:                      u.IsActive,
+	}
 }
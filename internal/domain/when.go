@@ -0,0 +1,357 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// timeWords are what times are phrased with in a locale.
+type timeWords struct {
+	today, tomorrow, yesterday string
+	weekdays                   [7]string
+	// date and dateWithYear lay out days further away.
+	date, dateWithYear string
+	clock              func(t time.Time) string
+	// at, from and to place a time of day in a sentence, e.g. "às 14h",
+	// "das 14h" and "às 16h" in "amanhã das 14h às 16h".
+	at, from, to func(t time.Time) string
+	// allDay, allDays and until are format strings for all-day events and
+	// events that end on another day.
+	allDay, allDays, until string
+	// stamp and stampClock lay out exact instants, for people who do not
+	// share the user's "today"; dateRange joins two of them.
+	stamp, stampClock, dateRange string
+	// Units of durations, singular and plural, and the conjunction of the
+	// last item of a list.
+	minute, hour, day [2]string
+	and               string
+	// every describes each recurrence frequency, as in "todo dia", and its
+	// unit for intervals, as in "a cada 2 dias"; everyN, times and
+	// repeatUntil complete the description.
+	every                      map[RecurrenceFrequency][2]string
+	everyN, times, repeatUntil string
+}
+
+var localeTimeWords = map[Locale]*timeWords{
+	LocalePortuguese: {
+		today:        "hoje",
+		tomorrow:     "amanhã",
+		yesterday:    "ontem",
+		weekdays:     [7]string{"domingo", "segunda", "terça", "quarta", "quinta", "sexta", "sábado"},
+		date:         "02/01",
+		dateWithYear: "02/01/2006",
+		clock:        portugueseClock,
+		at: func(t time.Time) string {
+			return contractedTime(t, portugueseClock, "à meia-noite", "ao meio-dia", "à ", "às ")
+		},
+		from: func(t time.Time) string {
+			return contractedTime(t, portugueseClock, "da meia-noite", "do meio-dia", "da ", "das ")
+		},
+		to: func(t time.Time) string {
+			return contractedTime(t, portugueseClock, "à meia-noite", "ao meio-dia", "à ", "às ")
+		},
+		allDay:     "%s (dia inteiro)",
+		allDays:    "de %s a %s (dia inteiro)",
+		until:      "%s até %s",
+		stamp:      "02/01/2006 15:04",
+		stampClock: "15:04",
+		dateRange:  "%s a %s",
+		minute:     [2]string{"minuto", "minutos"},
+		hour:       [2]string{"hora", "horas"},
+		day:        [2]string{"dia", "dias"},
+		and:        "e",
+		every: map[RecurrenceFrequency][2]string{
+			FrequencyDaily:   {"todos os dias", "dias"},
+			FrequencyWeekly:  {"toda semana", "semanas"},
+			FrequencyMonthly: {"todo mês", "meses"},
+			FrequencyYearly:  {"todo ano", "anos"},
+		},
+		everyN:      "a cada %d %s",
+		times:       ", %d vezes",
+		repeatUntil: ", até %s",
+	},
+	LocaleEnglish: {
+		today:        "today",
+		tomorrow:     "tomorrow",
+		yesterday:    "yesterday",
+		weekdays:     [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		date:         "Jan 2",
+		dateWithYear: "Jan 2, 2006",
+		clock:        englishClock,
+		at: func(t time.Time) string {
+			return "at " + englishTime(t)
+		},
+		from: func(t time.Time) string {
+			return "from " + englishTime(t)
+		},
+		to: func(t time.Time) string {
+			return "to " + englishTime(t)
+		},
+		allDay:     "%s (all day)",
+		allDays:    "from %s to %s (all day)",
+		until:      "%s until %s",
+		stamp:      "Jan 2, 2006 3:04pm",
+		stampClock: "3:04pm",
+		dateRange:  "%s to %s",
+		minute:     [2]string{"minute", "minutes"},
+		hour:       [2]string{"hour", "hours"},
+		day:        [2]string{"day", "days"},
+		and:        "and",
+		every: map[RecurrenceFrequency][2]string{
+			FrequencyDaily:   {"every day", "days"},
+			FrequencyWeekly:  {"every week", "weeks"},
+			FrequencyMonthly: {"every month", "months"},
+			FrequencyYearly:  {"every year", "years"},
+		},
+		everyN:      "every %d %s",
+		times:       ", %d times",
+		repeatUntil: ", until %s",
+	},
+	LocaleSpanish: {
+		today:        "hoy",
+		tomorrow:     "mañana",
+		yesterday:    "ayer",
+		weekdays:     [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		date:         "02/01",
+		dateWithYear: "02/01/2006",
+		clock:        spanishClock,
+		at: func(t time.Time) string {
+			return contractedTime(t, spanishClock, "a medianoche", "al mediodía", "a la ", "a las ")
+		},
+		from: func(t time.Time) string {
+			return contractedTime(t, spanishClock, "de medianoche", "del mediodía", "de la ", "de las ")
+		},
+		to: func(t time.Time) string {
+			return contractedTime(t, spanishClock, "a medianoche", "al mediodía", "a la ", "a las ")
+		},
+		allDay:     "%s (todo el día)",
+		allDays:    "de %s a %s (todo el día)",
+		until:      "%s hasta %s",
+		stamp:      "02/01/2006 15:04",
+		stampClock: "15:04",
+		dateRange:  "%s a %s",
+		minute:     [2]string{"minuto", "minutos"},
+		hour:       [2]string{"hora", "horas"},
+		day:        [2]string{"día", "días"},
+		and:        "y",
+		every: map[RecurrenceFrequency][2]string{
+			FrequencyDaily:   {"todos los días", "días"},
+			FrequencyWeekly:  {"todas las semanas", "semanas"},
+			FrequencyMonthly: {"todos los meses", "meses"},
+			FrequencyYearly:  {"todos los años", "años"},
+		},
+		everyN:      "cada %d %s",
+		times:       ", %d veces",
+		repeatUntil: ", hasta %s",
+	},
+}
+
+// wordsFor returns the words of locale, or of the default one.
+func wordsFor(locale Locale) *timeWords {
+	return localeTimeWords[locale.OrDefault()]
+}
+
+// TimeRenderer phrases instants for a user: in their timezone and language
+// and relative to now, e.g. "hoje às 9h", "tomorrow at 2:30pm" or "viernes,
+// 14/03 a las 8:00". It is shared by every message that tells the user when
+// something happens.
+type TimeRenderer struct {
+	now   time.Time
+	loc   *time.Location
+	words *timeWords
+}
+
+// NewTimeRenderer renders relative to now in loc, falling back to UTC, and
+// in locale, falling back to the default one.
+func NewTimeRenderer(now time.Time, loc *time.Location, locale Locale) TimeRenderer {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return TimeRenderer{now: now.In(loc), loc: loc, words: wordsFor(locale)}
+}
+
+// Day names the local day of t: "hoje", "amanhã", "ontem", the weekday and
+// date within the coming week, or the date, with the year only when it is
+// not the current one.
+func (r TimeRenderer) Day(t time.Time) string {
+	t = t.In(r.loc)
+	switch days := calendarDaysBetween(r.now, t); {
+	case days == 0:
+		return r.words.today
+	case days == 1:
+		return r.words.tomorrow
+	case days == -1:
+		return r.words.yesterday
+	case days > 1 && days < 7:
+		return r.Weekday(t)
+	default:
+		return r.Date(t)
+	}
+}
+
+// Weekday names the local weekday and date of t, e.g. "sexta, 14/03".
+func (r TimeRenderer) Weekday(t time.Time) string {
+	t = t.In(r.loc)
+	return fmt.Sprintf("%s, %s", r.words.weekdays[t.Weekday()], t.Format(r.words.date))
+}
+
+// Date renders the local date of t, with the year only when it is not the
+// current one, e.g. "14/03".
+func (r TimeRenderer) Date(t time.Time) string {
+	t = t.In(r.loc)
+	if t.Year() == r.now.Year() {
+		return t.Format(r.words.date)
+	}
+	return t.Format(r.words.dateWithYear)
+}
+
+// Clock renders the local time of day of t, e.g. "9h", "14h30".
+func (r TimeRenderer) Clock(t time.Time) string {
+	return r.words.clock(t.In(r.loc))
+}
+
+// At renders the day and time of t, e.g. "amanhã às 14h".
+func (r TimeRenderer) At(t time.Time) string {
+	return fmt.Sprintf("%s %s", r.Day(t), r.words.at(t.In(r.loc)))
+}
+
+// Event renders when the event's current occurrence happens, e.g. "hoje às
+// 9h", "amanhã das 14h às 16h", "sexta, 14/03 (dia inteiro)" or "hoje às 22h
+// até amanhã às 2h".
+func (r TimeRenderer) Event(e *Event) string {
+	start := e.OccurrenceStartsAt().In(r.loc)
+	end := e.OccurrenceEndsAt()
+
+	if e.AllDay {
+		if end != nil {
+			last := end.In(r.loc).AddDate(0, 0, -1)
+			if last.After(start) {
+				return fmt.Sprintf(r.words.allDays, r.Day(start), r.Day(last))
+			}
+		}
+		return fmt.Sprintf(r.words.allDay, r.Day(start))
+	}
+
+	if end == nil {
+		return r.At(start)
+	}
+
+	localEnd := end.In(r.loc)
+	if calendarDaysBetween(start, localEnd) == 0 {
+		return fmt.Sprintf("%s %s %s", r.Day(start), r.words.from(start), r.words.to(localEnd))
+	}
+	return fmt.Sprintf(r.words.until, r.At(start), r.At(localEnd))
+}
+
+// Duration renders a number of minutes in the largest whole unit, e.g.
+// "1 dia", "2 horas" or "15 minutos".
+func (r TimeRenderer) Duration(minutes int) string {
+	unit, n := r.words.minute, minutes
+	switch {
+	case minutes > 0 && minutes%1440 == 0:
+		unit, n = r.words.day, minutes/1440
+	case minutes > 0 && minutes%60 == 0:
+		unit, n = r.words.hour, minutes/60
+	}
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, unit[0])
+	}
+	return fmt.Sprintf("%d %s", n, unit[1])
+}
+
+// Durations renders a reminder schedule, e.g. "1 dia, 2 horas e 15 minutos".
+func (r TimeRenderer) Durations(offsets ReminderSchedule) string {
+	parts := make([]string, len(offsets))
+	for i, offset := range offsets {
+		parts[i] = r.Duration(offset)
+	}
+
+	if len(parts) < 2 {
+		return strings.Join(parts, "")
+	}
+	return fmt.Sprintf("%s %s %s", strings.Join(parts[:len(parts)-1], ", "), r.words.and, parts[len(parts)-1])
+}
+
+// Recurrence describes how often a rule repeats, e.g. "toda semana" or "a
+// cada 2 meses, 10 vezes".
+func (r TimeRenderer) Recurrence(rule *RecurrenceRule) string {
+	every := r.words.every[rule.Frequency]
+	description := every[0]
+	if rule.Interval > 1 {
+		description = fmt.Sprintf(r.words.everyN, rule.Interval, every[1])
+	}
+
+	if rule.Count > 0 {
+		description += fmt.Sprintf(r.words.times, rule.Count)
+	}
+	if rule.Until != nil {
+		description += fmt.Sprintf(r.words.repeatUntil, rule.Until.In(r.loc).Format(r.words.dateWithYear))
+	}
+
+	return description
+}
+
+// portugueseClock renders a time of day as in "9h" or "14h30".
+func portugueseClock(t time.Time) string {
+	if t.Minute() == 0 {
+		return fmt.Sprintf("%dh", t.Hour())
+	}
+	return fmt.Sprintf("%dh%02d", t.Hour(), t.Minute())
+}
+
+// englishClock renders a time of day as in "9am" or "2:30pm".
+func englishClock(t time.Time) string {
+	hour, suffix := t.Hour()%12, "am"
+	if t.Hour() >= 12 {
+		suffix = "pm"
+	}
+	if hour == 0 {
+		hour = 12
+	}
+	if t.Minute() == 0 {
+		return fmt.Sprintf("%d%s", hour, suffix)
+	}
+	return fmt.Sprintf("%d:%02d%s", hour, t.Minute(), suffix)
+}
+
+// englishTime names midnight and noon, and otherwise renders the clock.
+func englishTime(t time.Time) string {
+	switch {
+	case t.Hour() == 0 && t.Minute() == 0:
+		return "midnight"
+	case t.Hour() == 12 && t.Minute() == 0:
+		return "noon"
+	default:
+		return englishClock(t)
+	}
+}
+
+// spanishClock renders a time of day as in "9:00" or "14:30".
+func spanishClock(t time.Time) string {
+	return fmt.Sprintf("%d:%02d", t.Hour(), t.Minute())
+}
+
+// contractedTime prefixes the time of day of t with the contraction of a
+// preposition and its article, as in "às 14h" or "de las 14:00", which is
+// singular for one o'clock and names midnight and noon.
+func contractedTime(t time.Time, clock func(time.Time) string, midnight, noon, singular, plural string) string {
+	switch {
+	case t.Hour() == 0 && t.Minute() == 0:
+		return midnight
+	case t.Hour() == 12 && t.Minute() == 0:
+		return noon
+	case t.Hour() == 1:
+		return singular + clock(t)
+	default:
+		return plural + clock(t)
+	}
+}
+
+// calendarDaysBetween counts the days between the local dates of a and b,
+// which is not the elapsed time divided by 24h across DST transitions.
+func calendarDaysBetween(a, b time.Time) int {
+	dateA := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	dateB := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(dateB.Sub(dateA).Hours() / 24)
+}
@@ -0,0 +1,238 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeRenderer_At(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		loc  *time.Location
+		now  time.Time
+		at   time.Time
+		want string
+	}{
+		{
+			name: "stored in UTC, rendered in the user's zone",
+			loc:  saoPaulo,
+			now:  time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC),
+			at:   time.Date(2025, 3, 11, 17, 0, 0, 0, time.UTC),
+			want: "amanhã às 14h",
+		},
+		{
+			name: "late evening UTC is still today locally",
+			loc:  saoPaulo,
+			now:  time.Date(2025, 3, 10, 11, 0, 0, 0, time.UTC),
+			at:   time.Date(2025, 3, 11, 1, 30, 0, 0, time.UTC),
+			want: "hoje às 22h30",
+		},
+		{
+			name: "minutes and the singular hour",
+			loc:  saoPaulo,
+			now:  time.Date(2025, 3, 10, 0, 0, 0, 0, saoPaulo),
+			at:   time.Date(2025, 3, 10, 1, 5, 0, 0, saoPaulo),
+			want: "hoje à 1h05",
+		},
+		{
+			name: "midnight",
+			loc:  saoPaulo,
+			now:  time.Date(2025, 3, 10, 9, 0, 0, 0, saoPaulo),
+			at:   time.Date(2025, 3, 11, 0, 0, 0, 0, saoPaulo),
+			want: "amanhã à meia-noite",
+		},
+		{
+			name: "yesterday",
+			loc:  saoPaulo,
+			now:  time.Date(2025, 3, 10, 9, 0, 0, 0, saoPaulo),
+			at:   time.Date(2025, 3, 9, 12, 0, 0, 0, saoPaulo),
+			want: "ontem ao meio-dia",
+		},
+		{
+			name: "later this week",
+			loc:  saoPaulo,
+			now:  time.Date(2025, 3, 10, 9, 0, 0, 0, saoPaulo),
+			at:   time.Date(2025, 3, 14, 8, 0, 0, 0, saoPaulo),
+			want: "sexta, 14/03 às 8h",
+		},
+		{
+			name: "further ahead",
+			loc:  saoPaulo,
+			now:  time.Date(2025, 3, 10, 9, 0, 0, 0, saoPaulo),
+			at:   time.Date(2025, 3, 17, 8, 0, 0, 0, saoPaulo),
+			want: "17/03 às 8h",
+		},
+		{
+			name: "next year",
+			loc:  saoPaulo,
+			now:  time.Date(2025, 12, 20, 9, 0, 0, 0, saoPaulo),
+			at:   time.Date(2026, 1, 5, 8, 0, 0, 0, saoPaulo),
+			want: "05/01/2026 às 8h",
+		},
+		{
+			// Less than 24h pass before 00:15 on the day clocks spring
+			// forward, but it is still the next day.
+			name: "spring forward: short day",
+			loc:  newYork,
+			now:  time.Date(2025, 3, 9, 0, 30, 0, 0, newYork),
+			at:   time.Date(2025, 3, 10, 0, 15, 0, 0, newYork),
+			want: "amanhã às 0h15",
+		},
+		{
+			name: "spring forward: same wall clock the next day",
+			loc:  newYork,
+			now:  time.Date(2025, 3, 8, 14, 0, 0, 0, newYork),
+			at:   time.Date(2025, 3, 9, 14, 0, 0, 0, newYork),
+			want: "amanhã às 14h",
+		},
+		{
+			// More than 24h pass before 23:45 on the day clocks fall
+			// back, but it is still the next day.
+			name: "fall back: long day",
+			loc:  newYork,
+			now:  time.Date(2025, 11, 1, 23, 30, 0, 0, newYork),
+			at:   time.Date(2025, 11, 2, 23, 45, 0, 0, newYork),
+			want: "amanhã às 23h45",
+		},
+		{
+			name: "fall back: repeated hour",
+			loc:  newYork,
+			now:  time.Date(2025, 11, 2, 0, 30, 0, 0, newYork),
+			at:   time.Date(2025, 11, 2, 6, 30, 0, 0, time.UTC),
+			want: "hoje à 1h30",
+		},
+		{
+			name: "spring forward in Europe",
+			loc:  london,
+			now:  time.Date(2025, 3, 29, 23, 0, 0, 0, london),
+			at:   time.Date(2025, 3, 30, 9, 0, 0, 0, time.UTC),
+			want: "amanhã às 10h",
+		},
+		{
+			// Brazil kept DST until 2019: clocks jumped from 00:00 to
+			// 01:00 on 4 November 2018.
+			name: "historic Brazilian DST start",
+			loc:  saoPaulo,
+			now:  time.Date(2018, 11, 3, 23, 30, 0, 0, saoPaulo),
+			at:   time.Date(2018, 11, 4, 3, 0, 0, 0, time.UTC),
+			want: "amanhã à 1h",
+		},
+		{
+			name: "historic Brazilian DST end",
+			loc:  saoPaulo,
+			now:  time.Date(2019, 2, 16, 22, 0, 0, 0, saoPaulo),
+			at:   time.Date(2019, 2, 17, 2, 30, 0, 0, time.UTC),
+			want: "hoje às 23h30",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewTimeRenderer(tt.now, tt.loc, LocalePortuguese).At(tt.at))
+		})
+	}
+}
+
+func TestTimeRenderer_Event(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, loc)
+	rule := "FREQ=WEEKLY"
+	at := func(day, hour, minute int) *time.Time {
+		t := time.Date(2025, 3, day, hour, minute, 0, 0, loc)
+		return &t
+	}
+
+	tests := []struct {
+		name  string
+		event Event
+		want  string
+	}{
+		{name: "start only", event: Event{StartsAt: *at(10, 14, 0)}, want: "hoje às 14h"},
+		{name: "same day", event: Event{StartsAt: *at(11, 14, 0), EndsAt: at(11, 16, 30)}, want: "amanhã das 14h às 16h30"},
+		{name: "overnight", event: Event{StartsAt: *at(10, 22, 0), EndsAt: at(11, 2, 0)}, want: "hoje às 22h até amanhã às 2h"},
+		{name: "all day", event: Event{StartsAt: *at(11, 0, 0), EndsAt: at(12, 0, 0), AllDay: true}, want: "amanhã (dia inteiro)"},
+		{name: "several days", event: Event{StartsAt: *at(12, 0, 0), EndsAt: at(15, 0, 0), AllDay: true}, want: "de quarta, 12/03 a sexta, 14/03 (dia inteiro)"},
+		{name: "recurring occurrence", event: Event{StartsAt: *at(3, 8, 0), NextOccurrenceAt: at(17, 8, 0), RecurrenceRule: &rule}, want: "17/03 às 8h"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewTimeRenderer(now, loc, LocalePortuguese).Event(&tt.event))
+		})
+	}
+}
+
+func TestTimeRenderer_Locales(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, loc)
+	at := func(day, hour, minute int) *time.Time {
+		t := time.Date(2025, 3, day, hour, minute, 0, 0, loc)
+		return &t
+	}
+	until := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	rule := &RecurrenceRule{Frequency: FrequencyWeekly, Interval: 2, Until: &until}
+
+	tests := []struct {
+		locale     Locale
+		sameDay    string
+		overnight  string
+		later      string
+		allDays    string
+		durations  string
+		recurrence string
+	}{
+		{
+			locale:     LocalePortuguese,
+			sameDay:    "amanhã das 14h às 16h30",
+			overnight:  "hoje às 22h até amanhã à meia-noite",
+			later:      "sexta, 14/03 à 1h05",
+			allDays:    "de quarta, 12/03 a sexta, 14/03 (dia inteiro)",
+			durations:  "1 dia, 2 horas e 15 minutos",
+			recurrence: "a cada 2 semanas, até 30/06/2025",
+		},
+		{
+			locale:     LocaleEnglish,
+			sameDay:    "tomorrow from 2pm to 4:30pm",
+			overnight:  "today at 10pm until tomorrow at midnight",
+			later:      "Friday, Mar 14 at 1:05am",
+			allDays:    "from Wednesday, Mar 12 to Friday, Mar 14 (all day)",
+			durations:  "1 day, 2 hours and 15 minutes",
+			recurrence: "every 2 weeks, until Jun 30, 2025",
+		},
+		{
+			locale:     LocaleSpanish,
+			sameDay:    "mañana de las 14:00 a las 16:30",
+			overnight:  "hoy a las 22:00 hasta mañana a medianoche",
+			later:      "viernes, 14/03 a la 1:05",
+			allDays:    "de miércoles, 12/03 a viernes, 14/03 (todo el día)",
+			durations:  "1 día, 2 horas y 15 minutos",
+			recurrence: "cada 2 semanas, hasta 30/06/2025",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.locale), func(t *testing.T) {
+			when := NewTimeRenderer(now, loc, tt.locale)
+			assert.Equal(t, tt.sameDay, when.Event(&Event{StartsAt: *at(11, 14, 0), EndsAt: at(11, 16, 30)}))
+			assert.Equal(t, tt.overnight, when.Event(&Event{StartsAt: *at(10, 22, 0), EndsAt: at(11, 0, 0)}))
+			assert.Equal(t, tt.later, when.At(*at(14, 1, 5)))
+			assert.Equal(t, tt.allDays, when.Event(&Event{StartsAt: *at(12, 0, 0), EndsAt: at(15, 0, 0), AllDay: true}))
+			assert.Equal(t, tt.durations, when.Durations(ReminderSchedule{1440, 120, 15}))
+			assert.Equal(t, tt.recurrence, when.Recurrence(rule))
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package infra
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock is a TimeProvider that only moves when told to, for tests and
+// simulations. Sleep advances it instead of blocking.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) Sleep(duration time.Duration) {
+	c.Advance(duration)
+}
+
+// Advance moves the clock forward by duration.
+func (c *FakeClock) Advance(duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(duration)
+}
+
+// Set moves the clock to now.
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
@@ -9,10 +9,24 @@ import (
 
 type UserRepository interface {
 	GetByWANumber(ctx context.Context, waNumber string) (*domain.User, error)
+	// GetByIdentity finds the user with a verified identity on a channel.
+	GetByIdentity(ctx context.Context, channel domain.Channel, externalID string) (*domain.User, error)
 	GetByID(ctx context.Context, userID int) (*domain.User, error)
 	Create(ctx context.Context, user *domain.User) error
 	Update(ctx context.Context, user *domain.User) error
 	UpdateConfig(ctx context.Context, userID int, config *domain.UserConfig) error
+	ListWithDigests(ctx context.Context) ([]domain.User, error)
+	MarkDigestSent(ctx context.Context, userID int, kind domain.DigestKind, sentAt time.Time) error
+}
+
+type UserIdentityRepository interface {
+	Create(ctx context.Context, identity *domain.UserIdentity) error
+	GetByChannelID(ctx context.Context, channel domain.Channel, externalID string) (*domain.UserIdentity, error)
+	FindClaim(ctx context.Context, channel domain.Channel, externalID, code string) (*domain.UserIdentity, error)
+	ListByUserID(ctx context.Context, userID int) ([]domain.UserIdentity, error)
+	MarkVerified(ctx context.Context, id int) error
+	RecordInbound(ctx context.Context, id int, at time.Time) error
+	Delete(ctx context.Context, userID, id int) error
 }
 
 type WhitelistRepository interface {
@@ -25,12 +39,33 @@ type WhitelistRepository interface {
 type EventRepository interface {
 	Create(ctx context.Context, event *domain.Event) error
 	Update(ctx context.Context, event *domain.Event) error
+	ReplaceReminderOffsets(ctx context.Context, eventID int, offsets domain.ReminderSchedule) error
 	Delete(ctx context.Context, id int) error
 	GetByID(ctx context.Context, id int) (*domain.Event, error)
 	GetByUserID(ctx context.Context, userID int) ([]domain.Event, error)
+	ListActive(ctx context.Context, afterID, limit int) ([]domain.Event, error)
 	GetByUserIDAndDateRange(ctx context.Context, userID int, start, end time.Time) ([]domain.Event, error)
-	GetPendingReminders(ctx context.Context, reminderWindow time.Duration) ([]domain.EventWithUser, error)
+	ClaimPendingReminders(ctx context.Context, now time.Time, lease domain.Lease) ([]domain.EventWithUser, error)
 	FindByUserAndIdentifier(ctx context.Context, userID int, identifier *domain.EventIdentifier) ([]domain.Event, error)
+	ClaimRecurringEventsToAdvance(ctx context.Context, now time.Time, lease domain.Lease) ([]domain.EventWithUser, error)
+	ClaimEventsToClose(ctx context.Context, now time.Time, lease domain.Lease) ([]domain.EventWithUser, error)
+	ReleaseClaim(ctx context.Context, eventID int, owner string) error
+	GetLastNotifiedByUserID(ctx context.Context, userID int) (*domain.Event, error)
+	GetTentativeByUserID(ctx context.Context, userID int, now time.Time) (*domain.Event, error)
+}
+
+type EventExceptionRepository interface {
+	Upsert(ctx context.Context, exception *domain.EventException) error
+	ListByEventIDs(ctx context.Context, eventIDs []int) ([]domain.EventException, error)
+	DeleteFrom(ctx context.Context, eventID int, from time.Time) error
+}
+
+type EventParticipantRepository interface {
+	Create(ctx context.Context, participant *domain.EventParticipant) error
+	UpdateStatus(ctx context.Context, id int, status domain.ParticipantStatus) error
+	MarkInvited(ctx context.Context, id int, invitedAt time.Time) error
+	ListByEventID(ctx context.Context, eventID int) ([]domain.EventParticipant, error)
+	ListActiveInvitationsByNumber(ctx context.Context, waNumber string, now time.Time) ([]domain.EventParticipant, error)
 }
 
 type InboundMessageRepository interface {
@@ -38,6 +73,15 @@ type InboundMessageRepository interface {
 	Exists(ctx context.Context, providerMessageID string) (bool, error)
 }
 
+type OutboundMessageRepository interface {
+	Create(ctx context.Context, message *domain.OutboundMessage) error
+	Update(ctx context.Context, message *domain.OutboundMessage) error
+	GetByID(ctx context.Context, id int) (*domain.OutboundMessage, error)
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease domain.Lease) ([]domain.OutboundMessage, error)
+	ListByStatus(ctx context.Context, status domain.OutboundMessageStatus, limit, offset int) ([]domain.OutboundMessage, error)
+	CountByStatus(ctx context.Context, status domain.OutboundMessageStatus) (int, error)
+}
+
 type LLMConfigRepository interface {
 	GetDefaultModel(ctx context.Context) (*domain.LLMModel, error)
 	GetModelByProviderAndName(ctx context.Context, provider, model string) (*domain.LLMModel, error)
@@ -54,12 +98,35 @@ type UserAllowedContactRepository interface {
 	GetByUserAndNumber(ctx context.Context, userID int, contactNumber string) (*domain.UserAllowedContact, error)
 }
 
+type EventEscalationRepository interface {
+	Create(ctx context.Context, escalation *domain.EventEscalation) error
+	ListByEventID(ctx context.Context, eventID int) ([]domain.EventEscalation, error)
+	CancelOpen(ctx context.Context, eventID int, occurrenceStartsAt, canceledAt time.Time) ([]domain.EventEscalation, error)
+}
+
+// MessageTemplateRepository stores the template overrides edited without a
+// deploy, per locale. A nil user ID refers to the global overrides.
+type MessageTemplateRepository interface {
+	// Find returns the user's override of the named template in locale, or
+	// the global one when the user has none, or nil.
+	Find(ctx context.Context, userID int, locale domain.Locale, name string) (*domain.MessageTemplate, error)
+	List(ctx context.Context, userID *int) ([]domain.MessageTemplate, error)
+	Upsert(ctx context.Context, template *domain.MessageTemplate) error
+	Delete(ctx context.Context, userID *int, locale domain.Locale, name string) error
+}
+
 type Repositories interface {
 	User() UserRepository
+	UserIdentity() UserIdentityRepository
 	Whitelist() WhitelistRepository
 	Event() EventRepository
+	EventException() EventExceptionRepository
+	EventParticipant() EventParticipantRepository
 	InboundMessage() InboundMessageRepository
+	OutboundMessage() OutboundMessageRepository
 	LLMConfig() LLMConfigRepository
 	UserAllowedContact() UserAllowedContactRepository
+	EventEscalation() EventEscalationRepository
+	MessageTemplate() MessageTemplateRepository
 	WithTx(ctx context.Context, fn func(Repositories) error) error
 }
@@ -11,15 +11,66 @@ type LLMClient interface {
 	Chat(ctx context.Context, systemPrompt, userMessage string) (*domain.LLMResponse, error)
 }
 
+// LLMClientFactory returns the LLM client configured for a user.
+type LLMClientFactory interface {
+	ForUser(ctx context.Context, userID int) (LLMClient, error)
+}
+
 type WhatsAppSender interface {
 	SendText(ctx context.Context, to, text string) error
+	// SendInteractive sends text with options to answer it with a tap.
+	// Senders without interactive messages list the options in the text.
+	SendInteractive(ctx context.Context, to, text string, interactive domain.Interactive) error
+	// SendTemplate sends an approved WhatsApp template, which, unlike text,
+	// is delivered outside the 24-hour session window.
+	SendTemplate(ctx context.Context, to string, template domain.TemplateMessage) error
+}
+
+// ChannelSender delivers text on one messaging channel, to recipients
+// identified by their ID there. The outbox dispatcher picks one by the
+// channel of each message.
+type ChannelSender interface {
+	WhatsAppSender
+	Channel() domain.Channel
+}
+
+// MessageSender sends the agent's messages: SendText, SendInteractive and
+// SendTemplate reach other people, such as invitees and contacts, on WhatsApp, and
+// SendToUser reaches a user on their own channel.
+type MessageSender interface {
+	WhatsAppSender
+	SendToUser(ctx context.Context, user *domain.User, text string) error
 }
 
 type WhatsAppWebhookVerifier interface {
 	VerifySignature(payload []byte, signature string) bool
 }
 
+// WhatsAppSubscriptionVerifier is implemented by the verifiers of providers
+// that confirm the webhook subscription with a GET handshake.
+type WhatsAppSubscriptionVerifier interface {
+	VerifySubscription(mode, token string) bool
+}
+
+// MessageRenderer renders the messages sent to users from named templates,
+// in the user's language and applying the overrides set for the user.
+type MessageRenderer interface {
+	Render(ctx context.Context, user *domain.User, name string, data domain.MessageData) (string, error)
+	// Validate reports whether body can replace the named template in
+	// locale.
+	Validate(locale domain.Locale, name, body string) error
+}
+
 type TimeProvider interface {
 	Now() time.Time
 	Sleep(duration time.Duration)
 }
+
+// EventChangeFeed reports events created, updated or deleted by any instance,
+// so reminder schedules kept in memory can follow them.
+type EventChangeFeed interface {
+	// Subscribe delivers the IDs of changed events until ctx is done. A zero
+	// ID means changes may have been missed and everything should be
+	// reloaded.
+	Subscribe(ctx context.Context) (<-chan int, error)
+}
@@ -0,0 +1,374 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+// editScope decides which part of a recurring series an action targets. An
+// explicit scope wins; otherwise resolving to a single occurrence (via date
+// hint or occurrence start) means "just this one".
+func editScope(event *domain.Event, identifier *domain.EventIdentifier) domain.EditScope {
+	if !event.IsRecurring() {
+		return domain.EditScopeSeries
+	}
+	if identifier != nil && identifier.Scope != nil {
+		switch *identifier.Scope {
+		case domain.EditScopeOccurrence, domain.EditScopeFollowing, domain.EditScopeSeries:
+			return *identifier.Scope
+		}
+	}
+	if event.IsOccurrence() {
+		return domain.EditScopeOccurrence
+	}
+	return domain.EditScopeSeries
+}
+
+func (uc *EventUseCase) loadSeries(ctx context.Context, eventID int) (*domain.Event, error) {
+	event, err := uc.repos.Event().GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	if event == nil {
+		return nil, fmt.Errorf("event not found")
+	}
+
+	exceptions, err := uc.repos.EventException().ListByEventIDs(ctx, []int{eventID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event exceptions: %w", err)
+	}
+	event.Exceptions = exceptions
+
+	return event, nil
+}
+
+// resolveOccurrence returns the series row together with the occurrence the
+// target refers to. Targets that are the series itself resolve to the
+// current (next upcoming) occurrence.
+func (uc *EventUseCase) resolveOccurrence(ctx context.Context, userID int, target *domain.Event) (*domain.Event, domain.Event, *time.Location, error) {
+	user, err := uc.getUserByID(ctx, userID)
+	if err != nil {
+		return nil, domain.Event{}, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	loc := user.Location()
+
+	series, err := uc.loadSeries(ctx, target.ID)
+	if err != nil {
+		return nil, domain.Event{}, nil, err
+	}
+
+	if target.IsOccurrence() {
+		occurrence, ok := series.Occurrence(*target.OriginalStartsAt)
+		if !ok {
+			return nil, domain.Event{}, nil, fmt.Errorf("occurrence is already canceled")
+		}
+		return series, occurrence, loc, nil
+	}
+
+	occurrence, ok := series.NextOccurrence(uc.timeProvider.Now(), loc)
+	if !ok {
+		return nil, domain.Event{}, nil, fmt.Errorf("event has no upcoming occurrences")
+	}
+	return series, occurrence, loc, nil
+}
+
+func (uc *EventUseCase) cancelOccurrence(ctx context.Context, userID int, target *domain.Event) (*domain.Event, error) {
+	series, occurrence, loc, err := uc.resolveOccurrence(ctx, userID, target)
+	if err != nil {
+		return nil, err
+	}
+
+	exception := exceptionFor(series, *occurrence.OriginalStartsAt)
+	canceled := domain.EventStatusCanceled
+	exception.Status = &canceled
+
+	err = uc.repos.WithTx(ctx, func(repos ports.Repositories) error {
+		if err := repos.EventException().Upsert(ctx, exception); err != nil {
+			return err
+		}
+		setException(series, *exception)
+		return uc.refreshCurrentOccurrence(ctx, repos, series, loc)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel occurrence: %w", err)
+	}
+
+	occurrence.Status = domain.EventStatusCanceled
+	return &occurrence, nil
+}
+
+func (uc *EventUseCase) cancelFollowing(ctx context.Context, userID int, target *domain.Event) (*domain.Event, error) {
+	series, occurrence, loc, err := uc.resolveOccurrence(ctx, userID, target)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.repos.WithTx(ctx, func(repos ports.Repositories) error {
+		if !occurrence.OriginalStartsAt.After(series.StartsAt) {
+			series.Status = domain.EventStatusCanceled
+			return repos.Event().Update(ctx, series)
+		}
+		if err := truncateSeries(ctx, repos, series, *occurrence.OriginalStartsAt, loc); err != nil {
+			return err
+		}
+		return uc.refreshCurrentOccurrence(ctx, repos, series, loc)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel following occurrences: %w", err)
+	}
+
+	occurrence.Status = domain.EventStatusCanceled
+	return &occurrence, nil
+}
+
+func (uc *EventUseCase) updateOccurrence(ctx context.Context, userID int, target *domain.Event, entities *domain.EventEntities) (*domain.Event, error) {
+	series, occurrence, loc, err := uc.resolveOccurrence(ctx, userID, target)
+	if err != nil {
+		return nil, err
+	}
+
+	exception := exceptionFor(series, *occurrence.OriginalStartsAt)
+	if entities.StartsAt != nil {
+		exception.StartsAt = entities.StartsAt
+	}
+	if entities.Title != nil {
+		exception.Title = entities.Title
+	}
+	if entities.Location != nil {
+		exception.Location = entities.Location
+	}
+
+	if entities.StartsAt != nil && occurrence.IsActive() && !entities.Force {
+		moved := occurrence
+		moved.StartsAt = *entities.StartsAt
+		if occurrence.EndsAt != nil {
+			end := moved.StartsAt.Add(occurrence.Duration())
+			moved.EndsAt = &end
+		}
+		if err := uc.checkConflicts(ctx, &moved, loc); err != nil {
+			return nil, err
+		}
+	}
+
+	err = uc.repos.WithTx(ctx, func(repos ports.Repositories) error {
+		if err := repos.EventException().Upsert(ctx, exception); err != nil {
+			return err
+		}
+		setException(series, *exception)
+		return uc.refreshCurrentOccurrence(ctx, repos, series, loc)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update occurrence: %w", err)
+	}
+
+	updated, _ := series.Occurrence(*occurrence.OriginalStartsAt)
+	return &updated, nil
+}
+
+// updateFollowing splits the series: the original ends before the target
+// occurrence and a new series with the changes starts from it. Exceptions
+// from the split point on are discarded.
+func (uc *EventUseCase) updateFollowing(ctx context.Context, userID int, target *domain.Event, entities *domain.EventEntities) (*domain.Event, error) {
+	series, occurrence, loc, err := uc.resolveOccurrence(ctx, userID, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if !occurrence.OriginalStartsAt.After(series.StartsAt) {
+		scope := domain.EditScopeSeries
+		identifier := &domain.EventIdentifier{EventID: &series.ID, Scope: &scope}
+		seriesEntities := *entities
+		seriesEntities.Identifier = identifier
+		return uc.UpdateEvent(ctx, userID, &seriesEntities)
+	}
+
+	rule, err := series.Recurrence()
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurrence: %w", err)
+	}
+
+	following := &domain.Event{
+		UserID:                 series.UserID,
+		Title:                  series.Title,
+		Location:               series.Location,
+		StartsAt:               *occurrence.OriginalStartsAt,
+		AllDay:                 series.AllDay,
+		RemindBeforeMinutes:    series.RemindBeforeMinutes,
+		RemindFrequencyMinutes: series.RemindFrequencyMinutes,
+		RequireConfirmation:    series.RequireConfirmation,
+		MaxNotifications:       series.MaxNotifications,
+		ReminderOffsets:        series.ReminderOffsets,
+		IgnoreQuietHours:       series.IgnoreQuietHours,
+		Priority:               series.Priority,
+		EscalationPolicy:       series.EscalationPolicy,
+		Status:                 domain.EventStatusScheduled,
+	}
+
+	followingRule := *rule
+	if rule.Count > 0 {
+		before := len(rule.Between(series.StartsAt, series.StartsAt, occurrence.OriginalStartsAt.Add(-time.Second), loc))
+		followingRule.Count = rule.Count - before
+	}
+	recurrence := followingRule.String()
+	if entities.Recurrence != nil && *entities.Recurrence != "" {
+		recurrence = *entities.Recurrence
+	}
+
+	if entities.Title != nil {
+		following.Title = *entities.Title
+	}
+	if entities.StartsAt != nil {
+		following.StartsAt = *entities.StartsAt
+	}
+	if err := applyTimeSpan(following, entities, series.Duration(), loc); err != nil {
+		return nil, err
+	}
+	if entities.Location != nil {
+		following.Location = entities.Location
+	}
+	following.RemindBeforeMinutes = getIntOrDefault(entities.RemindBeforeMinutes, following.RemindBeforeMinutes)
+	following.RemindFrequencyMinutes = getIntOrDefault(entities.RemindFrequencyMinutes, following.RemindFrequencyMinutes)
+	following.RequireConfirmation = getBoolOrDefault(entities.RequireConfirmation, following.RequireConfirmation)
+	following.MaxNotifications = getIntOrDefault(entities.MaxNotifications, following.MaxNotifications)
+	following.IgnoreQuietHours = getBoolOrDefault(entities.IgnoreQuietHours, following.IgnoreQuietHours)
+	if entities.Priority != nil {
+		user, err := uc.getUserByID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if err := applyPriority(following, entities, user.DefaultRemindFrequencyMinutes); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := applyReminderSchedule(following, entities); err != nil {
+		return nil, err
+	}
+	if err := applyEscalationPolicy(following, entities); err != nil {
+		return nil, err
+	}
+
+	if err := applyRecurrence(following, recurrence, loc, uc.timeProvider.Now()); err != nil {
+		return nil, err
+	}
+
+	if timingChanged(entities) && !entities.Force {
+		// The rest of the original series is truncated, so it cannot conflict.
+		if err := uc.checkConflicts(ctx, following, loc, series.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	err = uc.repos.WithTx(ctx, func(repos ports.Repositories) error {
+		if err := truncateSeries(ctx, repos, series, *occurrence.OriginalStartsAt, loc); err != nil {
+			return err
+		}
+		if err := uc.refreshCurrentOccurrence(ctx, repos, series, loc); err != nil {
+			return err
+		}
+		return createEvent(ctx, repos, following)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update following occurrences: %w", err)
+	}
+
+	return following, nil
+}
+
+// confirmFutureOccurrence records a confirmation for an occurrence other
+// than the one currently being reminded. The current occurrence is
+// confirmed on the series row itself, so handled is false for it.
+func (uc *EventUseCase) confirmFutureOccurrence(ctx context.Context, userID int, target *domain.Event) (*domain.Event, bool, error) {
+	series, occurrence, _, err := uc.resolveOccurrence(ctx, userID, target)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if series.NextOccurrenceAt == nil || occurrence.StartsAt.Equal(*series.NextOccurrenceAt) {
+		return nil, false, nil
+	}
+
+	exception := exceptionFor(series, *occurrence.OriginalStartsAt)
+	confirmed := domain.EventStatusConfirmed
+	exception.Status = &confirmed
+
+	if err := uc.repos.EventException().Upsert(ctx, exception); err != nil {
+		return nil, true, fmt.Errorf("failed to confirm occurrence: %w", err)
+	}
+
+	occurrence.Status = domain.EventStatusConfirmed
+	return &occurrence, true, nil
+}
+
+// truncateSeries ends the series right before the given occurrence.
+func truncateSeries(ctx context.Context, repos ports.Repositories, series *domain.Event, original time.Time, loc *time.Location) error {
+	rule, err := series.Recurrence()
+	if err != nil {
+		return fmt.Errorf("invalid recurrence: %w", err)
+	}
+
+	until := original.Add(-time.Second).UTC()
+	if rule.Count > 0 {
+		rule.Count = len(rule.Between(series.StartsAt, series.StartsAt, until, loc))
+	} else {
+		rule.Until = &until
+	}
+	truncated := rule.String()
+	series.RecurrenceRule = &truncated
+
+	if err := repos.EventException().DeleteFrom(ctx, series.ID, original); err != nil {
+		return err
+	}
+
+	var kept []domain.EventException
+	for _, exception := range series.Exceptions {
+		if exception.OriginalStartsAt.Before(original) {
+			kept = append(kept, exception)
+		}
+	}
+	series.Exceptions = kept
+
+	return nil
+}
+
+// refreshCurrentOccurrence re-points the series at its next occurrence after
+// an exception may have canceled or moved the current one. Reminder state is
+// reset only when the current occurrence actually changes.
+func (uc *EventUseCase) refreshCurrentOccurrence(ctx context.Context, repos ports.Repositories, series *domain.Event, loc *time.Location) error {
+	next, ok := series.NextOccurrence(uc.timeProvider.Now(), loc)
+	switch {
+	case !ok:
+		series.Status = domain.EventStatusCompleted
+	case series.NextOccurrenceAt == nil || !next.StartsAt.Equal(*series.NextOccurrenceAt):
+		series.NextOccurrenceAt = &next.StartsAt
+		series.Status = next.Status
+		series.NotificationsSent = 0
+		series.LastNotifiedAt = nil
+		series.SnoozedUntil = nil
+		series.DeferredUntil = nil
+		series.EscalationLevel = 0
+	}
+
+	return repos.Event().Update(ctx, series)
+}
+
+func exceptionFor(series *domain.Event, original time.Time) *domain.EventException {
+	if existing := series.ExceptionFor(original); existing != nil {
+		exception := *existing
+		return &exception
+	}
+	return &domain.EventException{EventID: series.ID, OriginalStartsAt: original}
+}
+
+func setException(series *domain.Event, exception domain.EventException) {
+	for i := range series.Exceptions {
+		if series.Exceptions[i].OriginalStartsAt.Equal(exception.OriginalStartsAt) {
+			series.Exceptions[i] = exception
+			return
+		}
+	}
+	series.Exceptions = append(series.Exceptions, exception)
+}
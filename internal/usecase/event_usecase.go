@@ -9,15 +9,82 @@ import (
 	"github.com/alarm-agent/internal/ports"
 )
 
+// upcomingOccurrencesHorizon bounds how far ahead recurring series are
+// expanded when listing without an explicit date range.
+const upcomingOccurrencesHorizon = 30 * 24 * time.Hour
+
+// defaultSnoozeMinutes is used when a snooze request does not say for how long.
+const defaultSnoozeMinutes = 10
+
+// conflictLookback widens the conflict search backwards so that events which
+// started earlier but are still running are found.
+const conflictLookback = 24 * time.Hour
+
 type EventUseCase struct {
-	repos ports.Repositories
+	repos        ports.Repositories
+	participants *ParticipantUseCase
+	messages     ports.MessageRenderer
+	timeProvider ports.TimeProvider
 }
 
-func NewEventUseCase(repos ports.Repositories) *EventUseCase {
-	return &EventUseCase{repos: repos}
+func NewEventUseCase(repos ports.Repositories, participants *ParticipantUseCase, messages ports.MessageRenderer, timeProvider ports.TimeProvider) *EventUseCase {
+	return &EventUseCase{repos: repos, participants: participants, messages: messages, timeProvider: timeProvider}
 }
 
+// CreateEvent creates a scheduled event. Unless entities.Force is set, it
+// returns a *domain.ScheduleConflictError when the event overlaps other
+// scheduled or confirmed events of the user.
 func (uc *EventUseCase) CreateEvent(ctx context.Context, userID int, entities *domain.EventEntities) (*domain.Event, error) {
+	return uc.create(ctx, userID, entities, domain.EventStatusScheduled)
+}
+
+// HoldEvent creates the event as tentative, regardless of conflicts, so the
+// user can decide later whether to keep it. It gets no reminders and its
+// participants are not invited until then.
+func (uc *EventUseCase) HoldEvent(ctx context.Context, userID int, entities *domain.EventEntities) (*domain.Event, error) {
+	return uc.create(ctx, userID, entities, domain.EventStatusTentative)
+}
+
+// HeldEvent returns the user's pending tentative event, if any.
+func (uc *EventUseCase) HeldEvent(ctx context.Context, userID int) (*domain.Event, error) {
+	event, err := uc.repos.Event().GetTentativeByUserID(ctx, userID, uc.timeProvider.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tentative event: %w", err)
+	}
+	return event, nil
+}
+
+// ResolveHeldEvent schedules the user's pending tentative event when keep is
+// true and cancels it otherwise. It returns nil when nothing is held.
+func (uc *EventUseCase) ResolveHeldEvent(ctx context.Context, userID int, keep bool) (*domain.Event, error) {
+	event, err := uc.HeldEvent(ctx, userID)
+	if err != nil || event == nil {
+		return nil, err
+	}
+
+	event.Status = domain.EventStatusCanceled
+	if keep {
+		event.Status = domain.EventStatusScheduled
+	}
+
+	if err := uc.repos.Event().Update(ctx, event); err != nil {
+		return nil, fmt.Errorf("failed to update tentative event: %w", err)
+	}
+
+	if keep && uc.participants != nil {
+		user, err := uc.getUserByID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if event.Participants, err = uc.participants.InviteParticipants(ctx, user, event); err != nil {
+			return nil, fmt.Errorf("failed to invite participants: %w", err)
+		}
+	}
+
+	return event, nil
+}
+
+func (uc *EventUseCase) create(ctx context.Context, userID int, entities *domain.EventEntities, status domain.EventStatus) (*domain.Event, error) {
 	if entities.Title == nil || *entities.Title == "" {
 		return nil, fmt.Errorf("event title is required")
 	}
@@ -35,22 +102,64 @@ func (uc *EventUseCase) CreateEvent(ctx context.Context, userID int, entities *d
 		UserID:   userID,
 		Title:    *entities.Title,
 		StartsAt: *entities.StartsAt,
-		Status:   domain.EventStatusScheduled,
+		Status:   status,
 	}
 
 	if entities.Location != nil {
 		event.Location = entities.Location
 	}
 
+	if err := applyTimeSpan(event, entities, 0, user.Location()); err != nil {
+		return nil, err
+	}
+
+	if entities.Recurrence != nil && *entities.Recurrence != "" {
+		if err := applyRecurrence(event, *entities.Recurrence, user.Location(), uc.timeProvider.Now()); err != nil {
+			return nil, err
+		}
+	}
+
 	event.RemindBeforeMinutes = getIntOrDefault(entities.RemindBeforeMinutes, user.DefaultRemindBeforeMinutes)
 	event.RemindFrequencyMinutes = getIntOrDefault(entities.RemindFrequencyMinutes, user.DefaultRemindFrequencyMinutes)
 	event.RequireConfirmation = getBoolOrDefault(entities.RequireConfirmation, user.DefaultRequireConfirmation)
-	event.MaxNotifications = getIntOrDefault(entities.MaxNotifications, 3)
+	event.MaxNotifications = getIntOrDefault(entities.MaxNotifications, domain.PriorityNormal.MaxNotifications())
+	event.IgnoreQuietHours = getBoolOrDefault(entities.IgnoreQuietHours, false)
+	event.Priority = domain.PriorityNormal
+	if err := applyPriority(event, entities, user.DefaultRemindFrequencyMinutes); err != nil {
+		return nil, err
+	}
+
+	if entities.ReminderOffsets == nil && entities.RemindBeforeMinutes == nil && len(user.DefaultReminderOffsets) > 0 {
+		event.ReminderOffsets = user.DefaultReminderOffsets
+		event.RemindBeforeMinutes = user.DefaultReminderOffsets[0]
+	}
+	if _, err := applyReminderSchedule(event, entities); err != nil {
+		return nil, err
+	}
+	if err := applyEscalationPolicy(event, entities); err != nil {
+		return nil, err
+	}
 
-	if err := uc.repos.Event().Create(ctx, event); err != nil {
+	if status == domain.EventStatusScheduled && !entities.Force {
+		if err := uc.checkConflicts(ctx, event, user.Location()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.repos.WithTx(ctx, func(repos ports.Repositories) error {
+		return createEvent(ctx, repos, event)
+	}); err != nil {
 		return nil, fmt.Errorf("failed to create event: %w", err)
 	}
 
+	if len(entities.Participants) > 0 && uc.participants != nil {
+		participants, err := uc.participants.AddParticipants(ctx, user, event, entities.Participants)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add participants: %w", err)
+		}
+		event.Participants = participants
+	}
+
 	return event, nil
 }
 
@@ -59,27 +168,39 @@ func (uc *EventUseCase) UpdateEvent(ctx context.Context, userID int, entities *d
 		return nil, fmt.Errorf("event identifier is required for update")
 	}
 
-	events, err := uc.repos.Event().FindByUserAndIdentifier(ctx, userID, entities.Identifier)
+	event, err := uc.findEvent(ctx, userID, entities.Identifier)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find events: %w", err)
+		return nil, err
 	}
 
-	if len(events) == 0 {
-		return nil, fmt.Errorf("event not found")
+	switch editScope(event, entities.Identifier) {
+	case domain.EditScopeOccurrence:
+		return uc.updateOccurrence(ctx, userID, event, entities)
+	case domain.EditScopeFollowing:
+		return uc.updateFollowing(ctx, userID, event, entities)
 	}
 
-	if len(events) > 1 {
-		return nil, fmt.Errorf("multiple events found, please be more specific")
+	if event.IsOccurrence() {
+		if event, err = uc.loadSeries(ctx, event.ID); err != nil {
+			return nil, err
+		}
 	}
 
-	event := &events[0]
+	user, err := uc.getUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
 
+	previousDuration := event.Duration()
 	if entities.Title != nil {
 		event.Title = *entities.Title
 	}
 	if entities.StartsAt != nil {
 		event.StartsAt = *entities.StartsAt
 	}
+	if err := applyTimeSpan(event, entities, previousDuration, user.Location()); err != nil {
+		return nil, err
+	}
 	if entities.Location != nil {
 		event.Location = entities.Location
 	}
@@ -95,8 +216,52 @@ func (uc *EventUseCase) UpdateEvent(ctx context.Context, userID int, entities *d
 	if entities.MaxNotifications != nil {
 		event.MaxNotifications = *entities.MaxNotifications
 	}
+	if entities.IgnoreQuietHours != nil {
+		event.IgnoreQuietHours = *entities.IgnoreQuietHours
+	}
+	if err := applyPriority(event, entities, user.DefaultRemindFrequencyMinutes); err != nil {
+		return nil, err
+	}
+	scheduleChanged, err := applyReminderSchedule(event, entities)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyEscalationPolicy(event, entities); err != nil {
+		return nil, err
+	}
+	if entities.Recurrence != nil || ((entities.StartsAt != nil || entities.AllDay != nil) && event.IsRecurring()) {
+		rule := ""
+		if event.RecurrenceRule != nil {
+			rule = *event.RecurrenceRule
+		}
+		if entities.Recurrence != nil {
+			rule = *entities.Recurrence
+		}
 
-	if err := uc.repos.Event().Update(ctx, event); err != nil {
+		if rule == "" {
+			event.RecurrenceRule = nil
+			event.NextOccurrenceAt = nil
+		} else if err := applyRecurrence(event, rule, user.Location(), uc.timeProvider.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	if timingChanged(entities) && event.IsActive() && !entities.Force {
+		if err := uc.checkConflicts(ctx, event, user.Location()); err != nil {
+			return nil, err
+		}
+	}
+
+	err = uc.repos.WithTx(ctx, func(repos ports.Repositories) error {
+		if err := repos.Event().Update(ctx, event); err != nil {
+			return err
+		}
+		if scheduleChanged {
+			return repos.Event().ReplaceReminderOffsets(ctx, event.ID, event.ReminderOffsets)
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, fmt.Errorf("failed to update event: %w", err)
 	}
 
@@ -104,20 +269,24 @@ func (uc *EventUseCase) UpdateEvent(ctx context.Context, userID int, entities *d
 }
 
 func (uc *EventUseCase) CancelEvent(ctx context.Context, userID int, identifier *domain.EventIdentifier) (*domain.Event, error) {
-	events, err := uc.repos.Event().FindByUserAndIdentifier(ctx, userID, identifier)
+	event, err := uc.findEvent(ctx, userID, identifier)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find events: %w", err)
+		return nil, err
 	}
 
-	if len(events) == 0 {
-		return nil, fmt.Errorf("event not found")
+	switch editScope(event, identifier) {
+	case domain.EditScopeOccurrence:
+		return uc.cancelOccurrence(ctx, userID, event)
+	case domain.EditScopeFollowing:
+		return uc.cancelFollowing(ctx, userID, event)
 	}
 
-	if len(events) > 1 {
-		return nil, fmt.Errorf("multiple events found, please be more specific")
+	if event.IsOccurrence() {
+		if event, err = uc.loadSeries(ctx, event.ID); err != nil {
+			return nil, err
+		}
 	}
 
-	event := &events[0]
 	event.Status = domain.EventStatusCanceled
 
 	if err := uc.repos.Event().Update(ctx, event); err != nil {
@@ -128,8 +297,20 @@ func (uc *EventUseCase) CancelEvent(ctx context.Context, userID int, identifier
 }
 
 func (uc *EventUseCase) ListEvents(ctx context.Context, userID int, startDate, endDate *time.Time) ([]domain.Event, error) {
+	user, err := uc.getUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
 	if startDate != nil && endDate != nil {
-		return uc.repos.Event().GetByUserIDAndDateRange(ctx, userID, *startDate, *endDate)
+		events, err := uc.repos.Event().GetByUserIDAndDateRange(ctx, userID, *startDate, *endDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get events: %w", err)
+		}
+		if err := uc.attachExceptions(ctx, events); err != nil {
+			return nil, err
+		}
+		return domain.ExpandOccurrences(events, *startDate, *endDate, user.Location()), nil
 	}
 
 	events, err := uc.repos.Event().GetByUserID(ctx, userID)
@@ -137,10 +318,16 @@ func (uc *EventUseCase) ListEvents(ctx context.Context, userID int, startDate, e
 		return nil, fmt.Errorf("failed to get events: %w", err)
 	}
 
-	now := time.Now()
+	if err := uc.attachExceptions(ctx, events); err != nil {
+		return nil, err
+	}
+
+	now := uc.timeProvider.Now()
+	events = domain.ExpandOccurrences(events, now, now.Add(upcomingOccurrencesHorizon), user.Location())
+
 	var filteredEvents []domain.Event
 	for _, event := range events {
-		if event.Status != domain.EventStatusCanceled && event.StartsAt.After(now) {
+		if event.Status != domain.EventStatusCanceled && event.Status != domain.EventStatusTentative && event.IsOngoingOrUpcoming(now) {
 			filteredEvents = append(filteredEvents, event)
 		}
 	}
@@ -149,23 +336,33 @@ func (uc *EventUseCase) ListEvents(ctx context.Context, userID int, startDate, e
 }
 
 func (uc *EventUseCase) ConfirmEvent(ctx context.Context, userID int, identifier *domain.EventIdentifier) (*domain.Event, error) {
-	events, err := uc.repos.Event().FindByUserAndIdentifier(ctx, userID, identifier)
+	event, err := uc.findEvent(ctx, userID, identifier)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find events: %w", err)
+		return nil, err
 	}
 
-	if len(events) == 0 {
-		return nil, fmt.Errorf("event not found")
+	if editScope(event, identifier) == domain.EditScopeOccurrence {
+		occurrence, handled, err := uc.confirmFutureOccurrence(ctx, userID, event)
+		if err != nil || handled {
+			return occurrence, err
+		}
 	}
 
-	if len(events) > 1 {
-		return nil, fmt.Errorf("multiple events found, please be more specific")
+	if event.IsOccurrence() {
+		if event, err = uc.loadSeries(ctx, event.ID); err != nil {
+			return nil, err
+		}
 	}
 
-	event := &events[0]
 	if event.Status == domain.EventStatusScheduled {
 		event.Status = domain.EventStatusConfirmed
-		if err := uc.repos.Event().Update(ctx, event); err != nil {
+		err := uc.repos.WithTx(ctx, func(repos ports.Repositories) error {
+			if err := repos.Event().Update(ctx, event); err != nil {
+				return err
+			}
+			return uc.cancelEscalations(ctx, repos, event)
+		})
+		if err != nil {
 			return nil, fmt.Errorf("failed to confirm event: %w", err)
 		}
 	}
@@ -173,6 +370,122 @@ func (uc *EventUseCase) ConfirmEvent(ctx context.Context, userID int, identifier
 	return event, nil
 }
 
+// cancelEscalations closes the escalations of the confirmed occurrence and
+// lets the contacts that were told know the user answered after all.
+func (uc *EventUseCase) cancelEscalations(ctx context.Context, repos ports.Repositories, event *domain.Event) error {
+	if event.EscalationLevel == 0 {
+		return nil
+	}
+
+	now := uc.timeProvider.Now()
+	escalations, err := repos.EventEscalation().CancelOpen(ctx, event.ID, event.OccurrenceStartsAt(), now)
+	if err != nil {
+		return fmt.Errorf("failed to cancel escalations: %w", err)
+	}
+	if len(escalations) == 0 {
+		return nil
+	}
+
+	user, err := repos.User().GetByID(ctx, event.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return fmt.Errorf("user not found")
+	}
+
+	message, err := uc.messages.Render(ctx, user, domain.TemplateEscalationCanceled, domain.MessageData{
+		"User":  user,
+		"Event": event,
+		"When":  domain.NewTimeRenderer(now, user.Location(), user.Language()).Event(event),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render escalation cancellation: %w", err)
+	}
+	notified := make(map[string]bool)
+	for _, escalation := range escalations {
+		if notified[escalation.ContactNumber] {
+			continue
+		}
+		notified[escalation.ContactNumber] = true
+		if err := repos.OutboundMessage().Create(ctx, domain.NewOutboundMessage(escalation.ContactNumber, message, now)); err != nil {
+			return fmt.Errorf("failed to queue escalation cancellation: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListEscalations returns the escalation history of one of the user's events.
+func (uc *EventUseCase) ListEscalations(ctx context.Context, userID, eventID int) ([]domain.EventEscalation, error) {
+	event, err := uc.repos.Event().GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if event == nil || event.UserID != userID {
+		return nil, fmt.Errorf("event not found or access denied")
+	}
+
+	escalations, err := uc.repos.EventEscalation().ListByEventID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list escalations: %w", err)
+	}
+
+	return escalations, nil
+}
+
+// SnoozeEvent postpones the next reminder of an event until the requested
+// time. Without an identifier it targets the event the user was most
+// recently reminded about.
+func (uc *EventUseCase) SnoozeEvent(ctx context.Context, userID int, entities *domain.EventEntities) (*domain.Event, error) {
+	now := uc.timeProvider.Now()
+	until := now.Add(defaultSnoozeMinutes * time.Minute)
+	if entities.SnoozeUntil != nil {
+		until = *entities.SnoozeUntil
+	} else if entities.SnoozeMinutes != nil {
+		until = now.Add(time.Duration(*entities.SnoozeMinutes) * time.Minute)
+	}
+
+	if !until.After(now) {
+		return nil, fmt.Errorf("snooze time must be in the future")
+	}
+
+	var event *domain.Event
+	var err error
+	if entities.Identifier != nil {
+		event, err = uc.findEvent(ctx, userID, entities.Identifier)
+		if err != nil {
+			return nil, err
+		}
+		if event.IsOccurrence() {
+			if event, err = uc.loadSeries(ctx, event.ID); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		event, err = uc.repos.Event().GetLastNotifiedByUserID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get last notified event: %w", err)
+		}
+		if event == nil {
+			return nil, fmt.Errorf("no recent reminder to snooze")
+		}
+	}
+
+	if event.Status != domain.EventStatusScheduled && event.Status != domain.EventStatusConfirmed {
+		return nil, fmt.Errorf("event is not active")
+	}
+
+	event.SnoozedUntil = &until
+
+	if err := uc.repos.Event().Update(ctx, event); err != nil {
+		return nil, fmt.Errorf("failed to snooze event: %w", err)
+	}
+
+	return event, nil
+}
+
 func (uc *EventUseCase) GetEventByID(ctx context.Context, userID, eventID int) (*domain.Event, error) {
 	event, err := uc.repos.Event().GetByID(ctx, eventID)
 	if err != nil {
@@ -183,18 +496,254 @@ func (uc *EventUseCase) GetEventByID(ctx context.Context, userID, eventID int) (
 		return nil, fmt.Errorf("event not found or access denied")
 	}
 
+	participants, err := uc.repos.EventParticipant().ListByEventID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+	event.Participants = participants
+
 	return event, nil
 }
 
+func (uc *EventUseCase) attachExceptions(ctx context.Context, events []domain.Event) error {
+	var recurringIDs []int
+	for _, event := range events {
+		if event.IsRecurring() {
+			recurringIDs = append(recurringIDs, event.ID)
+		}
+	}
+	if len(recurringIDs) == 0 {
+		return nil
+	}
+
+	exceptions, err := uc.repos.EventException().ListByEventIDs(ctx, recurringIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get event exceptions: %w", err)
+	}
+
+	for i := range events {
+		for _, exception := range exceptions {
+			if exception.EventID == events[i].ID {
+				events[i].Exceptions = append(events[i].Exceptions, exception)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (uc *EventUseCase) findEvent(ctx context.Context, userID int, identifier *domain.EventIdentifier) (*domain.Event, error) {
+	if identifier == nil {
+		return nil, fmt.Errorf("event identifier is required")
+	}
+
+	events, err := uc.repos.Event().FindByUserAndIdentifier(ctx, userID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find events: %w", err)
+	}
+
+	if len(events) == 0 {
+		return nil, fmt.Errorf("event not found")
+	}
+
+	if len(events) > 1 {
+		return nil, fmt.Errorf("multiple events found, please be more specific")
+	}
+
+	return &events[0], nil
+}
+
+// checkConflicts returns a *domain.ScheduleConflictError when the event
+// overlaps other scheduled or confirmed events of its user. Recurring events
+// are checked over their upcoming occurrences.
+func (uc *EventUseCase) checkConflicts(ctx context.Context, event *domain.Event, loc *time.Location, ignore ...int) error {
+	candidates := []domain.Event{*event}
+	if event.IsRecurring() && !event.IsOccurrence() {
+		now := uc.timeProvider.Now()
+		candidates = event.OccurrencesBetween(now, now.Add(upcomingOccurrencesHorizon), loc)
+	}
+	if len(candidates) == 0 || event.AllDay {
+		return nil
+	}
+
+	from, to := candidates[0].StartsAt, candidates[0].StartsAt
+	for _, candidate := range candidates {
+		if candidate.StartsAt.Before(from) {
+			from = candidate.StartsAt
+		}
+		end := candidate.StartsAt
+		if candidate.EndsAt != nil {
+			end = *candidate.EndsAt
+		}
+		if end.After(to) {
+			to = end
+		}
+	}
+	from = from.Add(-conflictLookback)
+
+	existing, err := uc.repos.Event().GetByUserIDAndDateRange(ctx, event.UserID, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to get events: %w", err)
+	}
+	if err := uc.attachExceptions(ctx, existing); err != nil {
+		return err
+	}
+
+	conflicts := domain.FindConflicts(candidates, domain.ExpandOccurrences(existing, from, to, loc), ignore...)
+	if len(conflicts) > 0 {
+		return &domain.ScheduleConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// timingChanged reports whether an update moves or resizes the event.
+func timingChanged(entities *domain.EventEntities) bool {
+	return entities.StartsAt != nil || entities.EndsAt != nil || entities.DurationMinutes != nil ||
+		entities.AllDay != nil || entities.Recurrence != nil
+}
+
 func (uc *EventUseCase) getUserByID(ctx context.Context, userID int) (*domain.User, error) {
-	// This is a placeholder - we need to implement GetByID in UserRepository
-	// For now, we'll need to work around this limitation
-	return &domain.User{
-		ID:                            userID,
-		DefaultRemindBeforeMinutes:    30,
-		DefaultRemindFrequencyMinutes: 15,
-		DefaultRequireConfirmation:    true,
-	}, nil
+	user, err := uc.repos.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	return user, nil
+}
+
+// applyTimeSpan sets the event's end and all-day flag from the request.
+// Without a new end or duration, a moved event keeps its previous duration.
+// All-day events span whole days in the user's timezone, and an explicit
+// ends_at names their last day.
+func applyTimeSpan(event *domain.Event, entities *domain.EventEntities, previous time.Duration, loc *time.Location) error {
+	if entities.AllDay != nil {
+		event.AllDay = *entities.AllDay
+	}
+
+	switch {
+	case entities.EndsAt != nil:
+		end := *entities.EndsAt
+		event.EndsAt = &end
+	case entities.DurationMinutes != nil:
+		if *entities.DurationMinutes <= 0 {
+			return fmt.Errorf("event duration must be positive")
+		}
+		end := event.StartsAt.Add(time.Duration(*entities.DurationMinutes) * time.Minute)
+		event.EndsAt = &end
+	case previous > 0:
+		end := event.StartsAt.Add(previous)
+		event.EndsAt = &end
+	}
+
+	if event.AllDay {
+		last := event.StartsAt
+		if event.EndsAt != nil {
+			last = *event.EndsAt
+			if entities.EndsAt == nil {
+				// Derived ends are exclusive; step back into the last day.
+				last = last.Add(-time.Nanosecond)
+			}
+		}
+		start, end := domain.AllDayBounds(event.StartsAt, last, loc)
+		event.StartsAt, event.EndsAt = start, &end
+		return nil
+	}
+
+	if event.EndsAt != nil && !event.EndsAt.After(event.StartsAt) {
+		return fmt.Errorf("event end must be after its start")
+	}
+	return nil
+}
+
+// applyReminderSchedule sets the event's reminder schedule from the request
+// and reports whether it changed. An explicit remind_before_minutes without
+// offsets switches the event back to a single repeating reminder.
+func applyReminderSchedule(event *domain.Event, entities *domain.EventEntities) (bool, error) {
+	switch {
+	case entities.ReminderOffsets != nil:
+		schedule, err := domain.NewReminderSchedule(entities.ReminderOffsets)
+		if err != nil {
+			return false, err
+		}
+		event.ReminderOffsets = schedule
+		if len(schedule) > 0 {
+			event.RemindBeforeMinutes = schedule[0]
+		}
+		return true, nil
+	case entities.RemindBeforeMinutes != nil && event.HasReminderSchedule():
+		event.ReminderOffsets = nil
+		return true, nil
+	}
+	return false, nil
+}
+
+// applyEscalationPolicy sets the event's own escalation chain when the
+// request carries one.
+func applyEscalationPolicy(event *domain.Event, entities *domain.EventEntities) error {
+	if entities.EscalationPolicy == nil {
+		return nil
+	}
+	policy, err := domain.NewEscalationPolicy(*entities.EscalationPolicy)
+	if err != nil {
+		return fmt.Errorf("invalid escalation policy: %w", err)
+	}
+	event.EscalationPolicy = &policy
+	return nil
+}
+
+// createEvent inserts the event together with its reminder schedule.
+func createEvent(ctx context.Context, repos ports.Repositories, event *domain.Event) error {
+	if err := repos.Event().Create(ctx, event); err != nil {
+		return err
+	}
+	if event.HasReminderSchedule() {
+		return repos.Event().ReplaceReminderOffsets(ctx, event.ID, event.ReminderOffsets)
+	}
+	return nil
+}
+
+// applyPriority sets the event's priority and, unless they are given too, the
+// reminder frequency and number of reminders it implies.
+func applyPriority(event *domain.Event, entities *domain.EventEntities, defaultFrequency int) error {
+	if entities.Priority == nil {
+		return nil
+	}
+	priority, err := domain.ParsePriority(*entities.Priority)
+	if err != nil {
+		return err
+	}
+	event.Priority = priority
+	if entities.RemindFrequencyMinutes == nil {
+		event.RemindFrequencyMinutes = priority.RemindFrequencyMinutes(defaultFrequency)
+	}
+	if entities.MaxNotifications == nil {
+		event.MaxNotifications = priority.MaxNotifications()
+	}
+	return nil
+}
+
+// applyRecurrence validates and normalizes the rule and points the event at
+// its first occurrence that has not started yet.
+func applyRecurrence(event *domain.Event, value string, loc *time.Location, now time.Time) error {
+	rule, err := domain.ParseRecurrenceRule(value)
+	if err != nil {
+		return fmt.Errorf("invalid recurrence: %w", err)
+	}
+
+	normalized := rule.String()
+	event.RecurrenceRule = &normalized
+
+	next, ok := event.NextOccurrence(now, loc)
+	if !ok {
+		return fmt.Errorf("recurrence has no upcoming occurrences")
+	}
+	event.NextOccurrenceAt = &next.StartsAt
+
+	return nil
 }
 
 func getIntOrDefault(value *int, defaultValue int) int {
@@ -2,13 +2,17 @@ package usecase
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/alarm-agent/internal/adapters/templates"
 	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/infra"
 	"github.com/alarm-agent/internal/ports"
 )
 
@@ -24,6 +28,14 @@ func (m *MockUserRepository) GetByWANumber(ctx context.Context, waNumber string)
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetByIdentity(ctx context.Context, channel domain.Channel, externalID string) (*domain.User, error) {
+	args := m.Called(ctx, channel, externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
 func (m *MockUserRepository) GetByID(ctx context.Context, userID int) (*domain.User, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -47,6 +59,16 @@ func (m *MockUserRepository) UpdateConfig(ctx context.Context, userID int, confi
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) ListWithDigests(ctx context.Context) ([]domain.User, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) MarkDigestSent(ctx context.Context, userID int, kind domain.DigestKind, sentAt time.Time) error {
+	args := m.Called(ctx, userID, kind, sentAt)
+	return args.Error(0)
+}
+
 type MockEventRepository struct {
 	mock.Mock
 }
@@ -64,6 +86,11 @@ func (m *MockEventRepository) Update(ctx context.Context, event *domain.Event) e
 	return args.Error(0)
 }
 
+func (m *MockEventRepository) ReplaceReminderOffsets(ctx context.Context, eventID int, offsets domain.ReminderSchedule) error {
+	args := m.Called(ctx, eventID, offsets)
+	return args.Error(0)
+}
+
 func (m *MockEventRepository) Delete(ctx context.Context, id int) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -82,13 +109,18 @@ func (m *MockEventRepository) GetByUserID(ctx context.Context, userID int) ([]do
 	return args.Get(0).([]domain.Event), args.Error(1)
 }
 
+func (m *MockEventRepository) ListActive(ctx context.Context, afterID, limit int) ([]domain.Event, error) {
+	args := m.Called(ctx, afterID, limit)
+	return args.Get(0).([]domain.Event), args.Error(1)
+}
+
 func (m *MockEventRepository) GetByUserIDAndDateRange(ctx context.Context, userID int, start, end time.Time) ([]domain.Event, error) {
 	args := m.Called(ctx, userID, start, end)
 	return args.Get(0).([]domain.Event), args.Error(1)
 }
 
-func (m *MockEventRepository) GetPendingReminders(ctx context.Context, reminderWindow time.Duration) ([]domain.EventWithUser, error) {
-	args := m.Called(ctx, reminderWindow)
+func (m *MockEventRepository) ClaimPendingReminders(ctx context.Context, now time.Time, lease domain.Lease) ([]domain.EventWithUser, error) {
+	args := m.Called(ctx, now, lease)
 	return args.Get(0).([]domain.EventWithUser), args.Error(1)
 }
 
@@ -97,19 +129,143 @@ func (m *MockEventRepository) FindByUserAndIdentifier(ctx context.Context, userI
 	return args.Get(0).([]domain.Event), args.Error(1)
 }
 
+func (m *MockEventRepository) ClaimRecurringEventsToAdvance(ctx context.Context, now time.Time, lease domain.Lease) ([]domain.EventWithUser, error) {
+	args := m.Called(ctx, now, lease)
+	return args.Get(0).([]domain.EventWithUser), args.Error(1)
+}
+
+func (m *MockEventRepository) ClaimEventsToClose(ctx context.Context, now time.Time, lease domain.Lease) ([]domain.EventWithUser, error) {
+	args := m.Called(ctx, now, lease)
+	return args.Get(0).([]domain.EventWithUser), args.Error(1)
+}
+
+func (m *MockEventRepository) ReleaseClaim(ctx context.Context, eventID int, owner string) error {
+	args := m.Called(ctx, eventID, owner)
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) GetLastNotifiedByUserID(ctx context.Context, userID int) (*domain.Event, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Event), args.Error(1)
+}
+
+func (m *MockEventRepository) GetTentativeByUserID(ctx context.Context, userID int, now time.Time) (*domain.Event, error) {
+	args := m.Called(ctx, userID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Event), args.Error(1)
+}
+
+type MockEventExceptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockEventExceptionRepository) Upsert(ctx context.Context, exception *domain.EventException) error {
+	args := m.Called(ctx, exception)
+	return args.Error(0)
+}
+
+func (m *MockEventExceptionRepository) ListByEventIDs(ctx context.Context, eventIDs []int) ([]domain.EventException, error) {
+	args := m.Called(ctx, eventIDs)
+	return args.Get(0).([]domain.EventException), args.Error(1)
+}
+
+func (m *MockEventExceptionRepository) DeleteFrom(ctx context.Context, eventID int, from time.Time) error {
+	args := m.Called(ctx, eventID, from)
+	return args.Error(0)
+}
+
+type MockEventEscalationRepository struct {
+	mock.Mock
+}
+
+func (m *MockEventEscalationRepository) Create(ctx context.Context, escalation *domain.EventEscalation) error {
+	args := m.Called(ctx, escalation)
+	return args.Error(0)
+}
+
+func (m *MockEventEscalationRepository) ListByEventID(ctx context.Context, eventID int) ([]domain.EventEscalation, error) {
+	args := m.Called(ctx, eventID)
+	return args.Get(0).([]domain.EventEscalation), args.Error(1)
+}
+
+func (m *MockEventEscalationRepository) CancelOpen(ctx context.Context, eventID int, occurrenceStartsAt, canceledAt time.Time) ([]domain.EventEscalation, error) {
+	args := m.Called(ctx, eventID, occurrenceStartsAt, canceledAt)
+	return args.Get(0).([]domain.EventEscalation), args.Error(1)
+}
+
+type MockOutboundMessageRepository struct {
+	mock.Mock
+}
+
+func (m *MockOutboundMessageRepository) Create(ctx context.Context, message *domain.OutboundMessage) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+func (m *MockOutboundMessageRepository) Update(ctx context.Context, message *domain.OutboundMessage) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+func (m *MockOutboundMessageRepository) GetByID(ctx context.Context, id int) (*domain.OutboundMessage, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OutboundMessage), args.Error(1)
+}
+
+func (m *MockOutboundMessageRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease domain.Lease) ([]domain.OutboundMessage, error) {
+	args := m.Called(ctx, now, limit, lease)
+	return args.Get(0).([]domain.OutboundMessage), args.Error(1)
+}
+
+func (m *MockOutboundMessageRepository) ListByStatus(ctx context.Context, status domain.OutboundMessageStatus, limit, offset int) ([]domain.OutboundMessage, error) {
+	args := m.Called(ctx, status, limit, offset)
+	return args.Get(0).([]domain.OutboundMessage), args.Error(1)
+}
+
+func (m *MockOutboundMessageRepository) CountByStatus(ctx context.Context, status domain.OutboundMessageStatus) (int, error) {
+	args := m.Called(ctx, status)
+	return args.Int(0), args.Error(1)
+}
+
 type MockRepositories struct {
-	userRepo  *MockUserRepository
-	eventRepo *MockEventRepository
+	userRepo        *MockUserRepository
+	identityRepo    *MockUserIdentityRepository
+	eventRepo       *MockEventRepository
+	exceptionRepo   *MockEventExceptionRepository
+	participantRepo *MockEventParticipantRepository
+	contactRepo     *MockUserAllowedContactRepository
+	escalationRepo  *MockEventEscalationRepository
+	outboxRepo      *MockOutboundMessageRepository
 }
 
 func (m *MockRepositories) User() ports.UserRepository {
 	return m.userRepo
 }
 
+func (m *MockRepositories) UserIdentity() ports.UserIdentityRepository {
+	return m.identityRepo
+}
+
 func (m *MockRepositories) Event() ports.EventRepository {
 	return m.eventRepo
 }
 
+func (m *MockRepositories) EventException() ports.EventExceptionRepository {
+	return m.exceptionRepo
+}
+
+func (m *MockRepositories) EventParticipant() ports.EventParticipantRepository {
+	return m.participantRepo
+}
+
 func (m *MockRepositories) Whitelist() ports.WhitelistRepository {
 	return nil
 }
@@ -118,11 +274,23 @@ func (m *MockRepositories) InboundMessage() ports.InboundMessageRepository {
 	return nil
 }
 
+func (m *MockRepositories) OutboundMessage() ports.OutboundMessageRepository {
+	return m.outboxRepo
+}
+
 func (m *MockRepositories) LLMConfig() ports.LLMConfigRepository {
 	return nil
 }
 
 func (m *MockRepositories) UserAllowedContact() ports.UserAllowedContactRepository {
+	return m.contactRepo
+}
+
+func (m *MockRepositories) EventEscalation() ports.EventEscalationRepository {
+	return m.escalationRepo
+}
+
+func (m *MockRepositories) MessageTemplate() ports.MessageTemplateRepository {
 	return nil
 }
 
@@ -130,6 +298,13 @@ func (m *MockRepositories) WithTx(ctx context.Context, fn func(ports.Repositorie
 	return fn(m)
 }
 
+// defaultMessages renders the embedded message templates, without overrides.
+func defaultMessages(t *testing.T) ports.MessageRenderer {
+	messages, err := templates.NewRenderer(nil, "")
+	require.NoError(t, err)
+	return messages
+}
+
 func TestEventUseCase_CreateEvent(t *testing.T) {
 	ctx := context.Background()
 
@@ -138,7 +313,7 @@ func TestEventUseCase_CreateEvent(t *testing.T) {
 		eventRepo: &MockEventRepository{},
 	}
 
-	useCase := NewEventUseCase(mockRepos)
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
 
 	title := "Test Event"
 	startsAt := time.Now().Add(time.Hour)
@@ -156,8 +331,9 @@ func TestEventUseCase_CreateEvent(t *testing.T) {
 		DefaultRequireConfirmation:    true,
 	}
 
-	mockRepos.userRepo.On("GetByWANumber", ctx, mock.AnythingOfType("string")).Return(user, nil)
+	mockRepos.userRepo.On("GetByID", ctx, 1).Return(user, nil)
 	mockRepos.eventRepo.On("Create", ctx, mock.AnythingOfType("*domain.Event")).Return(nil)
+	mockRepos.eventRepo.On("GetByUserIDAndDateRange", ctx, 1, mock.Anything, mock.Anything).Return([]domain.Event{}, nil)
 
 	event, err := useCase.CreateEvent(ctx, 1, entities)
 
@@ -167,7 +343,402 @@ func TestEventUseCase_CreateEvent(t *testing.T) {
 	assert.Equal(t, startsAt, event.StartsAt)
 	assert.Equal(t, domain.EventStatusScheduled, event.Status)
 	assert.Equal(t, user.DefaultRemindBeforeMinutes, event.RemindBeforeMinutes)
+	assert.Nil(t, event.RecurrenceRule)
+
+	mockRepos.eventRepo.AssertExpectations(t)
+}
+
+func TestEventUseCase_CreateEvent_Recurring(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		userRepo:  &MockUserRepository{},
+		eventRepo: &MockEventRepository{},
+	}
+
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
+
+	title := "Standup"
+	startsAt := time.Now().Add(-72 * time.Hour)
+	recurrence := "rrule:freq=daily;interval=1"
+
+	entities := &domain.EventEntities{
+		Title:      &title,
+		StartsAt:   &startsAt,
+		Recurrence: &recurrence,
+	}
+
+	user := &domain.User{ID: 1, Timezone: "America/Sao_Paulo"}
+
+	mockRepos.userRepo.On("GetByID", ctx, 1).Return(user, nil)
+	mockRepos.eventRepo.On("Create", ctx, mock.AnythingOfType("*domain.Event")).Return(nil)
+	mockRepos.eventRepo.On("GetByUserIDAndDateRange", ctx, 1, mock.Anything, mock.Anything).Return([]domain.Event{}, nil)
+
+	event, err := useCase.CreateEvent(ctx, 1, entities)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "FREQ=DAILY", *event.RecurrenceRule)
+	assert.NotNil(t, event.NextOccurrenceAt)
+	assert.True(t, event.NextOccurrenceAt.After(time.Now()))
+	assert.True(t, event.NextOccurrenceAt.Before(time.Now().Add(24*time.Hour)))
+	assert.Equal(t, *event.NextOccurrenceAt, event.OccurrenceStartsAt())
+}
+
+func TestEventUseCase_CreateEvent_InvalidRecurrence(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		userRepo:  &MockUserRepository{},
+		eventRepo: &MockEventRepository{},
+	}
+
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
+
+	title := "Standup"
+	startsAt := time.Now().Add(time.Hour)
+	recurrence := "FREQ=HOURLY"
+
+	entities := &domain.EventEntities{
+		Title:      &title,
+		StartsAt:   &startsAt,
+		Recurrence: &recurrence,
+	}
+
+	mockRepos.userRepo.On("GetByID", ctx, 1).Return(&domain.User{ID: 1}, nil)
+
+	event, err := useCase.CreateEvent(ctx, 1, entities)
+
+	assert.Error(t, err)
+	assert.Nil(t, event)
+	assert.Contains(t, err.Error(), "invalid recurrence")
+	mockRepos.eventRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestEventUseCase_CreateEvent_DefaultReminderSchedule(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		userRepo:  &MockUserRepository{},
+		eventRepo: &MockEventRepository{},
+	}
+
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
+
+	title := "Consulta"
+	startsAt := time.Now().Add(48 * time.Hour)
+
+	entities := &domain.EventEntities{
+		Title:    &title,
+		StartsAt: &startsAt,
+	}
+
+	user := &domain.User{
+		ID:                         1,
+		DefaultRemindBeforeMinutes: 30,
+		DefaultReminderOffsets:     domain.ReminderSchedule{1440, 120, 15},
+	}
+
+	mockRepos.userRepo.On("GetByID", ctx, 1).Return(user, nil)
+	mockRepos.eventRepo.On("Create", ctx, mock.AnythingOfType("*domain.Event")).Return(nil)
+	mockRepos.eventRepo.On("GetByUserIDAndDateRange", ctx, 1, mock.Anything, mock.Anything).Return([]domain.Event{}, nil)
+	mockRepos.eventRepo.On("ReplaceReminderOffsets", ctx, 1, domain.ReminderSchedule{1440, 120, 15}).Return(nil)
+
+	event, err := useCase.CreateEvent(ctx, 1, entities)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReminderSchedule{1440, 120, 15}, event.ReminderOffsets)
+	assert.Equal(t, 1440, event.RemindBeforeMinutes)
+
+	mockRepos.eventRepo.AssertExpectations(t)
+}
+
+func TestEventUseCase_CreateEvent_ExplicitReminderOffsets(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		userRepo:  &MockUserRepository{},
+		eventRepo: &MockEventRepository{},
+	}
+
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
+
+	title := "Voo"
+	startsAt := time.Now().Add(72 * time.Hour)
+
+	entities := &domain.EventEntities{
+		Title:           &title,
+		StartsAt:        &startsAt,
+		ReminderOffsets: []int{15, 1440, 120, 15},
+	}
+
+	user := &domain.User{ID: 1, DefaultReminderOffsets: domain.ReminderSchedule{30}}
+
+	mockRepos.userRepo.On("GetByID", ctx, 1).Return(user, nil)
+	mockRepos.eventRepo.On("Create", ctx, mock.AnythingOfType("*domain.Event")).Return(nil)
+	mockRepos.eventRepo.On("GetByUserIDAndDateRange", ctx, 1, mock.Anything, mock.Anything).Return([]domain.Event{}, nil)
+	mockRepos.eventRepo.On("ReplaceReminderOffsets", ctx, 1, domain.ReminderSchedule{1440, 120, 15}).Return(nil)
+
+	event, err := useCase.CreateEvent(ctx, 1, entities)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReminderSchedule{1440, 120, 15}, event.ReminderOffsets, "offsets are deduplicated and sorted")
+
+	mockRepos.eventRepo.AssertExpectations(t)
+}
+
+func TestEventUseCase_CreateEvent_Priority(t *testing.T) {
+	user := &domain.User{ID: 1, DefaultRemindFrequencyMinutes: 15}
+	low, critical := "low", "critical"
+	maxNotifications := 2
+
+	tests := []struct {
+		name             string
+		priority         *string
+		maxNotifications *int
+		wantPriority     domain.Priority
+		wantFrequency    int
+		wantMax          int
+	}{
+		{name: "unset", wantPriority: domain.PriorityNormal, wantFrequency: 15, wantMax: 3},
+		{name: "low", priority: &low, wantPriority: domain.PriorityLow, wantFrequency: 15, wantMax: 1},
+		{name: "critical", priority: &critical, wantPriority: domain.PriorityCritical, wantFrequency: 5, wantMax: 10},
+		{name: "explicit values win", priority: &critical, maxNotifications: &maxNotifications, wantPriority: domain.PriorityCritical, wantFrequency: 5, wantMax: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockRepos := &MockRepositories{
+				userRepo:  &MockUserRepository{},
+				eventRepo: &MockEventRepository{},
+			}
+			useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
+
+			mockRepos.userRepo.On("GetByID", ctx, 1).Return(user, nil)
+			mockRepos.eventRepo.On("Create", ctx, mock.AnythingOfType("*domain.Event")).Return(nil)
+			mockRepos.eventRepo.On("GetByUserIDAndDateRange", ctx, 1, mock.Anything, mock.Anything).Return([]domain.Event{}, nil)
+
+			title := "Exame"
+			startsAt := time.Now().Add(24 * time.Hour)
+			event, err := useCase.CreateEvent(ctx, 1, &domain.EventEntities{
+				Title:            &title,
+				StartsAt:         &startsAt,
+				Priority:         tt.priority,
+				MaxNotifications: tt.maxNotifications,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPriority, event.Priority)
+			assert.Equal(t, tt.wantFrequency, event.RemindFrequencyMinutes)
+			assert.Equal(t, tt.wantMax, event.MaxNotifications)
+		})
+	}
+}
+
+func TestEventUseCase_CreateEvent_InvalidPriority(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		userRepo:  &MockUserRepository{},
+		eventRepo: &MockEventRepository{},
+	}
+
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
+
+	title := "Exame"
+	startsAt := time.Now().Add(time.Hour)
+	priority := "urgentissimo"
+
+	mockRepos.userRepo.On("GetByID", ctx, 1).Return(&domain.User{ID: 1}, nil)
+
+	event, err := useCase.CreateEvent(ctx, 1, &domain.EventEntities{Title: &title, StartsAt: &startsAt, Priority: &priority})
+
+	assert.Error(t, err)
+	assert.Nil(t, event)
+	assert.Contains(t, err.Error(), "invalid priority")
+	mockRepos.eventRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestEventUseCase_CreateEvent_AllDay(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		userRepo:  &MockUserRepository{},
+		eventRepo: &MockEventRepository{},
+	}
+
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
+
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	title := "Conferência"
+	startsAt := time.Now().AddDate(0, 0, 7).In(loc)
+	endsAt := startsAt.AddDate(0, 0, 2)
+	allDay := true
+
+	entities := &domain.EventEntities{
+		Title:    &title,
+		StartsAt: &startsAt,
+		EndsAt:   &endsAt,
+		AllDay:   &allDay,
+	}
+
+	user := &domain.User{ID: 1, Timezone: "America/Sao_Paulo"}
+
+	mockRepos.userRepo.On("GetByID", ctx, 1).Return(user, nil)
+	mockRepos.eventRepo.On("Create", ctx, mock.AnythingOfType("*domain.Event")).Return(nil)
+	mockRepos.eventRepo.On("GetByUserIDAndDateRange", ctx, 1, mock.Anything, mock.Anything).Return([]domain.Event{}, nil)
+
+	event, err := useCase.CreateEvent(ctx, 1, entities)
+
+	require.NoError(t, err)
+	assert.True(t, event.AllDay)
+	assert.Equal(t, domain.StartOfDay(startsAt, loc), event.StartsAt)
+	require.NotNil(t, event.EndsAt)
+	assert.Equal(t, domain.StartOfDay(startsAt, loc).AddDate(0, 0, 3), *event.EndsAt, "the last day is included")
+}
+
+func TestEventUseCase_CreateEvent_Duration(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		userRepo:  &MockUserRepository{},
+		eventRepo: &MockEventRepository{},
+	}
+
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
+
+	title := "Reunião"
+	startsAt := time.Now().Add(24 * time.Hour)
+	duration := 90
+
+	entities := &domain.EventEntities{
+		Title:           &title,
+		StartsAt:        &startsAt,
+		DurationMinutes: &duration,
+	}
+
+	mockRepos.userRepo.On("GetByID", ctx, 1).Return(&domain.User{ID: 1}, nil)
+	mockRepos.eventRepo.On("Create", ctx, mock.AnythingOfType("*domain.Event")).Return(nil)
+	mockRepos.eventRepo.On("GetByUserIDAndDateRange", ctx, 1, mock.Anything, mock.Anything).Return([]domain.Event{}, nil)
+
+	event, err := useCase.CreateEvent(ctx, 1, entities)
+
+	require.NoError(t, err)
+	require.NotNil(t, event.EndsAt)
+	assert.Equal(t, 90*time.Minute, event.Duration())
+}
+
+func TestEventUseCase_CreateEvent_EndBeforeStart(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		userRepo:  &MockUserRepository{},
+		eventRepo: &MockEventRepository{},
+	}
+
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
+
+	title := "Reunião"
+	startsAt := time.Now().Add(24 * time.Hour)
+	endsAt := startsAt.Add(-time.Hour)
+
+	entities := &domain.EventEntities{
+		Title:    &title,
+		StartsAt: &startsAt,
+		EndsAt:   &endsAt,
+	}
+
+	mockRepos.userRepo.On("GetByID", ctx, 1).Return(&domain.User{ID: 1}, nil)
+
+	event, err := useCase.CreateEvent(ctx, 1, entities)
+
+	assert.Error(t, err)
+	assert.Nil(t, event)
+	mockRepos.eventRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestEventUseCase_CreateEvent_Conflict(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		userRepo:  &MockUserRepository{},
+		eventRepo: &MockEventRepository{},
+	}
+
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
+
+	title := "Reunião"
+	startsAt := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	duration := 60
+
+	entities := &domain.EventEntities{
+		Title:           &title,
+		StartsAt:        &startsAt,
+		DurationMinutes: &duration,
+	}
+
+	existingEnd := startsAt.Add(30 * time.Minute)
+	existing := []domain.Event{
+		{ID: 7, UserID: 1, Title: "Dentista", StartsAt: startsAt.Add(-30 * time.Minute), EndsAt: &existingEnd, Status: domain.EventStatusScheduled},
+	}
+
+	mockRepos.userRepo.On("GetByID", ctx, 1).Return(&domain.User{ID: 1}, nil)
+	mockRepos.eventRepo.On("GetByUserIDAndDateRange", ctx, 1, mock.Anything, mock.Anything).Return(existing, nil)
+
+	event, err := useCase.CreateEvent(ctx, 1, entities)
+
+	var conflict *domain.ScheduleConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Nil(t, event)
+	require.Len(t, conflict.Conflicts, 1)
+	assert.Equal(t, "Dentista", conflict.Conflicts[0].Title)
+	mockRepos.eventRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+
+	entities.Force = true
+	mockRepos.eventRepo.On("Create", ctx, mock.AnythingOfType("*domain.Event")).Return(nil)
+
+	event, err = useCase.CreateEvent(ctx, 1, entities)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.EventStatusScheduled, event.Status)
+}
+
+func TestEventUseCase_HoldAndResolveEvent(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		userRepo:  &MockUserRepository{},
+		eventRepo: &MockEventRepository{},
+	}
+
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
+
+	title := "Reunião"
+	startsAt := time.Now().Add(24 * time.Hour)
+
+	entities := &domain.EventEntities{
+		Title:    &title,
+		StartsAt: &startsAt,
+	}
+
+	mockRepos.userRepo.On("GetByID", ctx, 1).Return(&domain.User{ID: 1}, nil)
+	mockRepos.eventRepo.On("Create", ctx, mock.AnythingOfType("*domain.Event")).Return(nil)
+
+	held, err := useCase.HoldEvent(ctx, 1, entities)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.EventStatusTentative, held.Status)
+	mockRepos.eventRepo.AssertNotCalled(t, "GetByUserIDAndDateRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	mockRepos.eventRepo.On("GetTentativeByUserID", ctx, 1, mock.AnythingOfType("time.Time")).Return(held, nil)
+	mockRepos.eventRepo.On("Update", ctx, held).Return(nil)
+
+	kept, err := useCase.ResolveHeldEvent(ctx, 1, true)
 
+	require.NoError(t, err)
+	assert.Equal(t, domain.EventStatusScheduled, kept.Status)
 	mockRepos.eventRepo.AssertExpectations(t)
 }
 
@@ -179,7 +750,7 @@ func TestEventUseCase_CreateEvent_MissingTitle(t *testing.T) {
 		eventRepo: &MockEventRepository{},
 	}
 
-	useCase := NewEventUseCase(mockRepos)
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
 
 	startsAt := time.Now().Add(time.Hour)
 
@@ -202,7 +773,7 @@ func TestEventUseCase_CreateEvent_MissingStartTime(t *testing.T) {
 		eventRepo: &MockEventRepository{},
 	}
 
-	useCase := NewEventUseCase(mockRepos)
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
 
 	title := "Test Event"
 
@@ -216,3 +787,280 @@ func TestEventUseCase_CreateEvent_MissingStartTime(t *testing.T) {
 	assert.Nil(t, event)
 	assert.Contains(t, err.Error(), "start time is required")
 }
+
+func TestEventUseCase_CancelEvent_SingleOccurrence(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		userRepo:      &MockUserRepository{},
+		eventRepo:     &MockEventRepository{},
+		exceptionRepo: &MockEventExceptionRepository{},
+	}
+
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
+
+	rule := "FREQ=WEEKLY"
+	dtstart := time.Now().UTC().Add(-7*24*time.Hour + time.Hour).Truncate(time.Minute)
+	current := dtstart.Add(7 * 24 * time.Hour)
+	target := dtstart.Add(14 * 24 * time.Hour)
+	series := &domain.Event{
+		ID:               7,
+		UserID:           1,
+		Title:            "Academia",
+		StartsAt:         dtstart,
+		RecurrenceRule:   &rule,
+		NextOccurrenceAt: &current,
+		Status:           domain.EventStatusScheduled,
+	}
+	occurrence, _ := series.Occurrence(target)
+
+	dateHint := target.Format("2006-01-02")
+	identifier := &domain.EventIdentifier{Title: &series.Title, DateHint: &dateHint}
+
+	mockRepos.userRepo.On("GetByID", ctx, 1).Return(&domain.User{ID: 1}, nil)
+	mockRepos.eventRepo.On("FindByUserAndIdentifier", ctx, 1, identifier).Return([]domain.Event{occurrence}, nil)
+	mockRepos.eventRepo.On("GetByID", ctx, 7).Return(series, nil)
+	mockRepos.eventRepo.On("Update", ctx, series).Return(nil)
+	mockRepos.exceptionRepo.On("ListByEventIDs", ctx, []int{7}).Return([]domain.EventException{}, nil)
+	mockRepos.exceptionRepo.On("Upsert", ctx, mock.MatchedBy(func(exception *domain.EventException) bool {
+		return exception.EventID == 7 && exception.OriginalStartsAt.Equal(target) && exception.IsCanceled()
+	})).Return(nil)
+
+	event, err := useCase.CancelEvent(ctx, 1, identifier)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.EventStatusCanceled, event.Status)
+	assert.True(t, event.StartsAt.Equal(target))
+	assert.Equal(t, domain.EventStatusScheduled, series.Status, "the series itself stays active")
+	assert.True(t, series.NextOccurrenceAt.Equal(current), "the current occurrence is untouched")
+
+	mockRepos.exceptionRepo.AssertExpectations(t)
+	mockRepos.eventRepo.AssertExpectations(t)
+}
+
+func TestEventUseCase_CancelEvent_CurrentOccurrenceResetsEscalation(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		userRepo:      &MockUserRepository{},
+		eventRepo:     &MockEventRepository{},
+		exceptionRepo: &MockEventExceptionRepository{},
+	}
+
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
+
+	rule := "FREQ=WEEKLY"
+	dtstart := time.Now().UTC().Add(-7*24*time.Hour + time.Hour).Truncate(time.Minute)
+	current := dtstart.Add(7 * 24 * time.Hour)
+	notified := current.Add(-30 * time.Minute)
+	series := &domain.Event{
+		ID:                7,
+		UserID:            1,
+		Title:             "Academia",
+		StartsAt:          dtstart,
+		RecurrenceRule:    &rule,
+		NextOccurrenceAt:  &current,
+		Status:            domain.EventStatusScheduled,
+		NotificationsSent: 3,
+		LastNotifiedAt:    &notified,
+		EscalationLevel:   2,
+	}
+	occurrence, _ := series.Occurrence(current)
+
+	dateHint := current.Format("2006-01-02")
+	identifier := &domain.EventIdentifier{Title: &series.Title, DateHint: &dateHint}
+
+	mockRepos.userRepo.On("GetByID", ctx, 1).Return(&domain.User{ID: 1}, nil)
+	mockRepos.eventRepo.On("FindByUserAndIdentifier", ctx, 1, identifier).Return([]domain.Event{occurrence}, nil)
+	mockRepos.eventRepo.On("GetByID", ctx, 7).Return(series, nil)
+	mockRepos.eventRepo.On("Update", ctx, series).Return(nil)
+	mockRepos.exceptionRepo.On("ListByEventIDs", ctx, []int{7}).Return([]domain.EventException{}, nil)
+	mockRepos.exceptionRepo.On("Upsert", ctx, mock.AnythingOfType("*domain.EventException")).Return(nil)
+
+	_, err := useCase.CancelEvent(ctx, 1, identifier)
+
+	require.NoError(t, err)
+	assert.True(t, series.NextOccurrenceAt.Equal(current.Add(7*24*time.Hour)), "the series moves on to the next occurrence")
+	assert.Equal(t, 0, series.NotificationsSent)
+	assert.Nil(t, series.LastNotifiedAt)
+	assert.Equal(t, 0, series.EscalationLevel, "the next occurrence starts at the top of the escalation chain")
+
+	mockRepos.eventRepo.AssertExpectations(t)
+}
+
+func TestEventUseCase_CancelEvent_SeriesCancelsLaterOccurrences(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		userRepo:      &MockUserRepository{},
+		eventRepo:     &MockEventRepository{},
+		exceptionRepo: &MockEventExceptionRepository{},
+	}
+
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
+
+	rule := "FREQ=WEEKLY"
+	dtstart := time.Now().UTC().Add(-7*24*time.Hour + time.Hour).Truncate(time.Minute)
+	current := dtstart.Add(7 * 24 * time.Hour)
+	series := &domain.Event{
+		ID:               7,
+		UserID:           1,
+		Title:            "Academia",
+		StartsAt:         dtstart,
+		RecurrenceRule:   &rule,
+		NextOccurrenceAt: &current,
+		Status:           domain.EventStatusScheduled,
+	}
+
+	scope := domain.EditScopeSeries
+	identifier := &domain.EventIdentifier{Title: &series.Title, Scope: &scope}
+
+	mockRepos.userRepo.On("GetByID", ctx, 1).Return(&domain.User{ID: 1}, nil)
+	mockRepos.eventRepo.On("FindByUserAndIdentifier", ctx, 1, identifier).Return([]domain.Event{*series}, nil)
+	mockRepos.eventRepo.On("Update", ctx, mock.AnythingOfType("*domain.Event")).Return(nil)
+
+	canceled, err := useCase.CancelEvent(ctx, 1, identifier)
+	require.NoError(t, err)
+	require.Equal(t, domain.EventStatusCanceled, canceled.Status)
+
+	from := current.Add(24 * time.Hour)
+	to := current.Add(5 * 7 * 24 * time.Hour)
+	mockRepos.eventRepo.On("GetByUserIDAndDateRange", ctx, 1, from, to).Return([]domain.Event{*canceled}, nil)
+	mockRepos.exceptionRepo.On("ListByEventIDs", ctx, []int{7}).Return([]domain.EventException{}, nil)
+
+	events, err := useCase.ListEvents(ctx, 1, &from, &to)
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+	for _, event := range events {
+		assert.Equal(t, domain.EventStatusCanceled, event.Status, "occurrence at %s", event.StartsAt)
+	}
+
+	candidate := domain.Event{StartsAt: events[0].StartsAt}
+	assert.Empty(t, domain.FindConflicts([]domain.Event{candidate}, events), "canceled occurrences do not conflict")
+}
+
+func TestEventUseCase_ConfirmEvent_CancelsEscalations(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		userRepo:       &MockUserRepository{},
+		eventRepo:      &MockEventRepository{},
+		escalationRepo: &MockEventEscalationRepository{},
+		outboxRepo:     &MockOutboundMessageRepository{},
+	}
+
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
+
+	name := "Maria"
+	user := &domain.User{ID: 1, WANumber: "+5511900000001", Name: &name, Timezone: "America/Sao_Paulo"}
+	event := domain.Event{
+		ID:                  5,
+		UserID:              1,
+		Title:               "Remédio",
+		StartsAt:            time.Now().Add(30 * time.Minute),
+		RequireConfirmation: true,
+		Status:              domain.EventStatusScheduled,
+		EscalationLevel:     2,
+	}
+	eventID := event.ID
+	identifier := &domain.EventIdentifier{EventID: &eventID}
+
+	// The same contact was told at two steps but hears back once.
+	escalations := []domain.EventEscalation{
+		{EventID: 5, Step: 1, ContactNumber: "+5511900000002", Status: domain.EscalationCanceled},
+		{EventID: 5, Step: 2, ContactNumber: "+5511900000002", Status: domain.EscalationCanceled},
+		{EventID: 5, Step: 2, ContactNumber: "+5511900000003", Status: domain.EscalationCanceled},
+	}
+
+	mockRepos.eventRepo.On("FindByUserAndIdentifier", ctx, 1, identifier).Return([]domain.Event{event}, nil)
+	mockRepos.eventRepo.On("Update", ctx, mock.AnythingOfType("*domain.Event")).Return(nil)
+	mockRepos.escalationRepo.On("CancelOpen", ctx, 5, event.StartsAt, mock.AnythingOfType("time.Time")).Return(escalations, nil)
+	mockRepos.userRepo.On("GetByID", ctx, 1).Return(user, nil)
+	mockRepos.outboxRepo.On("Create", ctx, mock.MatchedBy(func(message *domain.OutboundMessage) bool {
+		return strings.Contains(message.Body, "Maria confirmou o compromisso Remédio")
+	})).Return(nil).Twice()
+
+	confirmed, err := useCase.ConfirmEvent(ctx, 1, identifier)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.EventStatusConfirmed, confirmed.Status)
+	mockRepos.escalationRepo.AssertExpectations(t)
+	mockRepos.outboxRepo.AssertExpectations(t)
+}
+
+func TestEventUseCase_SnoozeEvent_LastNotified(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		userRepo:  &MockUserRepository{},
+		eventRepo: &MockEventRepository{},
+	}
+
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
+
+	lastNotifiedAt := time.Now().Add(-time.Minute)
+	event := &domain.Event{
+		ID:                5,
+		UserID:            1,
+		Title:             "Dentista",
+		StartsAt:          time.Now().Add(30 * time.Minute),
+		Status:            domain.EventStatusScheduled,
+		MaxNotifications:  3,
+		NotificationsSent: 1,
+		LastNotifiedAt:    &lastNotifiedAt,
+	}
+
+	minutes := 15
+	entities := &domain.EventEntities{SnoozeMinutes: &minutes}
+
+	mockRepos.eventRepo.On("GetLastNotifiedByUserID", ctx, 1).Return(event, nil)
+	mockRepos.eventRepo.On("Update", ctx, event).Return(nil)
+
+	before := time.Now()
+	snoozed, err := useCase.SnoozeEvent(ctx, 1, entities)
+
+	require.NoError(t, err)
+	require.NotNil(t, snoozed.SnoozedUntil)
+	assert.WithinDuration(t, before.Add(15*time.Minute), *snoozed.SnoozedUntil, time.Second)
+	assert.Equal(t, 1, snoozed.NotificationsSent, "snoozing does not consume notifications")
+
+	mockRepos.eventRepo.AssertExpectations(t)
+}
+
+func TestEventUseCase_SnoozeEvent_NoRecentReminder(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		userRepo:  &MockUserRepository{},
+		eventRepo: &MockEventRepository{},
+	}
+
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
+
+	mockRepos.eventRepo.On("GetLastNotifiedByUserID", ctx, 1).Return(nil, nil)
+
+	event, err := useCase.SnoozeEvent(ctx, 1, &domain.EventEntities{})
+
+	assert.Error(t, err)
+	assert.Nil(t, event)
+	assert.Contains(t, err.Error(), "no recent reminder")
+	mockRepos.eventRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestEventUseCase_SnoozeEvent_PastTime(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		userRepo:  &MockUserRepository{},
+		eventRepo: &MockEventRepository{},
+	}
+
+	useCase := NewEventUseCase(mockRepos, nil, defaultMessages(t), infra.NewRealTimeProvider())
+
+	until := time.Now().Add(-time.Minute)
+	event, err := useCase.SnoozeEvent(ctx, 1, &domain.EventEntities{SnoozeUntil: &until})
+
+	assert.Error(t, err)
+	assert.Nil(t, event)
+	assert.Contains(t, err.Error(), "must be in the future")
+}
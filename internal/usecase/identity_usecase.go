@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+type IdentityUseCase struct {
+	repos    ports.Repositories
+	sender   ports.MessageSender
+	messages ports.MessageRenderer
+}
+
+func NewIdentityUseCase(repos ports.Repositories, sender ports.MessageSender, messages ports.MessageRenderer) *IdentityUseCase {
+	return &IdentityUseCase{
+		repos:    repos,
+		sender:   sender,
+		messages: messages,
+	}
+}
+
+// LinkIdentity links an unverified identity to the user and sends it a
+// verification code. Until the code is sent back from the identity, its
+// messages keep reaching whoever they reached before.
+func (uc *IdentityUseCase) LinkIdentity(ctx context.Context, user *domain.User, channel domain.Channel, externalID string) (*domain.UserIdentity, error) {
+	code, err := domain.NewVerificationCode()
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &domain.UserIdentity{
+		UserID:           user.ID,
+		Channel:          channel,
+		ExternalID:       externalID,
+		VerificationCode: &code,
+	}
+
+	message, err := uc.messages.Render(ctx, user, domain.TemplateIdentityVerification, domain.MessageData{
+		"User": user,
+		"Code": code,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render verification: %w", err)
+	}
+
+	err = uc.repos.WithTx(ctx, func(repos ports.Repositories) error {
+		if err := repos.UserIdentity().Create(ctx, identity); err != nil {
+			return fmt.Errorf("failed to create identity: %w", err)
+		}
+		// Sent to the identity being linked rather than to where the user
+		// is reached.
+		recipient := *user
+		recipient.Via = identity
+		return uc.sender.SendToUser(ctx, &recipient, message)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return identity, nil
+}
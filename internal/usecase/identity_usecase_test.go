@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alarm-agent/internal/domain"
+)
+
+func TestIdentityUseCase_LinkIdentity(t *testing.T) {
+	ctx := context.Background()
+
+	identityRepo := &MockUserIdentityRepository{}
+	sender := &MockWhatsAppSender{}
+	uc := NewIdentityUseCase(&MockRepositories{identityRepo: identityRepo}, sender, defaultMessages(t))
+
+	name := "Ana"
+	user := &domain.User{ID: 3, Name: &name, WANumber: "+5511900000001"}
+	var code string
+	identityRepo.On("Create", ctx, mock.MatchedBy(func(identity *domain.UserIdentity) bool {
+		if identity.VerificationCode != nil {
+			code = *identity.VerificationCode
+		}
+		return identity.UserID == 3 && identity.Channel == domain.ChannelTelegram && identity.ExternalID == "42" && !identity.Verified
+	})).Return(nil)
+	sender.On("SendToUser", ctx, mock.MatchedBy(func(recipient *domain.User) bool {
+		channel, contact := recipient.Contact()
+		return channel == domain.ChannelTelegram && contact == "42"
+	}), mock.MatchedBy(func(text string) bool {
+		return strings.Contains(text, "Ana quer receber") && code != "" && strings.Contains(text, code)
+	})).Return(nil)
+
+	identity, err := uc.LinkIdentity(ctx, user, domain.ChannelTelegram, "42")
+	require.NoError(t, err)
+
+	assert.False(t, identity.Verified)
+	assert.True(t, domain.IsVerificationCode(code))
+	assert.Nil(t, user.Via, "the user is still reached where they were")
+	sender.AssertExpectations(t)
+}
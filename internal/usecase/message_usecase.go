@@ -3,40 +3,54 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/alarm-agent/internal/adapters/llm"
 	"github.com/alarm-agent/internal/adapters/whatsapp"
-	"github.com/alarm-agent/internal/config"
 	"github.com/alarm-agent/internal/domain"
 	"github.com/alarm-agent/internal/ports"
 )
 
 type MessageUseCase struct {
-	repos           ports.Repositories
-	whatsappSender  ports.WhatsAppSender
-	eventUseCase    *EventUseCase
-	defaultTimezone string
-	config          *config.Config
+	repos              ports.Repositories
+	sender             ports.MessageSender
+	messages           ports.MessageRenderer
+	eventUseCase       *EventUseCase
+	participantUseCase *ParticipantUseCase
+	defaultTimezone    string
+	llmClients         ports.LLMClientFactory
+	timeProvider       ports.TimeProvider
 }
 
 func NewMessageUseCase(
 	repos ports.Repositories,
-	whatsappSender ports.WhatsAppSender,
+	sender ports.MessageSender,
+	messages ports.MessageRenderer,
 	eventUseCase *EventUseCase,
+	participantUseCase *ParticipantUseCase,
 	defaultTimezone string,
-	config *config.Config,
+	llmClients ports.LLMClientFactory,
+	timeProvider ports.TimeProvider,
 ) *MessageUseCase {
 	return &MessageUseCase{
-		repos:           repos,
-		whatsappSender:  whatsappSender,
-		eventUseCase:    eventUseCase,
-		defaultTimezone: defaultTimezone,
-		config:          config,
+		repos:              repos,
+		sender:             sender,
+		messages:           messages,
+		eventUseCase:       eventUseCase,
+		participantUseCase: participantUseCase,
+		defaultTimezone:    defaultTimezone,
+		llmClients:         llmClients,
+		timeProvider:       timeProvider,
 	}
 }
 
+// when renders times for the user relative to the current time.
+func (uc *MessageUseCase) when(user *domain.User) domain.TimeRenderer {
+	return domain.NewTimeRenderer(uc.timeProvider.Now(), user.Location(), user.Language())
+}
+
 func (uc *MessageUseCase) ProcessInboundMessage(ctx context.Context, parsedMessage whatsapp.ParsedMessage) error {
 	exists, err := uc.repos.InboundMessage().Exists(ctx, parsedMessage.ID)
 	if err != nil {
@@ -57,12 +71,28 @@ func (uc *MessageUseCase) ProcessInboundMessage(ctx context.Context, parsedMessa
 		return fmt.Errorf("failed to create inbound message: %w", err)
 	}
 
+	// A code echoed back verifies an identity another user linked to the
+	// sender's ID, and only that moves the sender to their account.
+	claimed, err := uc.claimIdentity(ctx, parsedMessage)
+	if err != nil {
+		return fmt.Errorf("failed to claim identity: %w", err)
+	}
+	if claimed {
+		return nil
+	}
+
 	// Get or create user - this automatically creates users who send messages
-	user, err := uc.getOrCreateUser(ctx, parsedMessage.From, parsedMessage.ContactName)
+	user, err := uc.getOrCreateUser(ctx, parsedMessage.Channel.OrDefault(), parsedMessage.From, parsedMessage.ContactName)
 	if err != nil {
 		return fmt.Errorf("failed to get or create user: %w", err)
 	}
 
+	// Opens or extends the window in which WhatsApp delivers free-form
+	// messages to the user, such as reminders sent as text.
+	if err := uc.repos.UserIdentity().RecordInbound(ctx, user.Via.ID, uc.timeProvider.Now()); err != nil {
+		return fmt.Errorf("failed to record inbound message: %w", err)
+	}
+
 	// Check if user is active
 	if !user.IsActive {
 		return nil // Ignore messages from inactive users
@@ -72,35 +102,87 @@ func (uc *MessageUseCase) ProcessInboundMessage(ctx context.Context, parsedMessa
 }
 
 func (uc *MessageUseCase) processUserMessage(ctx context.Context, user *domain.User, parsedMessage whatsapp.ParsedMessage) error {
+	// A tapped option already says what to do with which event.
+	if action, eventID, ok := domain.ParseEventReplyID(parsedMessage.Payload); ok {
+		return uc.handleEventReply(ctx, user, action, eventID)
+	}
 
 	userPreferences := map[string]interface{}{
 		"timezone":                         user.Timezone,
 		"default_remind_before_minutes":    user.DefaultRemindBeforeMinutes,
 		"default_remind_frequency_minutes": user.DefaultRemindFrequencyMinutes,
 		"default_require_confirmation":     user.DefaultRequireConfirmation,
+		"default_reminder_offsets":         []int(user.DefaultReminderOffsets),
+		"daily_digest":                     user.DailyDigestEnabled,
+		"daily_digest_time":                user.DailyDigestTime,
+		"weekly_digest":                    user.WeeklyDigestEnabled,
+		"weekly_digest_time":               user.WeeklyDigestTime,
+	}
+
+	// Lets the LLM read "vou" or "não posso" as an answer to an invitation.
+	invitation, err := uc.participantUseCase.PendingInvitation(ctx, user.WANumber)
+	if err != nil {
+		return fmt.Errorf("failed to get pending invitation: %w", err)
+	}
+	if invitation != nil {
+		userPreferences["pending_invitation"] = invitation.Title
+	}
+
+	// Lets the LLM read "sim" or "não" as an answer to a conflict warning.
+	held, err := uc.eventUseCase.HeldEvent(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get held event: %w", err)
+	}
+	if held != nil {
+		userPreferences["pending_conflict"] = held.Title
 	}
 
 	// Get LLM client from user's database configuration
-	llmClient, err := llm.NewLLMClientFromDB(ctx, uc.repos.LLMConfig(), uc.config, user.ID)
+	llmClient, err := uc.llmClients.ForUser(ctx, user.ID)
 	if err != nil {
 		return fmt.Errorf("failed to create LLM client: %w", err)
 	}
 
-	systemPrompt := llm.BuildSystemPrompt(user.Timezone)
-	userMessage := llm.BuildUserMessage(parsedMessage.From, parsedMessage.Text, userPreferences)
+	systemPrompt := llm.BuildSystemPrompt(user.Language(), user.Timezone)
+	userMessage := llm.BuildUserMessage(user.Language(), parsedMessage.From, parsedMessage.Text, userPreferences)
 
 	llmResponse, err := llmClient.Chat(ctx, systemPrompt, userMessage)
 	if err != nil {
 		return fmt.Errorf("failed to get LLM response: %w", err)
 	}
 
+	if err := uc.detectLocale(ctx, user, llmResponse); err != nil {
+		return err
+	}
+
 	if llmResponse.FollowUpQuestion != nil {
-		return uc.sendWhatsAppMessage(ctx, parsedMessage.From, *llmResponse.FollowUpQuestion)
+		return uc.sender.SendToUser(ctx, user, *llmResponse.FollowUpQuestion)
 	}
 
 	return uc.handleLLMIntent(ctx, user, llmResponse)
 }
 
+// detectLocale sets the user's locale from the language the LLM recognized
+// in their message, until one is detected or set through the API, so the
+// reply already comes in their language.
+func (uc *MessageUseCase) detectLocale(ctx context.Context, user *domain.User, llmResponse *domain.LLMResponse) error {
+	if user.Locale != "" || llmResponse.Language == nil {
+		return nil
+	}
+	locale, err := domain.ParseLocale(*llmResponse.Language)
+	if err != nil {
+		return nil
+	}
+
+	config := user.Config()
+	config.Locale = locale
+	if err := uc.repos.User().UpdateConfig(ctx, user.ID, config); err != nil {
+		return fmt.Errorf("failed to update user locale: %w", err)
+	}
+	user.Locale = locale
+	return nil
+}
+
 func (uc *MessageUseCase) handleLLMIntent(ctx context.Context, user *domain.User, llmResponse *domain.LLMResponse) error {
 	switch llmResponse.Intent {
 	case domain.IntentCreateEvent:
@@ -115,143 +197,368 @@ func (uc *MessageUseCase) handleLLMIntent(ctx context.Context, user *domain.User
 		return uc.handleConfirmEvent(ctx, user, llmResponse)
 	case domain.IntentDeclineEvent:
 		return uc.handleDeclineEvent(ctx, user, llmResponse)
+	case domain.IntentSnooze:
+		return uc.handleSnoozeEvent(ctx, user, llmResponse)
+	case domain.IntentDigest:
+		return uc.handleConfigureDigest(ctx, user, llmResponse)
 	case domain.IntentSmallTalk:
-		return uc.sendWhatsAppMessage(ctx, user.WANumber, "Olá! Como posso ajudar com seus compromissos hoje?")
+		return uc.reply(ctx, user, domain.TemplateGreeting, nil)
 	default:
-		return uc.sendWhatsAppMessage(ctx, user.WANumber, "Desculpe, não consegui entender sua mensagem. Pode tentar novamente?")
+		return uc.reply(ctx, user, domain.TemplateNotUnderstood, nil)
 	}
 }
 
 func (uc *MessageUseCase) handleCreateEvent(ctx context.Context, user *domain.User, llmResponse *domain.LLMResponse) error {
 	entities, err := uc.parseEventEntities(llmResponse.Entities)
 	if err != nil {
-		return uc.sendWhatsAppMessage(ctx, user.WANumber, "Erro ao processar os dados do evento. Pode tentar novamente?")
+		return uc.reply(ctx, user, domain.TemplateInvalidEventData, nil)
 	}
 
 	event, err := uc.eventUseCase.CreateEvent(ctx, user.ID, entities)
+	var conflict *domain.ScheduleConflictError
+	if errors.As(err, &conflict) {
+		return uc.holdConflictingEvent(ctx, user, entities, conflict)
+	}
 	if err != nil {
-		return uc.sendWhatsAppMessage(ctx, user.WANumber, fmt.Sprintf("Erro ao criar evento: %s", err.Error()))
+		return uc.reply(ctx, user, domain.TemplateEventCreateFailed, domain.MessageData{"Error": err.Error()})
 	}
 
-	location := ""
-	if event.Location != nil {
-		location = fmt.Sprintf(" em %s", *event.Location)
+	when := uc.when(user)
+	reminders := ""
+	if event.HasReminderSchedule() {
+		reminders = when.Durations(event.ReminderOffsets)
+	}
+	recurrence := ""
+	if rule, err := event.Recurrence(); err == nil && rule != nil {
+		recurrence = when.Recurrence(rule)
 	}
 
-	message := fmt.Sprintf("✅ Evento criado: %s em %s%s. Lembrete: %d minutos antes.",
-		event.Title,
-		event.StartsAt.Format("02/01/2006 15:04"),
-		location,
-		event.RemindBeforeMinutes,
-	)
-
-	return uc.sendWhatsAppMessage(ctx, user.WANumber, message)
+	data := domain.MessageData{
+		"Event":      event,
+		"When":       when.Event(event),
+		"Reminders":  reminders,
+		"Recurrence": recurrence,
+	}
+	addParticipants(data, event.Participants)
+	return uc.reply(ctx, user, domain.TemplateEventCreated, data)
 }
 
 func (uc *MessageUseCase) handleUpdateEvent(ctx context.Context, user *domain.User, llmResponse *domain.LLMResponse) error {
 	entities, err := uc.parseEventEntities(llmResponse.Entities)
 	if err != nil {
-		return uc.sendWhatsAppMessage(ctx, user.WANumber, "Erro ao processar os dados do evento.")
+		return uc.reply(ctx, user, domain.TemplateInvalidEventData, nil)
 	}
 
+	// An explicit change of time is applied even when it overlaps other
+	// events; the user is only warned about it.
 	event, err := uc.eventUseCase.UpdateEvent(ctx, user.ID, entities)
+	var conflict *domain.ScheduleConflictError
+	if errors.As(err, &conflict) {
+		entities.Force = true
+		event, err = uc.eventUseCase.UpdateEvent(ctx, user.ID, entities)
+	}
 	if err != nil {
-		return uc.sendWhatsAppMessage(ctx, user.WANumber, fmt.Sprintf("Erro ao atualizar evento: %s", err.Error()))
+		return uc.reply(ctx, user, domain.TemplateEventUpdateFailed, domain.MessageData{"Error": err.Error()})
 	}
 
-	message := fmt.Sprintf("✏️ Evento atualizado: %s em %s", event.Title, event.StartsAt.Format("02/01/2006 15:04"))
-	return uc.sendWhatsAppMessage(ctx, user.WANumber, message)
+	when := uc.when(user)
+	data := domain.MessageData{
+		"Event":      event,
+		"When":       when.Event(event),
+		"Occurrence": event.IsOccurrence(),
+	}
+	var conflicts []domain.Event
+	if conflict != nil {
+		conflicts = conflict.Conflicts
+	}
+	addConflicts(data, conflicts, when)
+	return uc.reply(ctx, user, domain.TemplateEventUpdated, data)
 }
 
 func (uc *MessageUseCase) handleCancelEvent(ctx context.Context, user *domain.User, llmResponse *domain.LLMResponse) error {
 	entities, err := uc.parseEventEntities(llmResponse.Entities)
 	if err != nil {
-		return uc.sendWhatsAppMessage(ctx, user.WANumber, "Erro ao identificar o evento.")
+		return uc.reply(ctx, user, domain.TemplateUnknownEvent, domain.MessageData{"Intent": string(llmResponse.Intent)})
 	}
 
 	event, err := uc.eventUseCase.CancelEvent(ctx, user.ID, entities.Identifier)
 	if err != nil {
-		return uc.sendWhatsAppMessage(ctx, user.WANumber, fmt.Sprintf("Erro ao cancelar evento: %s", err.Error()))
+		return uc.reply(ctx, user, domain.TemplateEventCancelFailed, domain.MessageData{"Error": err.Error()})
 	}
 
-	message := fmt.Sprintf("❌ Evento cancelado: %s", event.Title)
-	return uc.sendWhatsAppMessage(ctx, user.WANumber, message)
+	return uc.reply(ctx, user, domain.TemplateEventCanceled, canceledMessageData(event, entities.Identifier, uc.when(user)))
 }
 
 func (uc *MessageUseCase) handleListEvents(ctx context.Context, user *domain.User, llmResponse *domain.LLMResponse) error {
 	events, err := uc.eventUseCase.ListEvents(ctx, user.ID, nil, nil)
 	if err != nil {
-		return uc.sendWhatsAppMessage(ctx, user.WANumber, "Erro ao listar eventos.")
+		return uc.reply(ctx, user, domain.TemplateEventListFailed, nil)
 	}
 
 	if len(events) == 0 {
-		return uc.sendWhatsAppMessage(ctx, user.WANumber, "Você não tem nenhum evento agendado.")
+		return uc.reply(ctx, user, domain.TemplateEventListEmpty, nil)
 	}
 
-	var message strings.Builder
-	message.WriteString("📅 *Seus próximos eventos:*\n\n")
-
-	for i, event := range events {
+	when := uc.when(user)
+	var items []domain.MessageData
+	for i := range events {
 		if i >= 10 { // Limit to 10 events
 			break
 		}
-		location := ""
-		if event.Location != nil {
-			location = fmt.Sprintf(" - %s", *event.Location)
-		}
-		message.WriteString(fmt.Sprintf("%d. %s\n📅 %s%s\n\n",
-			i+1,
-			event.Title,
-			event.StartsAt.Format("02/01/2006 15:04"),
-			location,
-		))
+		items = append(items, domain.MessageData{
+			"Number": i + 1,
+			"Event":  &events[i],
+			"When":   when.Event(&events[i]),
+		})
 	}
 
-	return uc.sendWhatsAppMessage(ctx, user.WANumber, message.String())
+	return uc.reply(ctx, user, domain.TemplateEventList, domain.MessageData{"Events": items})
 }
 
 func (uc *MessageUseCase) handleConfirmEvent(ctx context.Context, user *domain.User, llmResponse *domain.LLMResponse) error {
 	entities, err := uc.parseEventEntities(llmResponse.Entities)
+	if err == nil {
+		if handled, err := uc.resolveHeldEvent(ctx, user, true, entities.Identifier); handled || err != nil {
+			return err
+		}
+		if handled, err := uc.respondToInvitation(ctx, user, true, entities.Identifier); handled || err != nil {
+			return err
+		}
+	}
 	if err != nil || entities.Identifier == nil {
-		return uc.sendWhatsAppMessage(ctx, user.WANumber, "Não consegui identificar qual evento confirmar.")
+		return uc.reply(ctx, user, domain.TemplateUnknownEvent, domain.MessageData{"Intent": string(llmResponse.Intent)})
 	}
 
-	event, err := uc.eventUseCase.ConfirmEvent(ctx, user.ID, entities.Identifier)
+	return uc.confirmEvent(ctx, user, entities.Identifier)
+}
+
+func (uc *MessageUseCase) confirmEvent(ctx context.Context, user *domain.User, identifier *domain.EventIdentifier) error {
+	event, err := uc.eventUseCase.ConfirmEvent(ctx, user.ID, identifier)
 	if err != nil {
-		return uc.sendWhatsAppMessage(ctx, user.WANumber, fmt.Sprintf("Erro ao confirmar evento: %s", err.Error()))
+		return uc.reply(ctx, user, domain.TemplateEventConfirmFailed, domain.MessageData{"Error": err.Error()})
 	}
 
-	message := fmt.Sprintf("✅ Evento confirmado: %s", event.Title)
-	return uc.sendWhatsAppMessage(ctx, user.WANumber, message)
+	return uc.reply(ctx, user, domain.TemplateEventConfirmed, domain.MessageData{
+		"Event":      event,
+		"When":       uc.when(user).At(event.StartsAt),
+		"Occurrence": event.IsOccurrence(),
+	})
 }
 
 func (uc *MessageUseCase) handleDeclineEvent(ctx context.Context, user *domain.User, llmResponse *domain.LLMResponse) error {
 	entities, err := uc.parseEventEntities(llmResponse.Entities)
+	if err == nil {
+		if handled, err := uc.resolveHeldEvent(ctx, user, false, entities.Identifier); handled || err != nil {
+			return err
+		}
+		if handled, err := uc.respondToInvitation(ctx, user, false, entities.Identifier); handled || err != nil {
+			return err
+		}
+	}
 	if err != nil || entities.Identifier == nil {
-		return uc.sendWhatsAppMessage(ctx, user.WANumber, "Não consegui identificar qual evento cancelar.")
+		return uc.reply(ctx, user, domain.TemplateUnknownEvent, domain.MessageData{"Intent": string(llmResponse.Intent)})
 	}
 
-	event, err := uc.eventUseCase.CancelEvent(ctx, user.ID, entities.Identifier)
+	return uc.cancelEvent(ctx, user, entities.Identifier)
+}
+
+func (uc *MessageUseCase) cancelEvent(ctx context.Context, user *domain.User, identifier *domain.EventIdentifier) error {
+	event, err := uc.eventUseCase.CancelEvent(ctx, user.ID, identifier)
+	if err != nil {
+		return uc.reply(ctx, user, domain.TemplateEventCancelFailed, domain.MessageData{"Error": err.Error()})
+	}
+
+	return uc.reply(ctx, user, domain.TemplateEventCanceled, canceledMessageData(event, identifier, uc.when(user)))
+}
+
+func (uc *MessageUseCase) handleSnoozeEvent(ctx context.Context, user *domain.User, llmResponse *domain.LLMResponse) error {
+	entities, err := uc.parseEventEntities(llmResponse.Entities)
+	if err != nil {
+		return uc.reply(ctx, user, domain.TemplateInvalidSnooze, nil)
+	}
+
+	return uc.snoozeEvent(ctx, user, entities)
+}
+
+func (uc *MessageUseCase) snoozeEvent(ctx context.Context, user *domain.User, entities *domain.EventEntities) error {
+	event, err := uc.eventUseCase.SnoozeEvent(ctx, user.ID, entities)
+	if err != nil {
+		return uc.reply(ctx, user, domain.TemplateEventSnoozeFailed, domain.MessageData{"Error": err.Error()})
+	}
+
+	return uc.reply(ctx, user, domain.TemplateEventSnoozed, domain.MessageData{
+		"Event": event,
+		"Until": uc.when(user).At(*event.SnoozedUntil),
+	})
+}
+
+// handleEventReply acts on the event the user tapped an option about, such
+// as the buttons of a confirmation request, without asking the LLM. Recurring
+// events are confirmed or canceled for the occurrence reminded of only.
+func (uc *MessageUseCase) handleEventReply(ctx context.Context, user *domain.User, action domain.EventReply, eventID int) error {
+	scope := domain.EditScopeOccurrence
+	identifier := &domain.EventIdentifier{EventID: &eventID, Scope: &scope}
+
+	switch action {
+	case domain.EventReplyConfirm:
+		return uc.confirmEvent(ctx, user, identifier)
+	case domain.EventReplyCancel:
+		return uc.cancelEvent(ctx, user, identifier)
+	default:
+		return uc.snoozeEvent(ctx, user, &domain.EventEntities{Identifier: identifier})
+	}
+}
+
+func (uc *MessageUseCase) handleConfigureDigest(ctx context.Context, user *domain.User, llmResponse *domain.LLMResponse) error {
+	var settings domain.DigestSettings
+	if err := parseEntities(llmResponse.Entities, &settings); err != nil {
+		return uc.reply(ctx, user, domain.TemplateInvalidDigest, nil)
+	}
+
+	config := user.Config()
+	if err := settings.Apply(config); err != nil {
+		return uc.reply(ctx, user, domain.TemplateInvalidDigestTime, nil)
+	}
+
+	if err := uc.repos.User().UpdateConfig(ctx, user.ID, config); err != nil {
+		return fmt.Errorf("failed to update digest settings: %w", err)
+	}
+
+	return uc.reply(ctx, user, domain.TemplateDigestSettings, domain.MessageData{"Config": config})
+}
+
+// holdConflictingEvent stores an event that overlaps others as tentative and
+// asks the user whether to keep both.
+func (uc *MessageUseCase) holdConflictingEvent(ctx context.Context, user *domain.User, entities *domain.EventEntities, conflict *domain.ScheduleConflictError) error {
+	event, err := uc.eventUseCase.HoldEvent(ctx, user.ID, entities)
 	if err != nil {
-		return uc.sendWhatsAppMessage(ctx, user.WANumber, fmt.Sprintf("Erro ao cancelar evento: %s", err.Error()))
+		return uc.reply(ctx, user, domain.TemplateEventCreateFailed, domain.MessageData{"Error": err.Error()})
+	}
+
+	when := uc.when(user)
+	data := domain.MessageData{
+		"Event": event,
+		"When":  when.Event(event),
 	}
+	addConflicts(data, conflict.Conflicts, when)
+	return uc.reply(ctx, user, domain.TemplateConflictHeld, data)
+}
 
-	message := fmt.Sprintf("❌ Evento cancelado: %s", event.Title)
-	return uc.sendWhatsAppMessage(ctx, user.WANumber, message)
+// resolveHeldEvent treats a confirm/decline as the answer to a conflict
+// warning when an event is being held and the identifier, if any, matches it.
+func (uc *MessageUseCase) resolveHeldEvent(ctx context.Context, user *domain.User, keep bool, identifier *domain.EventIdentifier) (bool, error) {
+	held, err := uc.eventUseCase.HeldEvent(ctx, user.ID)
+	if err != nil {
+		return true, uc.reply(ctx, user, domain.TemplateConflictAnswerFailed, domain.MessageData{"Error": ""})
+	}
+	if held == nil {
+		return false, nil
+	}
+	if identifier != nil && identifier.Title != nil &&
+		!strings.Contains(strings.ToLower(held.Title), strings.ToLower(*identifier.Title)) {
+		return false, nil
+	}
+
+	event, err := uc.eventUseCase.ResolveHeldEvent(ctx, user.ID, keep)
+	if err != nil {
+		return true, uc.reply(ctx, user, domain.TemplateConflictAnswerFailed, domain.MessageData{"Error": err.Error()})
+	}
+	if event == nil {
+		return false, nil
+	}
+
+	if !keep {
+		return true, uc.reply(ctx, user, domain.TemplateConflictDiscarded, domain.MessageData{"Event": event})
+	}
+
+	data := domain.MessageData{
+		"Event": event,
+		"When":  uc.when(user).Event(event),
+	}
+	addParticipants(data, event.Participants)
+	return true, uc.reply(ctx, user, domain.TemplateConflictKept, data)
 }
 
-func (uc *MessageUseCase) getOrCreateUser(ctx context.Context, waNumber, contactName string) (*domain.User, error) {
-	user, err := uc.repos.User().GetByWANumber(ctx, waNumber)
+// respondToInvitation treats a confirm/decline as an RSVP when the sender
+// was invited to a matching event by someone else.
+func (uc *MessageUseCase) respondToInvitation(ctx context.Context, user *domain.User, accept bool, identifier *domain.EventIdentifier) (bool, error) {
+	event, participant, err := uc.participantUseCase.RespondToInvitation(ctx, user.WANumber, accept, identifier)
+	if err != nil {
+		return true, uc.reply(ctx, user, domain.TemplateInvitationFailed, nil)
+	}
+	if participant == nil {
+		return false, nil
+	}
+
+	return true, uc.reply(ctx, user, domain.TemplateInvitationAnswered, domain.MessageData{"Event": event, "Accepted": accept})
+}
+
+// claimIdentity verifies the identity of the sender that another user linked
+// through the API when the message is the code sent to it, and tells that
+// user's account it was linked. It reports whether the message was such a
+// code. A sender who already has a verified identity keeps it.
+func (uc *MessageUseCase) claimIdentity(ctx context.Context, parsedMessage whatsapp.ParsedMessage) (bool, error) {
+	code := strings.TrimSpace(parsedMessage.Text)
+	if !domain.IsVerificationCode(code) {
+		return false, nil
+	}
+
+	channel := parsedMessage.Channel.OrDefault()
+	verified, err := uc.repos.UserIdentity().GetByChannelID(ctx, channel, parsedMessage.From)
+	if err != nil {
+		return false, err
+	}
+	if verified != nil {
+		return false, nil
+	}
+
+	identity, err := uc.repos.UserIdentity().FindClaim(ctx, channel, parsedMessage.From, code)
+	if err != nil {
+		return false, err
+	}
+	if identity == nil {
+		return false, nil
+	}
+
+	user, err := uc.identityUser(ctx, identity)
+	if err != nil {
+		return false, err
+	}
+	if err := uc.repos.UserIdentity().MarkVerified(ctx, identity.ID); err != nil {
+		return false, fmt.Errorf("failed to verify identity: %w", err)
+	}
+	identity.Verified = true
+	identity.VerificationCode = nil
+	if err := uc.repos.UserIdentity().RecordInbound(ctx, identity.ID, uc.timeProvider.Now()); err != nil {
+		return false, fmt.Errorf("failed to record inbound message: %w", err)
+	}
+
+	user.Via = identity
+	return true, uc.reply(ctx, user, domain.TemplateIdentityVerified, nil)
+}
+
+// getOrCreateUser resolves the sender through their verified identity on
+// the channel, or creates a user identified by it. Identities other users
+// linked to the ID but were not verified are left alone. Replies to the
+// user go back to that identity.
+func (uc *MessageUseCase) getOrCreateUser(ctx context.Context, channel domain.Channel, externalID, contactName string) (*domain.User, error) {
+	identity, err := uc.repos.UserIdentity().GetByChannelID(ctx, channel, externalID)
 	if err != nil {
 		return nil, err
 	}
 
-	if user != nil {
+	if identity != nil {
+		user, err := uc.identityUser(ctx, identity)
+		if err != nil {
+			return nil, err
+		}
+		user.Via = identity
 		return user, nil
 	}
 
-	user = &domain.User{
-		WANumber:                      waNumber,
+	user := &domain.User{
+		PreferredChannel:              channel,
+		ContactID:                     externalID,
 		Timezone:                      uc.defaultTimezone,
 		DefaultRemindBeforeMinutes:    30,
 		DefaultRemindFrequencyMinutes: 15,
@@ -259,32 +566,113 @@ func (uc *MessageUseCase) getOrCreateUser(ctx context.Context, waNumber, contact
 		RateLimitPerMinute:            30,
 		IsActive:                      true,
 	}
+	if channel == domain.ChannelWhatsApp {
+		user.WANumber = externalID
+	}
 
 	if contactName != "" {
 		user.Name = &contactName
 	}
 
-	if err := uc.repos.User().Create(ctx, user); err != nil {
+	err = uc.repos.WithTx(ctx, func(tx ports.Repositories) error {
+		if err := tx.User().Create(ctx, user); err != nil {
+			return err
+		}
+		user.Via = &domain.UserIdentity{
+			UserID:     user.ID,
+			Channel:    channel,
+			ExternalID: externalID,
+			Verified:   true,
+		}
+		return tx.UserIdentity().Create(ctx, user.Via)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return user, nil
 }
 
-func (uc *MessageUseCase) parseEventEntities(entities map[string]interface{}) (*domain.EventEntities, error) {
-	entitiesJSON, err := json.Marshal(entities)
+// identityUser returns the user the identity belongs to.
+func (uc *MessageUseCase) identityUser(ctx context.Context, identity *domain.UserIdentity) (*domain.User, error) {
+	user, err := uc.repos.User().GetByID(ctx, identity.UserID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user %d of identity %d not found", identity.UserID, identity.ID)
+	}
+	return user, nil
+}
+
+// canceledMessageData tells whether the whole event, one occurrence or the
+// occurrence and the ones after it were canceled.
+func canceledMessageData(event *domain.Event, identifier *domain.EventIdentifier, when domain.TimeRenderer) domain.MessageData {
+	occurrence := event.IsOccurrence()
+	following := occurrence && identifier != nil && identifier.Scope != nil && *identifier.Scope == domain.EditScopeFollowing
+	return domain.MessageData{
+		"Event":      event,
+		"When":       when.At(event.StartsAt),
+		"Occurrence": occurrence,
+		"Following":  following,
 	}
+}
+
+// addConflicts sets the conflicting events, up to three of them, and how
+// many more there are.
+func addConflicts(data domain.MessageData, conflicts []domain.Event, when domain.TimeRenderer) {
+	var items []domain.MessageData
+	more := 0
+	for i := range conflicts {
+		if i >= 3 {
+			more = len(conflicts) - i
+			break
+		}
+		items = append(items, domain.MessageData{
+			"Event": &conflicts[i],
+			"When":  when.Event(&conflicts[i]),
+		})
+	}
+	data["Conflicts"] = items
+	data["MoreConflicts"] = more
+}
+
+// addParticipants sets who was invited and who could not be.
+func addParticipants(data domain.MessageData, participants []domain.EventParticipant) {
+	var invited, skipped []string
+	for _, participant := range participants {
+		if participant.IsInvited() {
+			invited = append(invited, participant.DisplayName())
+		} else {
+			skipped = append(skipped, participant.DisplayName())
+		}
+	}
+	data["Invited"] = invited
+	data["Skipped"] = skipped
+}
 
+func (uc *MessageUseCase) parseEventEntities(entities map[string]interface{}) (*domain.EventEntities, error) {
 	var eventEntities domain.EventEntities
-	if err := json.Unmarshal(entitiesJSON, &eventEntities); err != nil {
+	if err := parseEntities(entities, &eventEntities); err != nil {
 		return nil, err
 	}
 
 	return &eventEntities, nil
 }
 
-func (uc *MessageUseCase) sendWhatsAppMessage(ctx context.Context, to, text string) error {
-	return uc.whatsappSender.SendText(ctx, to, text)
+func parseEntities(entities map[string]interface{}, dest interface{}) error {
+	entitiesJSON, err := json.Marshal(entities)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(entitiesJSON, dest)
+}
+
+// reply renders the named template for the user and sends it to them.
+func (uc *MessageUseCase) reply(ctx context.Context, user *domain.User, name string, data domain.MessageData) error {
+	text, err := uc.messages.Render(ctx, user, name, data)
+	if err != nil {
+		return fmt.Errorf("failed to render message: %w", err)
+	}
+	return uc.sender.SendToUser(ctx, user, text)
 }
@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alarm-agent/internal/adapters/whatsapp"
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/infra"
+)
+
+type MockUserIdentityRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserIdentityRepository) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	args := m.Called(ctx, identity)
+	return args.Error(0)
+}
+
+func (m *MockUserIdentityRepository) GetByChannelID(ctx context.Context, channel domain.Channel, externalID string) (*domain.UserIdentity, error) {
+	args := m.Called(ctx, channel, externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserIdentity), args.Error(1)
+}
+
+func (m *MockUserIdentityRepository) FindClaim(ctx context.Context, channel domain.Channel, externalID, code string) (*domain.UserIdentity, error) {
+	args := m.Called(ctx, channel, externalID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserIdentity), args.Error(1)
+}
+
+func (m *MockUserIdentityRepository) ListByUserID(ctx context.Context, userID int) ([]domain.UserIdentity, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.UserIdentity), args.Error(1)
+}
+
+func (m *MockUserIdentityRepository) MarkVerified(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserIdentityRepository) RecordInbound(ctx context.Context, id int, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (m *MockUserIdentityRepository) Delete(ctx context.Context, userID, id int) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func TestMessageUseCase_GetOrCreateUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates a user identified on the channel", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		identityRepo := &MockUserIdentityRepository{}
+		repos := &MockRepositories{userRepo: userRepo, identityRepo: identityRepo}
+		uc := NewMessageUseCase(repos, nil, nil, nil, nil, "America/Sao_Paulo", nil, infra.NewRealTimeProvider())
+
+		identityRepo.On("GetByChannelID", ctx, domain.ChannelTelegram, "42").Return(nil, nil)
+		userRepo.On("Create", ctx, mock.AnythingOfType("*domain.User")).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.User).ID = 7
+		}).Return(nil)
+		identityRepo.On("Create", ctx, &domain.UserIdentity{UserID: 7, Channel: domain.ChannelTelegram, ExternalID: "42", Verified: true}).Return(nil)
+
+		user, err := uc.getOrCreateUser(ctx, domain.ChannelTelegram, "42", "Ana")
+		require.NoError(t, err)
+
+		assert.Equal(t, 7, user.ID)
+		assert.Empty(t, user.WANumber)
+		channel, contact := user.Contact()
+		assert.Equal(t, domain.ChannelTelegram, channel)
+		assert.Equal(t, "42", contact)
+		identityRepo.AssertExpectations(t)
+	})
+
+	t.Run("resolves a verified identity", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		identityRepo := &MockUserIdentityRepository{}
+		repos := &MockRepositories{userRepo: userRepo, identityRepo: identityRepo}
+		uc := NewMessageUseCase(repos, nil, nil, nil, nil, "America/Sao_Paulo", nil, infra.NewRealTimeProvider())
+
+		existing := &domain.User{ID: 3, WANumber: "+5511900000001", PreferredChannel: domain.ChannelWhatsApp}
+		identityRepo.On("GetByChannelID", ctx, domain.ChannelTelegram, "42").
+			Return(&domain.UserIdentity{ID: 5, UserID: 3, Channel: domain.ChannelTelegram, ExternalID: "42", Verified: true}, nil)
+		userRepo.On("GetByID", ctx, 3).Return(existing, nil)
+
+		user, err := uc.getOrCreateUser(ctx, domain.ChannelTelegram, "42", "Ana")
+		require.NoError(t, err)
+
+		assert.Same(t, existing, user)
+		channel, contact := user.Contact()
+		assert.Equal(t, domain.ChannelTelegram, channel, "replies go back to the identity written from")
+		assert.Equal(t, "42", contact)
+		identityRepo.AssertNotCalled(t, "MarkVerified", mock.Anything, mock.Anything)
+		userRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails on an identity whose user is gone", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		identityRepo := &MockUserIdentityRepository{}
+		repos := &MockRepositories{userRepo: userRepo, identityRepo: identityRepo}
+		uc := NewMessageUseCase(repos, nil, nil, nil, nil, "America/Sao_Paulo", nil, infra.NewRealTimeProvider())
+
+		identityRepo.On("GetByChannelID", ctx, domain.ChannelTelegram, "42").
+			Return(&domain.UserIdentity{ID: 5, UserID: 3, Channel: domain.ChannelTelegram, ExternalID: "42", Verified: true}, nil)
+		userRepo.On("GetByID", ctx, 3).Return(nil, nil)
+
+		user, err := uc.getOrCreateUser(ctx, domain.ChannelTelegram, "42", "Ana")
+		assert.EqualError(t, err, "user 3 of identity 5 not found")
+		assert.Nil(t, user)
+	})
+}
+
+func TestMessageUseCase_ClaimIdentity(t *testing.T) {
+	ctx := context.Background()
+	message := whatsapp.ParsedMessage{ID: "msg-1", Channel: domain.ChannelTelegram, From: "42", Text: " 123456 "}
+
+	t.Run("verifies the identity the code was sent to", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		identityRepo := &MockUserIdentityRepository{}
+		sender := &MockWhatsAppSender{}
+		repos := &MockRepositories{userRepo: userRepo, identityRepo: identityRepo}
+		uc := NewMessageUseCase(repos, sender, defaultMessages(t), nil, nil, "America/Sao_Paulo", nil, infra.NewRealTimeProvider())
+
+		code := "123456"
+		claimer := &domain.User{ID: 3, WANumber: "+5511900000001"}
+		identityRepo.On("GetByChannelID", ctx, domain.ChannelTelegram, "42").Return(nil, nil)
+		identityRepo.On("FindClaim", ctx, domain.ChannelTelegram, "42", code).
+			Return(&domain.UserIdentity{ID: 5, UserID: 3, Channel: domain.ChannelTelegram, ExternalID: "42", VerificationCode: &code}, nil)
+		userRepo.On("GetByID", ctx, 3).Return(claimer, nil)
+		identityRepo.On("MarkVerified", ctx, 5).Return(nil)
+		identityRepo.On("RecordInbound", ctx, 5, mock.Anything).Return(nil)
+		sender.On("SendToUser", ctx, claimer, mock.MatchedBy(func(text string) bool {
+			return strings.Contains(text, "vinculado à sua conta")
+		})).Return(nil)
+
+		claimed, err := uc.claimIdentity(ctx, message)
+		require.NoError(t, err)
+
+		assert.True(t, claimed)
+		channel, contact := claimer.Contact()
+		assert.Equal(t, domain.ChannelTelegram, channel, "the confirmation goes to the verified identity")
+		assert.Equal(t, "42", contact)
+		identityRepo.AssertExpectations(t)
+		sender.AssertExpectations(t)
+	})
+
+	t.Run("ignores other messages", func(t *testing.T) {
+		identityRepo := &MockUserIdentityRepository{}
+		uc := NewMessageUseCase(&MockRepositories{identityRepo: identityRepo}, nil, nil, nil, nil, "America/Sao_Paulo", nil, infra.NewRealTimeProvider())
+
+		claimed, err := uc.claimIdentity(ctx, whatsapp.ParsedMessage{Channel: domain.ChannelTelegram, From: "42", Text: "oi"})
+		require.NoError(t, err)
+
+		assert.False(t, claimed)
+		identityRepo.AssertNotCalled(t, "FindClaim", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("leaves a sender with an account of their own alone", func(t *testing.T) {
+		identityRepo := &MockUserIdentityRepository{}
+		uc := NewMessageUseCase(&MockRepositories{identityRepo: identityRepo}, nil, nil, nil, nil, "America/Sao_Paulo", nil, infra.NewRealTimeProvider())
+
+		identityRepo.On("GetByChannelID", ctx, domain.ChannelTelegram, "42").
+			Return(&domain.UserIdentity{ID: 9, UserID: 8, Channel: domain.ChannelTelegram, ExternalID: "42", Verified: true}, nil)
+
+		claimed, err := uc.claimIdentity(ctx, message)
+		require.NoError(t, err)
+
+		assert.False(t, claimed)
+		identityRepo.AssertNotCalled(t, "FindClaim", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		identityRepo.AssertNotCalled(t, "MarkVerified", mock.Anything, mock.Anything)
+	})
+}
+
+func TestMessageUseCase_ProcessEventReply(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{eventRepo: &MockEventRepository{}}
+	sender := &MockWhatsAppSender{}
+	messages := defaultMessages(t)
+	timeProvider := infra.NewRealTimeProvider()
+	eventUseCase := NewEventUseCase(mockRepos, nil, messages, timeProvider)
+	// Without LLM clients, reaching the LLM would panic.
+	uc := NewMessageUseCase(mockRepos, sender, messages, eventUseCase, nil, "America/Sao_Paulo", nil, timeProvider)
+
+	user := &domain.User{ID: 1, WANumber: "+5511900000001", Timezone: "America/Sao_Paulo", IsActive: true}
+	event := domain.Event{
+		ID:                  5,
+		UserID:              1,
+		Title:               "Remédio",
+		StartsAt:            time.Now().Add(30 * time.Minute),
+		RequireConfirmation: true,
+		Status:              domain.EventStatusScheduled,
+	}
+
+	mockRepos.eventRepo.On("FindByUserAndIdentifier", ctx, 1, mock.MatchedBy(func(identifier *domain.EventIdentifier) bool {
+		return identifier.EventID != nil && *identifier.EventID == 5
+	})).Return([]domain.Event{event}, nil)
+	mockRepos.eventRepo.On("Update", ctx, mock.MatchedBy(func(event *domain.Event) bool {
+		return event.Status == domain.EventStatusConfirmed
+	})).Return(nil)
+	sender.On("SendToUser", ctx, user, mock.MatchedBy(func(text string) bool {
+		return strings.Contains(text, "Evento confirmado: Remédio")
+	})).Return(nil)
+
+	err := uc.processUserMessage(ctx, user, whatsapp.ParsedMessage{
+		ID:      "msg-1",
+		Channel: domain.ChannelWhatsApp,
+		From:    user.WANumber,
+		Type:    "INTERACTIVE_BUTTON_REPLY",
+		Text:    "✅ Confirmar",
+		Payload: domain.EventReplyID(domain.EventReplyConfirm, 5),
+	})
+
+	require.NoError(t, err)
+	mockRepos.eventRepo.AssertExpectations(t)
+	sender.AssertExpectations(t)
+}
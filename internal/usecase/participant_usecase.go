@@ -0,0 +1,316 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.uber.org/zap"
+
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+type ParticipantUseCase struct {
+	repos        ports.Repositories
+	sender       ports.MessageSender
+	messages     ports.MessageRenderer
+	timeProvider ports.TimeProvider
+	logger       *zap.Logger
+}
+
+func NewParticipantUseCase(repos ports.Repositories, sender ports.MessageSender, messages ports.MessageRenderer, timeProvider ports.TimeProvider, logger *zap.Logger) *ParticipantUseCase {
+	return &ParticipantUseCase{
+		repos:        repos,
+		sender:       sender,
+		messages:     messages,
+		timeProvider: timeProvider,
+		logger:       logger,
+	}
+}
+
+// AddParticipants stores the participants of a new event and invites those
+// whose number is in the organizer's allowed contacts. Participants given by
+// name are matched against the notes of the allowed contacts. Invitations
+// for tentative events wait until the event is kept.
+func (uc *ParticipantUseCase) AddParticipants(ctx context.Context, organizer *domain.User, event *domain.Event, participants []string) ([]domain.EventParticipant, error) {
+	contacts, err := uc.repos.UserAllowedContact().List(ctx, organizer.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allowed contacts: %w", err)
+	}
+
+	var added []domain.EventParticipant
+	seen := make(map[string]bool)
+
+	for _, raw := range participants {
+		raw = strings.TrimSpace(raw)
+		if raw == "" || seen[strings.ToLower(raw)] {
+			continue
+		}
+		seen[strings.ToLower(raw)] = true
+
+		participant := domain.EventParticipant{
+			EventID: event.ID,
+			Status:  domain.ParticipantStatusPending,
+		}
+
+		number, name := resolveParticipant(raw, contacts)
+		if number != "" {
+			normalized := domain.NormalizeNumber(number)
+			if seen[normalized] {
+				continue
+			}
+			seen[normalized] = true
+			participant.WANumber = &normalized
+		}
+		if name != "" {
+			participant.Name = &name
+		}
+
+		if event.Status != domain.EventStatusTentative {
+			if participant.InvitedAt, err = uc.invite(ctx, organizer, event, number, contacts); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := uc.repos.EventParticipant().Create(ctx, &participant); err != nil {
+			return nil, fmt.Errorf("failed to create participant: %w", err)
+		}
+		added = append(added, participant)
+	}
+
+	return added, nil
+}
+
+// InviteParticipants sends the invitations of stored participants that were
+// not invited yet, such as those of an event that was held as tentative.
+func (uc *ParticipantUseCase) InviteParticipants(ctx context.Context, organizer *domain.User, event *domain.Event) ([]domain.EventParticipant, error) {
+	participants, err := uc.repos.EventParticipant().ListByEventID(ctx, event.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	contacts, err := uc.repos.UserAllowedContact().List(ctx, organizer.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allowed contacts: %w", err)
+	}
+
+	for i := range participants {
+		if participants[i].IsInvited() || participants[i].WANumber == nil {
+			continue
+		}
+		invitedAt, err := uc.invite(ctx, organizer, event, *participants[i].WANumber, contacts)
+		if err != nil {
+			return nil, err
+		}
+		if invitedAt == nil {
+			continue
+		}
+		if err := uc.repos.EventParticipant().MarkInvited(ctx, participants[i].ID, *invitedAt); err != nil {
+			return nil, fmt.Errorf("failed to mark participant invited: %w", err)
+		}
+		participants[i].InvitedAt = invitedAt
+	}
+
+	return participants, nil
+}
+
+// invite sends an invitation when the number is one of the organizer's
+// allowed contacts and returns when it was sent. A failed send leaves the
+// participant uninvited rather than failing the whole event.
+func (uc *ParticipantUseCase) invite(ctx context.Context, organizer *domain.User, event *domain.Event, number string, contacts []domain.UserAllowedContact) (*time.Time, error) {
+	if number == "" || sameNumber(number, organizer.WANumber) || !isAllowedContact(contacts, number) {
+		return nil, nil
+	}
+
+	message, err := uc.messages.Render(ctx, organizer, domain.TemplateInvitation, domain.MessageData{
+		"Organizer": organizer,
+		"Event":     event,
+		"When":      event.FormatWhen(organizer.Location(), organizer.Language()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render invitation: %w", err)
+	}
+	if err := uc.sender.SendText(ctx, domain.NormalizeNumber(number), message); err != nil {
+		uc.logger.Warn("Failed to send invitation",
+			zap.Error(err),
+			zap.Int("event_id", event.ID),
+			zap.Int("organizer_id", organizer.ID),
+		)
+		return nil, nil
+	}
+	invitedAt := uc.timeProvider.Now()
+	return &invitedAt, nil
+}
+
+// RespondToInvitation records an RSVP from a participant and notifies the
+// organizer when the answer changes. It returns a nil participant when the
+// sender has no matching active invitation, so the caller can fall back to
+// the sender's own events.
+func (uc *ParticipantUseCase) RespondToInvitation(ctx context.Context, waNumber string, accept bool, identifier *domain.EventIdentifier) (*domain.Event, *domain.EventParticipant, error) {
+	participant, event, err := uc.findInvitation(ctx, waNumber, identifier)
+	if err != nil || participant == nil {
+		return nil, nil, err
+	}
+
+	status := domain.ParticipantStatusDeclined
+	if accept {
+		status = domain.ParticipantStatusAccepted
+	}
+
+	if participant.Status == status {
+		return event, participant, nil
+	}
+
+	if err := uc.repos.EventParticipant().UpdateStatus(ctx, participant.ID, status); err != nil {
+		return nil, nil, fmt.Errorf("failed to update participant status: %w", err)
+	}
+	participant.Status = status
+
+	organizer, err := uc.repos.User().GetByID(ctx, event.UserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get organizer: %w", err)
+	}
+	if organizer != nil {
+		message, err := uc.messages.Render(ctx, organizer, domain.TemplateRSVP, domain.MessageData{
+			"Participant": participant,
+			"Event":       event,
+			"Accepted":    status == domain.ParticipantStatusAccepted,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to render RSVP: %w", err)
+		}
+		if err := uc.sender.SendToUser(ctx, organizer, message); err != nil {
+			return nil, nil, fmt.Errorf("failed to notify organizer: %w", err)
+		}
+	}
+
+	return event, participant, nil
+}
+
+// PendingInvitation returns the event of the most recent invitation the
+// number has not answered yet, if any. Users without a WhatsApp number have
+// none, since invitations are sent to numbers.
+func (uc *ParticipantUseCase) PendingInvitation(ctx context.Context, waNumber string) (*domain.Event, error) {
+	if waNumber == "" {
+		return nil, nil
+	}
+
+	invitations, err := uc.repos.EventParticipant().ListActiveInvitationsByNumber(ctx, waNumber, uc.timeProvider.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+
+	for _, invitation := range invitations {
+		if invitation.Status != domain.ParticipantStatusPending {
+			continue
+		}
+		event, err := uc.repos.Event().GetByID(ctx, invitation.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get event: %w", err)
+		}
+		if event != nil {
+			return event, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (uc *ParticipantUseCase) findInvitation(ctx context.Context, waNumber string, identifier *domain.EventIdentifier) (*domain.EventParticipant, *domain.Event, error) {
+	if waNumber == "" {
+		return nil, nil, nil
+	}
+
+	invitations, err := uc.repos.EventParticipant().ListActiveInvitationsByNumber(ctx, waNumber, uc.timeProvider.Now())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+
+	for i := range invitations {
+		event, err := uc.repos.Event().GetByID(ctx, invitations[i].EventID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get event: %w", err)
+		}
+		if event == nil {
+			continue
+		}
+		if identifier != nil && identifier.Title != nil &&
+			!strings.Contains(strings.ToLower(event.Title), strings.ToLower(*identifier.Title)) {
+			continue
+		}
+		return &invitations[i], event, nil
+	}
+
+	return nil, nil, nil
+}
+
+// resolveParticipant turns a participant as extracted by the LLM into a
+// number and a name. Phone numbers are kept as given minus formatting;
+// names are looked up in the notes of the allowed contacts.
+func resolveParticipant(raw string, contacts []domain.UserAllowedContact) (string, string) {
+	if number, ok := parsePhoneNumber(raw); ok {
+		for _, contact := range contacts {
+			if sameNumber(contact.ContactNumber, number) {
+				if contact.Note != nil {
+					return contact.ContactNumber, *contact.Note
+				}
+				return contact.ContactNumber, ""
+			}
+		}
+		return number, ""
+	}
+
+	var match *domain.UserAllowedContact
+	for i, contact := range contacts {
+		if contact.Note == nil || !strings.Contains(strings.ToLower(*contact.Note), strings.ToLower(raw)) {
+			continue
+		}
+		if match != nil {
+			// Ambiguous names are stored without a number.
+			return "", raw
+		}
+		match = &contacts[i]
+	}
+
+	if match != nil {
+		return match.ContactNumber, raw
+	}
+	return "", raw
+}
+
+func parsePhoneNumber(value string) (string, bool) {
+	var digits strings.Builder
+	for i, r := range value {
+		switch {
+		case unicode.IsDigit(r):
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '(' || r == ')' || r == '.':
+		default:
+			return "", false
+		}
+	}
+
+	number := digits.String()
+	if len(strings.TrimPrefix(number, "+")) < 8 {
+		return "", false
+	}
+	return number, true
+}
+
+func sameNumber(a, b string) bool {
+	return domain.NormalizeNumber(a) == domain.NormalizeNumber(b)
+}
+
+func isAllowedContact(contacts []domain.UserAllowedContact, number string) bool {
+	for _, contact := range contacts {
+		if sameNumber(contact.ContactNumber, number) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/infra"
+)
+
+type MockEventParticipantRepository struct {
+	mock.Mock
+}
+
+func (m *MockEventParticipantRepository) Create(ctx context.Context, participant *domain.EventParticipant) error {
+	args := m.Called(ctx, participant)
+	return args.Error(0)
+}
+
+func (m *MockEventParticipantRepository) UpdateStatus(ctx context.Context, id int, status domain.ParticipantStatus) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockEventParticipantRepository) MarkInvited(ctx context.Context, id int, invitedAt time.Time) error {
+	args := m.Called(ctx, id, invitedAt)
+	return args.Error(0)
+}
+
+func (m *MockEventParticipantRepository) ListByEventID(ctx context.Context, eventID int) ([]domain.EventParticipant, error) {
+	args := m.Called(ctx, eventID)
+	return args.Get(0).([]domain.EventParticipant), args.Error(1)
+}
+
+func (m *MockEventParticipantRepository) ListActiveInvitationsByNumber(ctx context.Context, waNumber string, now time.Time) ([]domain.EventParticipant, error) {
+	args := m.Called(ctx, waNumber, mock.Anything)
+	return args.Get(0).([]domain.EventParticipant), args.Error(1)
+}
+
+type MockUserAllowedContactRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserAllowedContactRepository) IsAllowed(ctx context.Context, userID int, contactNumber string) (bool, error) {
+	args := m.Called(ctx, userID, contactNumber)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserAllowedContactRepository) Add(ctx context.Context, contact *domain.UserAllowedContact) error {
+	args := m.Called(ctx, contact)
+	return args.Error(0)
+}
+
+func (m *MockUserAllowedContactRepository) Remove(ctx context.Context, userID int, contactNumber string) error {
+	args := m.Called(ctx, userID, contactNumber)
+	return args.Error(0)
+}
+
+func (m *MockUserAllowedContactRepository) List(ctx context.Context, userID int) ([]domain.UserAllowedContact, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.UserAllowedContact), args.Error(1)
+}
+
+func (m *MockUserAllowedContactRepository) GetByUserAndNumber(ctx context.Context, userID int, contactNumber string) (*domain.UserAllowedContact, error) {
+	args := m.Called(ctx, userID, contactNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserAllowedContact), args.Error(1)
+}
+
+type MockWhatsAppSender struct {
+	mock.Mock
+}
+
+func (m *MockWhatsAppSender) SendText(ctx context.Context, to, text string) error {
+	args := m.Called(ctx, to, text)
+	return args.Error(0)
+}
+
+func (m *MockWhatsAppSender) SendInteractive(ctx context.Context, to, text string, interactive domain.Interactive) error {
+	args := m.Called(ctx, to, text, interactive)
+	return args.Error(0)
+}
+
+func (m *MockWhatsAppSender) SendTemplate(ctx context.Context, to string, template domain.TemplateMessage) error {
+	args := m.Called(ctx, to, template)
+	return args.Error(0)
+}
+
+func (m *MockWhatsAppSender) SendToUser(ctx context.Context, user *domain.User, text string) error {
+	args := m.Called(ctx, user, text)
+	return args.Error(0)
+}
+
+func TestParticipantUseCase_AddParticipants(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		participantRepo: &MockEventParticipantRepository{},
+		contactRepo:     &MockUserAllowedContactRepository{},
+	}
+	sender := &MockWhatsAppSender{}
+
+	useCase := NewParticipantUseCase(mockRepos, sender, defaultMessages(t), infra.NewRealTimeProvider(), zap.NewNop())
+
+	anaNote := "Ana Souza"
+	organizer := &domain.User{ID: 1, WANumber: "+5511999999999"}
+	event := &domain.Event{ID: 10, UserID: 1, Title: "Reunião", StartsAt: time.Now().Add(24 * time.Hour)}
+
+	mockRepos.contactRepo.On("List", ctx, 1).Return([]domain.UserAllowedContact{
+		{UserID: 1, ContactNumber: "+5511988887777", Note: &anaNote},
+	}, nil)
+	sender.On("SendText", ctx, "5511988887777", mock.AnythingOfType("string")).Return(nil).Once()
+	mockRepos.participantRepo.On("Create", ctx, mock.AnythingOfType("*domain.EventParticipant")).Return(nil)
+
+	participants, err := useCase.AddParticipants(ctx, organizer, event, []string{"Ana", "+55 11 97777-6666", "Bruno", "ana"})
+
+	require.NoError(t, err)
+	require.Len(t, participants, 3)
+
+	assert.Equal(t, "Ana", *participants[0].Name)
+	assert.Equal(t, "5511988887777", *participants[0].WANumber, "numbers are stored as inbound messages carry them")
+	assert.True(t, participants[0].IsInvited())
+
+	assert.Equal(t, "5511977776666", *participants[1].WANumber)
+	assert.False(t, participants[1].IsInvited(), "numbers outside the allowed contacts are not invited")
+
+	assert.Nil(t, participants[2].WANumber)
+	assert.False(t, participants[2].IsInvited())
+
+	sender.AssertExpectations(t)
+	mockRepos.contactRepo.AssertExpectations(t)
+}
+
+func TestParticipantUseCase_AddParticipants_SendFails(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		participantRepo: &MockEventParticipantRepository{},
+		contactRepo:     &MockUserAllowedContactRepository{},
+	}
+	sender := &MockWhatsAppSender{}
+
+	useCase := NewParticipantUseCase(mockRepos, sender, defaultMessages(t), infra.NewRealTimeProvider(), zap.NewNop())
+
+	organizer := &domain.User{ID: 1, WANumber: "+5511999999999"}
+	event := &domain.Event{ID: 10, UserID: 1, Title: "Reunião", StartsAt: time.Now().Add(24 * time.Hour)}
+
+	mockRepos.contactRepo.On("List", ctx, 1).Return([]domain.UserAllowedContact{
+		{UserID: 1, ContactNumber: "+5511988887777"},
+	}, nil)
+	sender.On("SendText", ctx, "5511988887777", mock.AnythingOfType("string")).Return(errors.New("infobip API error 503: unavailable"))
+	mockRepos.participantRepo.On("Create", ctx, mock.AnythingOfType("*domain.EventParticipant")).Return(nil)
+
+	participants, err := useCase.AddParticipants(ctx, organizer, event, []string{"+55 11 98888-7777"})
+
+	require.NoError(t, err, "a failed invitation does not fail the event")
+	require.Len(t, participants, 1)
+	assert.False(t, participants[0].IsInvited())
+}
+
+func TestParticipantUseCase_InviteParticipants(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		participantRepo: &MockEventParticipantRepository{},
+		contactRepo:     &MockUserAllowedContactRepository{},
+	}
+	sender := &MockWhatsAppSender{}
+
+	useCase := NewParticipantUseCase(mockRepos, sender, defaultMessages(t), infra.NewRealTimeProvider(), zap.NewNop())
+
+	organizer := &domain.User{ID: 1, WANumber: "+5511999999999"}
+	event := &domain.Event{ID: 10, UserID: 1, Title: "Reunião", StartsAt: time.Now().Add(24 * time.Hour)}
+	number := "5511988887777"
+
+	mockRepos.participantRepo.On("ListByEventID", ctx, 10).Return([]domain.EventParticipant{
+		{ID: 3, EventID: 10, WANumber: &number, Status: domain.ParticipantStatusPending},
+	}, nil)
+	mockRepos.contactRepo.On("List", ctx, 1).Return([]domain.UserAllowedContact{
+		{UserID: 1, ContactNumber: "+5511988887777"},
+	}, nil)
+	sender.On("SendText", ctx, number, mock.AnythingOfType("string")).Return(nil).Once()
+	mockRepos.participantRepo.On("MarkInvited", ctx, 3, mock.AnythingOfType("time.Time")).Return(nil)
+
+	participants, err := useCase.InviteParticipants(ctx, organizer, event)
+
+	require.NoError(t, err)
+	require.Len(t, participants, 1)
+	assert.True(t, participants[0].IsInvited(), "stored numbers still match contacts saved with a +")
+
+	sender.AssertExpectations(t)
+	mockRepos.participantRepo.AssertExpectations(t)
+}
+
+func TestParticipantUseCase_RespondToInvitation(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		userRepo:        &MockUserRepository{},
+		eventRepo:       &MockEventRepository{},
+		participantRepo: &MockEventParticipantRepository{},
+	}
+	sender := &MockWhatsAppSender{}
+
+	useCase := NewParticipantUseCase(mockRepos, sender, defaultMessages(t), infra.NewRealTimeProvider(), zap.NewNop())
+
+	name := "Ana"
+	number := "+5511988887777"
+	invitedAt := time.Now().Add(-time.Hour)
+	invitation := domain.EventParticipant{
+		ID:        3,
+		EventID:   10,
+		Name:      &name,
+		WANumber:  &number,
+		Status:    domain.ParticipantStatusPending,
+		InvitedAt: &invitedAt,
+	}
+	event := &domain.Event{ID: 10, UserID: 1, Title: "Reunião", StartsAt: time.Now().Add(24 * time.Hour)}
+	organizer := &domain.User{ID: 1, WANumber: "+5511999999999"}
+
+	mockRepos.participantRepo.On("ListActiveInvitationsByNumber", ctx, number, mock.Anything).Return([]domain.EventParticipant{invitation}, nil)
+	mockRepos.eventRepo.On("GetByID", ctx, 10).Return(event, nil)
+	mockRepos.participantRepo.On("UpdateStatus", ctx, 3, domain.ParticipantStatusAccepted).Return(nil)
+	mockRepos.userRepo.On("GetByID", ctx, 1).Return(organizer, nil)
+	sender.On("SendToUser", ctx, organizer, "✅ Ana confirmou presença em Reunião.").Return(nil)
+
+	gotEvent, participant, err := useCase.RespondToInvitation(ctx, number, true, nil)
+
+	require.NoError(t, err)
+	require.NotNil(t, participant)
+	assert.Equal(t, domain.ParticipantStatusAccepted, participant.Status)
+	assert.Equal(t, event, gotEvent)
+
+	sender.AssertExpectations(t)
+	mockRepos.participantRepo.AssertExpectations(t)
+}
+
+func TestParticipantUseCase_RespondToInvitation_NoInvitation(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepos := &MockRepositories{
+		participantRepo: &MockEventParticipantRepository{},
+	}
+
+	useCase := NewParticipantUseCase(mockRepos, &MockWhatsAppSender{}, defaultMessages(t), infra.NewRealTimeProvider(), zap.NewNop())
+
+	mockRepos.participantRepo.On("ListActiveInvitationsByNumber", ctx, "+5511900000000", mock.Anything).Return([]domain.EventParticipant{}, nil)
+
+	event, participant, err := useCase.RespondToInvitation(ctx, "+5511900000000", false, nil)
+
+	assert.NoError(t, err)
+	assert.Nil(t, event)
+	assert.Nil(t, participant)
+}
@@ -0,0 +1,217 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+	"github.com/alarm-agent/internal/usecase"
+)
+
+// maxDigestEvents keeps digests readable on a phone screen.
+const maxDigestEvents = 20
+
+// DigestWorker sends each opted-in user a morning agenda for the day and a
+// Sunday overview of the coming week, at their chosen local time.
+type DigestWorker struct {
+	repos        ports.Repositories
+	eventUseCase *usecase.EventUseCase
+	messages     ports.MessageRenderer
+	timeProvider ports.TimeProvider
+	logger       *zap.Logger
+	tickInterval time.Duration
+	stopCh       chan struct{}
+}
+
+func NewDigestWorker(
+	repos ports.Repositories,
+	eventUseCase *usecase.EventUseCase,
+	messages ports.MessageRenderer,
+	timeProvider ports.TimeProvider,
+	logger *zap.Logger,
+	tickInterval time.Duration,
+) *DigestWorker {
+	return &DigestWorker{
+		repos:        repos,
+		eventUseCase: eventUseCase,
+		messages:     messages,
+		timeProvider: timeProvider,
+		logger:       logger,
+		tickInterval: tickInterval,
+		stopCh:       make(chan struct{}),
+	}
+}
+
+func (w *DigestWorker) Start(ctx context.Context) error {
+	w.logger.Info("Starting digest worker", zap.Duration("tick_interval", w.tickInterval))
+
+	ticker := time.NewTicker(w.tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Digest worker stopped by context")
+			return ctx.Err()
+		case <-w.stopCh:
+			w.logger.Info("Digest worker stopped")
+			return nil
+		case <-ticker.C:
+			if err := w.processDigests(ctx); err != nil {
+				w.logger.Error("Failed to process digests", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (w *DigestWorker) Stop() {
+	close(w.stopCh)
+}
+
+// RunOnce queues the digests due at the provider's current time.
+func (w *DigestWorker) RunOnce(ctx context.Context) error {
+	return w.processDigests(ctx)
+}
+
+func (w *DigestWorker) processDigests(ctx context.Context) error {
+	users, err := w.repos.User().ListWithDigests(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get users with digests: %w", err)
+	}
+
+	now := w.timeProvider.Now()
+	for i := range users {
+		for _, kind := range []domain.DigestKind{domain.DigestDaily, domain.DigestWeekly} {
+			if err := w.processUserDigest(ctx, &users[i], kind, now); err != nil {
+				w.logger.Error("Failed to send digest",
+					zap.Error(err),
+					zap.Int("user_id", users[i].ID),
+					zap.String("kind", string(kind)),
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (w *DigestWorker) processUserDigest(ctx context.Context, user *domain.User, kind domain.DigestKind, now time.Time) error {
+	schedule := user.Digest(kind)
+	loc := user.Location()
+
+	due, ok := schedule.DueAt(now, loc)
+	if !ok {
+		return nil
+	}
+
+	start, end := schedule.Period(due, loc)
+	events, err := w.eventUseCase.ListEvents(ctx, user.ID, &start, &end)
+	if err != nil {
+		return fmt.Errorf("failed to list events: %w", err)
+	}
+
+	var active []domain.Event
+	for _, event := range events {
+		if event.IsActive() {
+			active = append(active, event)
+		}
+	}
+
+	body, err := w.messages.Render(ctx, user, digestTemplate(kind), digestMessageData(kind, active, start, end, loc, domain.NewTimeRenderer(now, loc, user.Language())))
+	if err != nil {
+		return fmt.Errorf("failed to render digest: %w", err)
+	}
+
+	message := domain.NewUserMessage(user, body, now)
+	err = w.repos.WithTx(ctx, func(tx ports.Repositories) error {
+		if err := tx.User().MarkDigestSent(ctx, user.ID, kind, now); err != nil {
+			return fmt.Errorf("failed to mark digest sent: %w", err)
+		}
+		if err := tx.OutboundMessage().Create(ctx, message); err != nil {
+			return fmt.Errorf("failed to queue digest message: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	w.logger.Info("Queued digest",
+		zap.Int("user_id", user.ID),
+		zap.String("kind", string(kind)),
+		zap.Int("events", len(active)),
+	)
+
+	return nil
+}
+
+func digestTemplate(kind domain.DigestKind) string {
+	if kind == domain.DigestWeekly {
+		return domain.TemplateWeeklyDigest
+	}
+	return domain.TemplateDailyDigest
+}
+
+// digestMessageData lists the events of the period, grouped by day for the
+// weekly digest, with dates and times phrased by when.
+func digestMessageData(kind domain.DigestKind, events []domain.Event, start, end time.Time, loc *time.Location, when domain.TimeRenderer) domain.MessageData {
+	data := domain.MessageData{"More": 0}
+	if len(events) > maxDigestEvents {
+		data["More"] = len(events) - maxDigestEvents
+		events = events[:maxDigestEvents]
+	}
+
+	if kind == domain.DigestDaily {
+		items := make([]domain.MessageData, len(events))
+		for i := range events {
+			items[i] = digestItem(&events[i], loc, when)
+		}
+		data["Date"] = when.Date(start)
+		data["Events"] = items
+		return data
+	}
+
+	var days []domain.MessageData
+	var day time.Time
+	for i := range events {
+		eventDay := domain.StartOfDay(events[i].StartsAt, loc)
+		if len(days) == 0 || !eventDay.Equal(day) {
+			day = eventDay
+			if day.Before(start) {
+				day = start
+			}
+			days = append(days, domain.MessageData{
+				"Label":  when.Weekday(day),
+				"Events": []domain.MessageData{},
+			})
+		}
+		current := days[len(days)-1]
+		current["Events"] = append(current["Events"].([]domain.MessageData), digestItem(&events[i], loc, when))
+	}
+	data["Start"] = when.Date(start)
+	data["End"] = when.Date(end)
+	data["Days"] = days
+	return data
+}
+
+func digestItem(event *domain.Event, loc *time.Location, when domain.TimeRenderer) domain.MessageData {
+	return domain.MessageData{"Event": event, "Time": digestTime(event, loc, when)}
+}
+
+// digestTime renders the time of an event within its day, e.g. "14h" or
+// "14h–15h30".
+func digestTime(event *domain.Event, loc *time.Location, when domain.TimeRenderer) string {
+	start := event.StartsAt.In(loc)
+	if event.EndsAt == nil {
+		return when.Clock(start)
+	}
+	end := event.EndsAt.In(loc)
+	if !domain.StartOfDay(start, loc).Equal(domain.StartOfDay(end, loc)) {
+		return when.Clock(start)
+	}
+	return fmt.Sprintf("%s–%s", when.Clock(start), when.Clock(end))
+}
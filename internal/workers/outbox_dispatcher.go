@@ -0,0 +1,227 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+// maxOutboxBatchSize bounds how many messages one dispatch claims, so a
+// backlog is spread across instances.
+const maxOutboxBatchSize = 50
+
+// outboxSendTimeout bounds a single delivery. Together with the lease it
+// decides how many messages a batch can hold.
+const outboxSendTimeout = 30 * time.Second
+
+// OutboxDispatcher delivers the messages queued in the outbox through the
+// sender of their channel. Failed deliveries are retried with exponential backoff
+// until the retry policy gives up and the message becomes a dead letter.
+type OutboxDispatcher struct {
+	repos         ports.Repositories
+	senders       map[domain.Channel]ports.ChannelSender
+	timeProvider  ports.TimeProvider
+	logger        *zap.Logger
+	tickInterval  time.Duration
+	instanceID    string
+	leaseDuration time.Duration
+	batchSize     int
+	policy        domain.RetryPolicy
+	wakeCh        chan struct{}
+	stopCh        chan struct{}
+}
+
+func NewOutboxDispatcher(
+	repos ports.Repositories,
+	senders []ports.ChannelSender,
+	timeProvider ports.TimeProvider,
+	logger *zap.Logger,
+	tickInterval time.Duration,
+	instanceID string,
+	leaseDuration time.Duration,
+	policy domain.RetryPolicy,
+) *OutboxDispatcher {
+	byChannel := make(map[domain.Channel]ports.ChannelSender, len(senders))
+	for _, sender := range senders {
+		byChannel[sender.Channel()] = sender
+	}
+
+	return &OutboxDispatcher{
+		repos:         repos,
+		senders:       byChannel,
+		timeProvider:  timeProvider,
+		logger:        logger,
+		tickInterval:  tickInterval,
+		instanceID:    instanceID,
+		leaseDuration: leaseDuration,
+		batchSize:     outboxBatchSize(leaseDuration),
+		policy:        policy,
+		wakeCh:        make(chan struct{}, 1),
+		stopCh:        make(chan struct{}),
+	}
+}
+
+func (d *OutboxDispatcher) Start(ctx context.Context) error {
+	d.logger.Info("Starting outbox dispatcher",
+		zap.Duration("tick_interval", d.tickInterval),
+		zap.String("instance_id", d.instanceID),
+	)
+
+	ticker := time.NewTicker(d.tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.Info("Outbox dispatcher stopped by context")
+			return ctx.Err()
+		case <-d.stopCh:
+			d.logger.Info("Outbox dispatcher stopped")
+			return nil
+		case <-ticker.C:
+		case <-d.wakeCh:
+		}
+
+		if err := d.dispatch(ctx); err != nil {
+			d.logger.Error("Failed to dispatch outbox", zap.Error(err))
+		}
+	}
+}
+
+func (d *OutboxDispatcher) Stop() {
+	close(d.stopCh)
+}
+
+// Wake asks for a dispatch without waiting for the next tick. It never
+// blocks; wakes sent while one is pending are merged.
+func (d *OutboxDispatcher) Wake() {
+	select {
+	case d.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// RunOnce delivers the messages due at the provider's current time.
+func (d *OutboxDispatcher) RunOnce(ctx context.Context) error {
+	return d.dispatch(ctx)
+}
+
+// dispatch delivers due messages until none are left or a batch comes back
+// short.
+func (d *OutboxDispatcher) dispatch(ctx context.Context) error {
+	for {
+		now := d.timeProvider.Now()
+		lease := domain.Lease{Owner: d.instanceID, ExpiresAt: now.Add(d.leaseDuration)}
+		messages, err := d.repos.OutboundMessage().ClaimDue(ctx, now, d.batchSize, lease)
+		if err != nil {
+			return fmt.Errorf("failed to claim outbound messages: %w", err)
+		}
+
+		for i := range messages {
+			if err := d.deliver(ctx, &messages[i]); err != nil {
+				return err
+			}
+		}
+
+		if len(messages) < d.batchSize {
+			return nil
+		}
+	}
+}
+
+// outboxBatchSize is how many messages can be sent, each taking up to
+// outboxSendTimeout, before their lease runs out and another instance claims
+// them again.
+func outboxBatchSize(lease time.Duration) int {
+	size := int(lease / outboxSendTimeout)
+	switch {
+	case size < 1:
+		return 1
+	case size > maxOutboxBatchSize:
+		return maxOutboxBatchSize
+	}
+	return size
+}
+
+func (d *OutboxDispatcher) deliver(ctx context.Context, message *domain.OutboundMessage) error {
+	sendCtx, cancel := context.WithTimeout(ctx, outboxSendTimeout)
+	sendErr := d.send(sendCtx, message)
+	cancel()
+	now := d.timeProvider.Now()
+
+	if sendErr == nil {
+		message.MarkSent(now)
+	} else {
+		message.MarkFailed(sendErr, now, d.policy)
+	}
+
+	if err := d.repos.OutboundMessage().Update(ctx, message); err != nil {
+		return fmt.Errorf("failed to update outbound message %d: %w", message.ID, err)
+	}
+
+	switch message.Status {
+	case domain.OutboundMessageSent:
+		d.logger.Debug("Delivered outbound message",
+			zap.Int("message_id", message.ID),
+			zap.String("to", message.ToNumber),
+		)
+	case domain.OutboundMessageDead:
+		d.logger.Error("Outbound message moved to dead letters",
+			zap.Error(sendErr),
+			zap.Int("message_id", message.ID),
+			zap.String("to", message.ToNumber),
+			zap.Int("attempts", message.Attempts),
+		)
+	default:
+		d.logger.Warn("Outbound message delivery failed, will retry",
+			zap.Error(sendErr),
+			zap.Int("message_id", message.ID),
+			zap.Int("attempts", message.Attempts),
+			zap.Time("next_attempt_at", message.NextAttemptAt),
+		)
+	}
+
+	return nil
+}
+
+// send delivers the message through the sender of its channel, as its
+// template when it has no text or the recipient's session window is closed.
+func (d *OutboxDispatcher) send(ctx context.Context, message *domain.OutboundMessage) error {
+	sender, ok := d.senders[message.Channel.OrDefault()]
+	if !ok {
+		return fmt.Errorf("no sender for channel %s", message.Channel)
+	}
+
+	if message.Template != nil {
+		inSession := false
+		if message.Body != "" {
+			var err error
+			if inSession, err = d.inSession(ctx, message); err != nil {
+				return err
+			}
+		}
+		if !inSession {
+			return sender.SendTemplate(ctx, message.ToNumber, *message.Template)
+		}
+	}
+
+	if message.Interactive != nil {
+		return sender.SendInteractive(ctx, message.ToNumber, message.Body, *message.Interactive)
+	}
+	return sender.SendText(ctx, message.ToNumber, message.Body)
+}
+
+// inSession reports whether the recipient wrote from their identity within
+// the session window, so free-form messages still reach them.
+func (d *OutboxDispatcher) inSession(ctx context.Context, message *domain.OutboundMessage) (bool, error) {
+	identity, err := d.repos.UserIdentity().GetByChannelID(ctx, message.Channel.OrDefault(), message.ToNumber)
+	if err != nil {
+		return false, fmt.Errorf("failed to get recipient identity: %w", err)
+	}
+	return identity != nil && identity.InSession(d.timeProvider.Now()), nil
+}
@@ -0,0 +1,218 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+// memoryOutbox keeps outbound messages in memory; leases are not needed by
+// these single-instance tests.
+type memoryOutbox struct {
+	ports.OutboundMessageRepository
+	messages []domain.OutboundMessage
+}
+
+func (o *memoryOutbox) ClaimDue(ctx context.Context, now time.Time, limit int, lease domain.Lease) ([]domain.OutboundMessage, error) {
+	var due []domain.OutboundMessage
+	for _, message := range o.messages {
+		if message.Status == domain.OutboundMessagePending && !message.NextAttemptAt.After(now) && len(due) < limit {
+			due = append(due, message)
+		}
+	}
+	return due, nil
+}
+
+func (o *memoryOutbox) Update(ctx context.Context, message *domain.OutboundMessage) error {
+	for i := range o.messages {
+		if o.messages[i].ID == message.ID {
+			o.messages[i] = *message
+		}
+	}
+	return nil
+}
+
+// staticIdentities knows one identity.
+type staticIdentities struct {
+	ports.UserIdentityRepository
+	identity domain.UserIdentity
+}
+
+func (r *staticIdentities) GetByChannelID(ctx context.Context, channel domain.Channel, externalID string) (*domain.UserIdentity, error) {
+	if channel != r.identity.Channel || externalID != r.identity.ExternalID {
+		return nil, nil
+	}
+	identity := r.identity
+	return &identity, nil
+}
+
+type failingSender struct {
+	failures    int
+	sent        int
+	interactive int
+	templates   int
+}
+
+func (s *failingSender) Channel() domain.Channel {
+	return domain.ChannelWhatsApp
+}
+
+func (s *failingSender) SendText(ctx context.Context, to, text string) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("infobip API error 503: unavailable")
+	}
+	s.sent++
+	return nil
+}
+
+func (s *failingSender) SendInteractive(ctx context.Context, to, text string, interactive domain.Interactive) error {
+	if err := s.SendText(ctx, to, text); err != nil {
+		return err
+	}
+	s.interactive++
+	return nil
+}
+
+func (s *failingSender) SendTemplate(ctx context.Context, to string, template domain.TemplateMessage) error {
+	if err := s.SendText(ctx, to, template.Name); err != nil {
+		return err
+	}
+	s.templates++
+	return nil
+}
+
+func TestOutboxDispatcher_Dispatch(t *testing.T) {
+	start := time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC)
+	policy := domain.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: 10 * time.Minute}
+
+	newDispatcher := func(sender ports.ChannelSender) (*OutboxDispatcher, *memoryOutbox) {
+		message := domain.NewOutboundMessage("+5511900000001", "⏰ Lembrete", start)
+		message.ID = 1
+		outbox := &memoryOutbox{messages: []domain.OutboundMessage{*message}}
+		lastInbound := start.Add(-time.Hour)
+		repos := &memoryRepositories{outbox: outbox, identities: &staticIdentities{identity: domain.UserIdentity{
+			Channel:       domain.ChannelWhatsApp,
+			ExternalID:    "+5511900000001",
+			LastInboundAt: &lastInbound,
+		}}}
+		dispatcher := NewOutboxDispatcher(repos, []ports.ChannelSender{sender}, fixedTimeProvider{now: start}, zap.NewNop(), time.Second, "worker-a", time.Minute, policy)
+		return dispatcher, outbox
+	}
+
+	dispatchAt := func(t *testing.T, dispatcher *OutboxDispatcher, at time.Time) {
+		dispatcher.timeProvider = fixedTimeProvider{now: at}
+		require.NoError(t, dispatcher.dispatch(context.Background()))
+	}
+
+	t.Run("delivered", func(t *testing.T) {
+		sender := &failingSender{}
+		dispatcher, outbox := newDispatcher(sender)
+
+		dispatchAt(t, dispatcher, start)
+		dispatchAt(t, dispatcher, start.Add(time.Hour))
+
+		assert.Equal(t, 1, sender.sent)
+		assert.Equal(t, domain.OutboundMessageSent, outbox.messages[0].Status)
+		assert.Equal(t, 1, outbox.messages[0].Attempts)
+	})
+
+	t.Run("interactive", func(t *testing.T) {
+		sender := &failingSender{}
+		dispatcher, outbox := newDispatcher(sender)
+		outbox.messages[0].Interactive = &domain.Interactive{Buttons: []domain.ReplyOption{{ID: "confirm:1", Title: "Confirmar"}}}
+
+		dispatchAt(t, dispatcher, start)
+
+		assert.Equal(t, 1, sender.interactive)
+		assert.Equal(t, domain.OutboundMessageSent, outbox.messages[0].Status)
+	})
+
+	t.Run("template outside the session window", func(t *testing.T) {
+		sender := &failingSender{}
+		dispatcher, outbox := newDispatcher(sender)
+		outbox.messages[0].Template = &domain.TemplateMessage{Name: "alarm_reminder", Language: "pt_BR"}
+
+		dispatchAt(t, dispatcher, start)
+		assert.Equal(t, 0, sender.templates, "the user wrote an hour ago")
+
+		outbox.messages[0].Status = domain.OutboundMessagePending
+		dispatchAt(t, dispatcher, start.Add(23*time.Hour))
+		assert.Equal(t, 1, sender.templates)
+		assert.Equal(t, 2, sender.sent)
+	})
+
+	t.Run("retried with backoff", func(t *testing.T) {
+		sender := &failingSender{failures: 1}
+		dispatcher, outbox := newDispatcher(sender)
+
+		dispatchAt(t, dispatcher, start)
+		assert.Equal(t, domain.OutboundMessagePending, outbox.messages[0].Status)
+		assert.Equal(t, start.Add(time.Minute), outbox.messages[0].NextAttemptAt)
+		require.NotNil(t, outbox.messages[0].LastError)
+
+		dispatchAt(t, dispatcher, start.Add(30*time.Second))
+		assert.Equal(t, 0, sender.sent)
+
+		dispatchAt(t, dispatcher, start.Add(time.Minute))
+		assert.Equal(t, 1, sender.sent)
+		assert.Equal(t, domain.OutboundMessageSent, outbox.messages[0].Status)
+		assert.Nil(t, outbox.messages[0].LastError)
+	})
+
+	t.Run("dead after max attempts", func(t *testing.T) {
+		sender := &failingSender{failures: 10}
+		dispatcher, outbox := newDispatcher(sender)
+
+		dispatchAt(t, dispatcher, start)
+		dispatchAt(t, dispatcher, start.Add(time.Minute))
+		assert.Equal(t, start.Add(3*time.Minute), outbox.messages[0].NextAttemptAt)
+		dispatchAt(t, dispatcher, start.Add(3*time.Minute))
+		dispatchAt(t, dispatcher, start.Add(time.Hour))
+
+		assert.Equal(t, domain.OutboundMessageDead, outbox.messages[0].Status)
+		assert.Equal(t, 3, outbox.messages[0].Attempts)
+		assert.Equal(t, 7, sender.failures)
+	})
+
+	t.Run("channel without sender", func(t *testing.T) {
+		sender := &failingSender{}
+		dispatcher, outbox := newDispatcher(sender)
+		outbox.messages[0].Channel = domain.ChannelTelegram
+
+		dispatchAt(t, dispatcher, start)
+
+		assert.Equal(t, 0, sender.sent)
+		assert.Equal(t, domain.OutboundMessagePending, outbox.messages[0].Status)
+		require.NotNil(t, outbox.messages[0].LastError)
+		assert.Contains(t, *outbox.messages[0].LastError, "no sender for channel telegram")
+	})
+}
+
+func TestOutboxBatchSize(t *testing.T) {
+	tests := []struct {
+		lease time.Duration
+		want  int
+	}{
+		{lease: 10 * time.Second, want: 1},
+		{lease: time.Minute, want: 2},
+		{lease: 5 * time.Minute, want: 10},
+		{lease: time.Hour, want: maxOutboxBatchSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lease.String(), func(t *testing.T) {
+			size := outboxBatchSize(tt.lease)
+			assert.Equal(t, tt.want, size)
+			assert.LessOrEqual(t, time.Duration(size)*outboxSendTimeout, max(tt.lease, outboxSendTimeout))
+		})
+	}
+}
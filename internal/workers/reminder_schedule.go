@@ -0,0 +1,95 @@
+package workers
+
+import (
+	"container/heap"
+	"time"
+)
+
+// reminderSchedule is a min-heap of the next instant each event needs the
+// reminder worker, with at most one entry per event.
+type reminderSchedule struct {
+	entries []*scheduledEvent
+	byEvent map[int]*scheduledEvent
+}
+
+type scheduledEvent struct {
+	eventID int
+	at      time.Time
+	index   int
+}
+
+func newReminderSchedule() *reminderSchedule {
+	return &reminderSchedule{byEvent: make(map[int]*scheduledEvent)}
+}
+
+// Set schedules the event at at, replacing its previous entry.
+func (s *reminderSchedule) Set(eventID int, at time.Time) {
+	if entry, ok := s.byEvent[eventID]; ok {
+		entry.at = at
+		heap.Fix(s, entry.index)
+		return
+	}
+	entry := &scheduledEvent{eventID: eventID, at: at}
+	s.byEvent[eventID] = entry
+	heap.Push(s, entry)
+}
+
+// Remove drops the event from the schedule, if present.
+func (s *reminderSchedule) Remove(eventID int) {
+	if entry, ok := s.byEvent[eventID]; ok {
+		heap.Remove(s, entry.index)
+	}
+}
+
+// At returns when the event is scheduled.
+func (s *reminderSchedule) At(eventID int) (time.Time, bool) {
+	entry, ok := s.byEvent[eventID]
+	if !ok {
+		return time.Time{}, false
+	}
+	return entry.at, true
+}
+
+// Next returns the earliest scheduled instant.
+func (s *reminderSchedule) Next() (time.Time, bool) {
+	if len(s.entries) == 0 {
+		return time.Time{}, false
+	}
+	return s.entries[0].at, true
+}
+
+// PopDue removes and returns the events scheduled at or before now.
+func (s *reminderSchedule) PopDue(now time.Time) []int {
+	var due []int
+	for len(s.entries) > 0 && !s.entries[0].at.After(now) {
+		due = append(due, heap.Pop(s).(*scheduledEvent).eventID)
+	}
+	return due
+}
+
+// heap.Interface, used through the methods above.
+
+func (s *reminderSchedule) Len() int { return len(s.entries) }
+
+func (s *reminderSchedule) Less(i, j int) bool { return s.entries[i].at.Before(s.entries[j].at) }
+
+func (s *reminderSchedule) Swap(i, j int) {
+	s.entries[i], s.entries[j] = s.entries[j], s.entries[i]
+	s.entries[i].index = i
+	s.entries[j].index = j
+}
+
+func (s *reminderSchedule) Push(x any) {
+	entry := x.(*scheduledEvent)
+	entry.index = len(s.entries)
+	s.entries = append(s.entries, entry)
+}
+
+func (s *reminderSchedule) Pop() any {
+	last := len(s.entries) - 1
+	entry := s.entries[last]
+	s.entries[last] = nil
+	s.entries = s.entries[:last]
+	delete(s.byEvent, entry.eventID)
+	return entry
+}
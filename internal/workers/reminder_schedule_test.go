@@ -0,0 +1,36 @@
+package workers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReminderSchedule(t *testing.T) {
+	base := time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC)
+	schedule := newReminderSchedule()
+
+	_, ok := schedule.Next()
+	assert.False(t, ok)
+
+	schedule.Set(1, base.Add(30*time.Minute))
+	schedule.Set(2, base.Add(10*time.Minute))
+	schedule.Set(3, base.Add(20*time.Minute))
+
+	next, ok := schedule.Next()
+	assert.True(t, ok)
+	assert.Equal(t, base.Add(10*time.Minute), next)
+
+	// Rescheduling replaces the event's entry.
+	schedule.Set(1, base.Add(5*time.Minute))
+	schedule.Remove(3)
+	assert.Equal(t, 2, schedule.Len())
+
+	assert.Empty(t, schedule.PopDue(base))
+	assert.Equal(t, []int{1, 2}, schedule.PopDue(base.Add(10*time.Minute)))
+	assert.Equal(t, 0, schedule.Len())
+
+	_, ok = schedule.At(1)
+	assert.False(t, ok)
+}
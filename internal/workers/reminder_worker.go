@@ -3,7 +3,6 @@ package workers
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -12,39 +11,92 @@ import (
 	"github.com/alarm-agent/internal/ports"
 )
 
+// scheduleBatchSize is how many events are loaded per query when the
+// reminder schedule is rebuilt.
+const scheduleBatchSize = 500
+
+// stillDueRetryDelay is how long the worker waits before looking again at an
+// event that is still due after a run, because another instance holds its
+// lease or processing it failed.
+const stillDueRetryDelay = 30 * time.Second
+
+// ReminderWorker sends due reminders and moves events through their
+// lifecycle. It keeps the next instant each active event needs attention in
+// an in-memory schedule, loaded from the database on start and kept in sync
+// through changes, and wakes up exactly when the earliest one is due. The
+// schedule is rebuilt every resyncInterval as a safety net.
+//
+// Several instances can run side by side: each one leases the events it
+// works on under its instanceID, so an event is handled by a single instance
+// until it releases the lease or leaseDuration passes. Reminders that became
+// due while no instance was running are handled by catchUp.
 type ReminderWorker struct {
 	repos          ports.Repositories
-	whatsappSender ports.WhatsAppSender
+	changes        ports.EventChangeFeed
+	messages       ports.MessageRenderer
 	timeProvider   ports.TimeProvider
 	logger         *zap.Logger
-	tickInterval   time.Duration
+	resyncInterval time.Duration
+	instanceID     string
+	leaseDuration  time.Duration
+	catchUp        domain.CatchUpPolicy
+	templates      domain.RegisteredTemplates
+	schedule       *reminderSchedule
 	stopCh         chan struct{}
 }
 
 func NewReminderWorker(
 	repos ports.Repositories,
-	whatsappSender ports.WhatsAppSender,
+	changes ports.EventChangeFeed,
+	messages ports.MessageRenderer,
 	timeProvider ports.TimeProvider,
 	logger *zap.Logger,
-	tickInterval time.Duration,
+	resyncInterval time.Duration,
+	instanceID string,
+	leaseDuration time.Duration,
+	catchUp domain.CatchUpPolicy,
+	templates domain.RegisteredTemplates,
 ) *ReminderWorker {
 	return &ReminderWorker{
 		repos:          repos,
-		whatsappSender: whatsappSender,
+		changes:        changes,
+		messages:       messages,
 		timeProvider:   timeProvider,
 		logger:         logger,
-		tickInterval:   tickInterval,
+		resyncInterval: resyncInterval,
+		instanceID:     instanceID,
+		leaseDuration:  leaseDuration,
+		catchUp:        catchUp,
+		templates:      templates,
+		schedule:       newReminderSchedule(),
 		stopCh:         make(chan struct{}),
 	}
 }
 
 func (w *ReminderWorker) Start(ctx context.Context) error {
-	w.logger.Info("Starting reminder worker", zap.Duration("tick_interval", w.tickInterval))
+	w.logger.Info("Starting reminder worker",
+		zap.Duration("resync_interval", w.resyncInterval),
+		zap.String("instance_id", w.instanceID),
+		zap.String("catch_up_mode", string(w.catchUp.Mode)),
+	)
+
+	changes, err := w.changes.Subscribe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to event changes: %w", err)
+	}
 
-	ticker := time.NewTicker(w.tickInterval)
-	defer ticker.Stop()
+	// Reminders missed while the service was down are caught up right away.
+	w.resync(ctx)
+
+	resync := time.NewTicker(w.resyncInterval)
+	defer resync.Stop()
+
+	wake := time.NewTimer(w.resyncInterval)
+	defer wake.Stop()
 
 	for {
+		w.armWakeTimer(wake)
+
 		select {
 		case <-ctx.Done():
 			w.logger.Info("Reminder worker stopped by context")
@@ -52,10 +104,22 @@ func (w *ReminderWorker) Start(ctx context.Context) error {
 		case <-w.stopCh:
 			w.logger.Info("Reminder worker stopped")
 			return nil
-		case <-ticker.C:
-			if err := w.processReminders(ctx); err != nil {
-				w.logger.Error("Failed to process reminders", zap.Error(err))
+		case eventID, ok := <-changes:
+			if !ok {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return fmt.Errorf("event change feed closed")
 			}
+			if eventID == 0 {
+				w.resync(ctx)
+				continue
+			}
+			w.reschedule(ctx, eventID)
+		case <-wake.C:
+			w.processDue(ctx)
+		case <-resync.C:
+			w.resync(ctx)
 		}
 	}
 }
@@ -64,11 +128,125 @@ func (w *ReminderWorker) Stop() {
 	close(w.stopCh)
 }
 
+// armWakeTimer sets the timer to fire when the earliest scheduled event is
+// due, or stops it when nothing is scheduled.
+func (w *ReminderWorker) armWakeTimer(timer *time.Timer) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+
+	if next, ok := w.schedule.Next(); ok {
+		delay := next.Sub(w.timeProvider.Now())
+		if delay < 0 {
+			delay = 0
+		}
+		timer.Reset(delay)
+	}
+}
+
+// processDue runs the reminder cycle for the events that are due and
+// reschedules them.
+func (w *ReminderWorker) processDue(ctx context.Context) {
+	now := w.timeProvider.Now()
+	due := w.schedule.PopDue(now)
+	if len(due) == 0 {
+		return
+	}
+
+	if err := w.processReminders(ctx); err != nil {
+		w.logger.Error("Failed to process reminders", zap.Error(err))
+	}
+
+	for _, eventID := range due {
+		w.reschedule(ctx, eventID)
+		// An event still due after the run is leased by another instance or
+		// failed; look at it again later instead of spinning on it.
+		if at, ok := w.schedule.At(eventID); ok && !at.After(now) {
+			w.schedule.Set(eventID, now.Add(stillDueRetryDelay))
+		}
+	}
+}
+
+// resync processes whatever is due and rebuilds the schedule from the
+// database.
+func (w *ReminderWorker) resync(ctx context.Context) {
+	if err := w.processReminders(ctx); err != nil {
+		w.logger.Error("Failed to process reminders", zap.Error(err))
+	}
+
+	if err := w.loadSchedule(ctx); err != nil {
+		w.logger.Error("Failed to load reminder schedule", zap.Error(err))
+	}
+}
+
+func (w *ReminderWorker) loadSchedule(ctx context.Context) error {
+	schedule := newReminderSchedule()
+
+	afterID := 0
+	for {
+		events, err := w.repos.Event().ListActive(ctx, afterID, scheduleBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list active events: %w", err)
+		}
+		for i := range events {
+			w.scheduleEvent(schedule, &events[i])
+		}
+		if len(events) < scheduleBatchSize {
+			break
+		}
+		afterID = events[len(events)-1].ID
+	}
+
+	w.schedule = schedule
+	w.logger.Debug("Loaded reminder schedule", zap.Int("events", schedule.Len()))
+	return nil
+}
+
+// reschedule reloads a changed event and updates its place in the schedule.
+func (w *ReminderWorker) reschedule(ctx context.Context, eventID int) {
+	event, err := w.repos.Event().GetByID(ctx, eventID)
+	if err != nil {
+		w.logger.Error("Failed to reload event", zap.Error(err), zap.Int("event_id", eventID))
+		return
+	}
+	if event == nil {
+		w.schedule.Remove(eventID)
+		return
+	}
+	w.scheduleEvent(w.schedule, event)
+}
+
+func (w *ReminderWorker) scheduleEvent(schedule *reminderSchedule, event *domain.Event) {
+	at, ok := event.NextActionAt()
+	if !ok {
+		schedule.Remove(event.ID)
+		return
+	}
+	schedule.Set(event.ID, at)
+}
+
+// RunOnce runs a single reminder cycle at the provider's current time,
+// without the in-memory schedule. The simulator drives the worker with it.
+func (w *ReminderWorker) RunOnce(ctx context.Context) error {
+	return w.processReminders(ctx)
+}
+
 func (w *ReminderWorker) processReminders(ctx context.Context) error {
-	reminderWindow := 30 * time.Minute
-	eventsWithUsers, err := w.repos.Event().GetPendingReminders(ctx, reminderWindow)
+	if err := w.advanceRecurringEvents(ctx); err != nil {
+		w.logger.Error("Failed to advance recurring events", zap.Error(err))
+	}
+
+	if err := w.closeFinishedEvents(ctx); err != nil {
+		w.logger.Error("Failed to close finished events", zap.Error(err))
+	}
+
+	now := w.timeProvider.Now()
+	eventsWithUsers, err := w.repos.Event().ClaimPendingReminders(ctx, now, w.lease(now))
 	if err != nil {
-		return fmt.Errorf("failed to get pending reminders: %w", err)
+		return fmt.Errorf("failed to claim pending reminders: %w", err)
 	}
 
 	if len(eventsWithUsers) == 0 {
@@ -77,7 +255,15 @@ func (w *ReminderWorker) processReminders(ctx context.Context) error {
 
 	w.logger.Info("Processing reminders", zap.Int("count", len(eventsWithUsers)))
 
+	var missed []domain.EventWithUser
 	for _, eventWithUser := range eventsWithUsers {
+		if w.catchUp.Mode == domain.CatchUpSummary {
+			if dueAt, due := eventWithUser.ReminderDueAt(now); due && w.catchUp.IsLate(dueAt, now) {
+				missed = append(missed, eventWithUser)
+				continue
+			}
+		}
+
 		if err := w.processEventReminder(ctx, &eventWithUser); err != nil {
 			w.logger.Error("Failed to process event reminder",
 				zap.Error(err),
@@ -85,92 +271,560 @@ func (w *ReminderWorker) processReminders(ctx context.Context) error {
 				zap.String("user_number", eventWithUser.User.WANumber),
 			)
 		}
+		w.releaseClaim(ctx, eventWithUser.ID)
+	}
+
+	if len(missed) > 0 {
+		w.summarizeMissedReminders(ctx, missed, now)
+		for _, eventWithUser := range missed {
+			w.releaseClaim(ctx, eventWithUser.ID)
+		}
+	}
+
+	return nil
+}
+
+// lease is the claim this instance takes on the events it processes at now.
+func (w *ReminderWorker) lease(now time.Time) domain.Lease {
+	return domain.Lease{Owner: w.instanceID, ExpiresAt: now.Add(w.leaseDuration)}
+}
+
+// releaseClaim hands an event back once its changes are stored. A failed
+// release only delays the event until the lease expires.
+func (w *ReminderWorker) releaseClaim(ctx context.Context, eventID int) {
+	if err := w.repos.Event().ReleaseClaim(ctx, eventID, w.instanceID); err != nil {
+		w.logger.Error("Failed to release event claim", zap.Error(err), zap.Int("event_id", eventID))
+	}
+}
+
+// advanceRecurringEvents moves recurring series whose current occurrence has
+// passed on to their next occurrence, resetting the per-occurrence reminder
+// state. Occurrences that required a confirmation and never got one are
+// recorded as no_response. Series without further occurrences are completed.
+func (w *ReminderWorker) advanceRecurringEvents(ctx context.Context) error {
+	now := w.timeProvider.Now()
+	eventsWithUsers, err := w.repos.Event().ClaimRecurringEventsToAdvance(ctx, now, w.lease(now))
+	if err != nil {
+		return fmt.Errorf("failed to claim recurring events: %w", err)
+	}
+
+	var eventIDs []int
+	for _, eventWithUser := range eventsWithUsers {
+		eventIDs = append(eventIDs, eventWithUser.ID)
+	}
+
+	defer func() {
+		for _, eventID := range eventIDs {
+			w.releaseClaim(ctx, eventID)
+		}
+	}()
+
+	exceptions, err := w.repos.EventException().ListByEventIDs(ctx, eventIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get event exceptions: %w", err)
+	}
+
+	for _, eventWithUser := range eventsWithUsers {
+		event := eventWithUser.Event
+		for _, exception := range exceptions {
+			if exception.EventID == event.ID {
+				event.Exceptions = append(event.Exceptions, exception)
+			}
+		}
+
+		if _, err := event.Recurrence(); err != nil {
+			w.logger.Error("Invalid recurrence rule", zap.Error(err), zap.Int("event_id", event.ID))
+			continue
+		}
+
+		user := &eventWithUser.User
+		err := w.repos.WithTx(ctx, func(tx ports.Repositories) error {
+			if status, ok := event.LifecycleStatus(now); ok && status == domain.EventStatusNoResponse {
+				if err := w.escalate(ctx, tx, &event, user, event.NotificationsSent, now); err != nil {
+					return err
+				}
+				missed, err := w.markOccurrenceNoResponse(ctx, tx, &event, user.Location())
+				if err != nil {
+					return err
+				}
+				if missed != nil {
+					if err := w.queueNoResponseNotice(ctx, tx, user, missed, now); err != nil {
+						return err
+					}
+				}
+			}
+
+			// Canceled occurrences are skipped and confirmations recorded ahead
+			// of time carry over to the occurrence they belong to.
+			next, ok := event.NextOccurrence(now, user.Location())
+			if ok {
+				event.NextOccurrenceAt = &next.StartsAt
+				event.Status = next.Status
+			} else {
+				event.Status = domain.EventStatusCompleted
+			}
+			event.NotificationsSent = 0
+			event.LastNotifiedAt = nil
+			event.SnoozedUntil = nil
+			event.DeferredUntil = nil
+			event.EscalationLevel = 0
+
+			return tx.Event().Update(ctx, &event)
+		})
+		if err != nil {
+			w.logger.Error("Failed to advance recurring event", zap.Error(err), zap.Int("event_id", event.ID))
+			continue
+		}
+
+		w.logger.Debug("Advanced recurring event",
+			zap.Int("event_id", event.ID),
+			zap.String("status", string(event.Status)),
+		)
+	}
+
+	return nil
+}
+
+// markOccurrenceNoResponse records no_response on the occurrence of the
+// series reminders were tracking, keeping any other override it has.
+func (w *ReminderWorker) markOccurrenceNoResponse(ctx context.Context, repos ports.Repositories, event *domain.Event, loc *time.Location) (*domain.Event, error) {
+	current := event.OccurrenceStartsAt()
+	occurrences := event.OccurrencesBetween(current, current, loc)
+	if len(occurrences) == 0 {
+		return nil, nil
+	}
+	occurrence := occurrences[0]
+
+	status := domain.EventStatusNoResponse
+	exception := domain.EventException{EventID: event.ID, OriginalStartsAt: *occurrence.OriginalStartsAt}
+	if existing := event.ExceptionFor(exception.OriginalStartsAt); existing != nil {
+		exception = *existing
+	}
+	exception.Status = &status
+
+	if err := repos.EventException().Upsert(ctx, &exception); err != nil {
+		return nil, fmt.Errorf("failed to store occurrence status: %w", err)
+	}
+
+	occurrence.Status = status
+	return &occurrence, nil
+}
+
+// closeFinishedEvents moves single events to their final status once they
+// are over: completed, or no_response when a required confirmation never
+// came before they were due.
+func (w *ReminderWorker) closeFinishedEvents(ctx context.Context) error {
+	now := w.timeProvider.Now()
+	eventsWithUsers, err := w.repos.Event().ClaimEventsToClose(ctx, now, w.lease(now))
+	if err != nil {
+		return fmt.Errorf("failed to claim events to close: %w", err)
+	}
+
+	for _, eventWithUser := range eventsWithUsers {
+		w.closeEvent(ctx, &eventWithUser, now)
+		w.releaseClaim(ctx, eventWithUser.ID)
 	}
 
 	return nil
 }
 
+// closeEvent stores the final status of an event that is over, if it
+// reached one.
+func (w *ReminderWorker) closeEvent(ctx context.Context, eventWithUser *domain.EventWithUser, now time.Time) {
+	event := eventWithUser.Event
+	status, ok := event.LifecycleStatus(now)
+	if !ok {
+		return
+	}
+	event.Status = status
+	event.SnoozedUntil = nil
+	event.DeferredUntil = nil
+
+	err := w.repos.WithTx(ctx, func(tx ports.Repositories) error {
+		if status == domain.EventStatusNoResponse {
+			if err := w.escalate(ctx, tx, &event, &eventWithUser.User, event.NotificationsSent, now); err != nil {
+				return err
+			}
+		}
+		if err := tx.Event().Update(ctx, &event); err != nil {
+			return err
+		}
+		if status == domain.EventStatusNoResponse {
+			return w.queueNoResponseNotice(ctx, tx, &eventWithUser.User, &event, now)
+		}
+		return nil
+	})
+	if err != nil {
+		w.logger.Error("Failed to close event", zap.Error(err), zap.Int("event_id", event.ID))
+		return
+	}
+
+	w.logger.Debug("Closed event",
+		zap.Int("event_id", event.ID),
+		zap.String("status", string(event.Status)),
+	)
+}
+
+// queueNoResponseNotice tells the user an event went unconfirmed, unless
+// they opted out.
+func (w *ReminderWorker) queueNoResponseNotice(ctx context.Context, repos ports.Repositories, user *domain.User, event *domain.Event, now time.Time) error {
+	if !user.NotifyNoResponse {
+		return nil
+	}
+
+	body, err := w.messages.Render(ctx, user, domain.TemplateNoResponse, domain.MessageData{
+		"Event": event,
+		"When":  domain.NewTimeRenderer(now, user.Location(), user.Language()).Event(event),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render missed confirmation notice: %w", err)
+	}
+	message := domain.NewUserMessage(user, body, now)
+	if err := repos.OutboundMessage().Create(ctx, message); err != nil {
+		return fmt.Errorf("failed to queue missed confirmation notice: %w", err)
+	}
+	return nil
+}
+
+// escalate takes the steps of the event's escalation chain that unanswered
+// reminders reached, telling the chosen contacts that the event is still
+// unconfirmed and recording it. The caller stores the event, whose
+// EscalationLevel moves past the steps taken.
+func (w *ReminderWorker) escalate(ctx context.Context, repos ports.Repositories, event *domain.Event, user *domain.User, unanswered int, now time.Time) error {
+	policy := event.EscalationChain(user)
+	steps := policy.DueSteps(event.EscalationLevel, unanswered)
+	if len(steps) == 0 {
+		return nil
+	}
+
+	contacts, err := repos.UserAllowedContact().List(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list escalation contacts: %w", err)
+	}
+
+	message, err := w.messages.Render(ctx, user, domain.TemplateEscalation, domain.MessageData{
+		"User":       user,
+		"Event":      event,
+		"When":       domain.NewTimeRenderer(now, user.Location(), user.Language()).Event(event),
+		"Unanswered": unanswered,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render escalation message: %w", err)
+	}
+	for _, step := range steps {
+		for _, number := range policy[step].Recipients(contacts) {
+			if err := repos.OutboundMessage().Create(ctx, domain.NewOutboundMessage(number, message, now)); err != nil {
+				return fmt.Errorf("failed to queue escalation message: %w", err)
+			}
+			escalation := &domain.EventEscalation{
+				EventID:             event.ID,
+				UserID:              user.ID,
+				OccurrenceStartsAt:  event.OccurrenceStartsAt(),
+				Step:                step + 1,
+				ContactNumber:       number,
+				UnansweredReminders: unanswered,
+				Status:              domain.EscalationNotified,
+				NotifiedAt:          now,
+			}
+			if err := repos.EventEscalation().Create(ctx, escalation); err != nil {
+				return fmt.Errorf("failed to record escalation: %w", err)
+			}
+		}
+		event.EscalationLevel = step + 1
+	}
+
+	w.logger.Info("Escalated unconfirmed event",
+		zap.Int("event_id", event.ID),
+		zap.Int("unanswered_reminders", unanswered),
+		zap.Int("escalation_level", event.EscalationLevel),
+	)
+	return nil
+}
+
 func (w *ReminderWorker) processEventReminder(ctx context.Context, eventWithUser *domain.EventWithUser) error {
 	event := &eventWithUser.Event
 	user := &eventWithUser.User
 
 	now := w.timeProvider.Now()
-	reminderTime := event.StartsAt.Add(-time.Duration(event.RemindBeforeMinutes) * time.Minute)
+	snoozed := event.SnoozedUntil != nil
 
-	if now.Before(reminderTime) {
+	// Each schedule stage fires once; MaxNotifications only caps repeating
+	// reminders.
+	dueAt, due := event.ReminderDueAt(now)
+	if !due {
 		return nil
 	}
+	late := w.catchUp.IsLate(dueAt, now)
 
-	if event.NotificationsSent >= event.MaxNotifications {
+	if late && w.catchUp.Mode == domain.CatchUpDrop && !now.Before(event.OccurrenceStartsAt()) {
+		markReminded(event, now, snoozed)
+		if err := w.repos.Event().Update(ctx, event); err != nil {
+			return fmt.Errorf("failed to drop missed reminder: %w", err)
+		}
+		w.logger.Info("Dropped missed reminder",
+			zap.Int("event_id", event.ID),
+			zap.Time("due_at", dueAt),
+		)
 		return nil
 	}
 
-	var message string
-	if event.RequireConfirmation && event.Status == domain.EventStatusScheduled {
-		message = w.buildConfirmationMessage(event)
-	} else {
-		message = w.buildReminderMessage(event)
+	deferred, err := w.deferForQuietHours(ctx, eventWithUser, now)
+	if err != nil || deferred {
+		return err
 	}
 
-	if err := w.whatsappSender.SendText(ctx, user.WANumber, message); err != nil {
-		return fmt.Errorf("failed to send reminder message: %w", err)
-	}
+	awaitingConfirmation := event.RequireConfirmation && event.Status == domain.EventStatusScheduled
 
-	event.NotificationsSent++
-	event.LastNotifiedAt = &now
+	message, err := w.buildReminderMessage(ctx, event, user, awaitingConfirmation, late, now)
+	if err != nil {
+		return fmt.Errorf("failed to render reminder: %w", err)
+	}
+	reminder := domain.NewUserMessage(user, message, now)
+	if awaitingConfirmation {
+		if reminder.Interactive, err = w.buildConfirmationButtons(ctx, event, user, now); err != nil {
+			return fmt.Errorf("failed to render confirmation buttons: %w", err)
+		}
+	}
+	if reminder.Channel == domain.ChannelWhatsApp {
+		reminder.Template = w.buildTemplateMessage(event, user, reminder.Interactive, now)
+	}
 
-	if err := w.repos.Event().Update(ctx, event); err != nil {
-		return fmt.Errorf("failed to update event after sending reminder: %w", err)
+	// Every reminder sent so far went unanswered if another one is due and
+	// the event is still unconfirmed. A snooze counts as an answer.
+	unanswered := event.NotificationsSent
+	markReminded(event, now, snoozed)
+
+	// The reminder is queued in the same transaction that records it, so it
+	// is neither lost nor sent twice if the worker stops in between.
+	err = w.repos.WithTx(ctx, func(tx ports.Repositories) error {
+		if awaitingConfirmation && !snoozed {
+			if err := w.escalate(ctx, tx, event, user, unanswered, now); err != nil {
+				return err
+			}
+		}
+		if err := tx.Event().Update(ctx, event); err != nil {
+			return fmt.Errorf("failed to update event after sending reminder: %w", err)
+		}
+		if err := tx.OutboundMessage().Create(ctx, reminder); err != nil {
+			return fmt.Errorf("failed to queue reminder message: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	w.logger.Info("Sent reminder",
+	w.logger.Info("Queued reminder",
 		zap.Int("event_id", event.ID),
 		zap.String("user_number", user.WANumber),
 		zap.String("event_title", event.Title),
 		zap.Int("notifications_sent", event.NotificationsSent),
+		zap.Bool("snoozed", snoozed),
+		zap.Bool("late", late),
 	)
 
 	return nil
 }
 
-func (w *ReminderWorker) buildReminderMessage(event *domain.Event) string {
-	var parts []string
-	parts = append(parts, "⏰ *Lembrete de Compromisso*")
-	parts = append(parts, fmt.Sprintf("📅 %s", event.Title))
-	parts = append(parts, fmt.Sprintf("🕐 %s", event.StartsAt.Format("02/01/2006 15:04")))
+// markReminded records that the event's due reminder was handled at now. A
+// snoozed nudge was explicitly asked for, so it does not count towards
+// MaxNotifications; a reminder deferred for quiet hours does.
+func markReminded(event *domain.Event, now time.Time, snoozed bool) {
+	if snoozed {
+		event.SnoozedUntil = nil
+	} else {
+		event.NotificationsSent++
+	}
+	event.DeferredUntil = nil
+	event.LastNotifiedAt = &now
+}
+
+// deferForQuietHours holds a reminder due during the user's quiet hours until
+// they end, unless the event bypasses them or would already have started by
+// then. It reports whether the reminder was deferred.
+func (w *ReminderWorker) deferForQuietHours(ctx context.Context, eventWithUser *domain.EventWithUser, now time.Time) (bool, error) {
+	event := &eventWithUser.Event
+	user := &eventWithUser.User
+	if event.BypassesQuietHours() {
+		return false, nil
+	}
+
+	until, quiet := user.QuietHours.Until(now, user.Location())
+	if !quiet || !until.Before(event.ReminderAnchor()) {
+		return false, nil
+	}
 
-	if event.Location != nil {
-		parts = append(parts, fmt.Sprintf("📍 %s", *event.Location))
+	event.DeferredUntil = &until
+	if err := w.repos.Event().Update(ctx, event); err != nil {
+		return false, fmt.Errorf("failed to defer reminder: %w", err)
 	}
+	w.logger.Info("Deferred reminder until end of quiet hours",
+		zap.Int("event_id", event.ID),
+		zap.Time("until", until),
+	)
+	return true, nil
+}
+
+// summarizeMissedReminders replaces the missed reminders of each user with a
+// single message listing their events. Reminders falling in the user's quiet
+// hours are deferred one by one instead.
+func (w *ReminderWorker) summarizeMissedReminders(ctx context.Context, missed []domain.EventWithUser, now time.Time) {
+	var userIDs []int
+	byUser := make(map[int][]*domain.EventWithUser)
+	for i := range missed {
+		eventWithUser := &missed[i]
+		deferred, err := w.deferForQuietHours(ctx, eventWithUser, now)
+		if err != nil {
+			w.logger.Error("Failed to process event reminder", zap.Error(err), zap.Int("event_id", eventWithUser.ID))
+			continue
+		}
+		if deferred {
+			continue
+		}
 
-	timeUntil := time.Until(event.StartsAt)
-	if timeUntil > 0 {
-		if timeUntil < time.Hour {
-			minutes := int(timeUntil.Minutes())
-			parts = append(parts, fmt.Sprintf("⏱️ Começa em %d minutos", minutes))
-		} else {
-			hours := int(timeUntil.Hours())
-			parts = append(parts, fmt.Sprintf("⏱️ Começa em %d horas", hours))
+		if _, ok := byUser[eventWithUser.UserID]; !ok {
+			userIDs = append(userIDs, eventWithUser.UserID)
 		}
+		byUser[eventWithUser.UserID] = append(byUser[eventWithUser.UserID], eventWithUser)
 	}
 
-	return strings.Join(parts, "\n")
+	for _, userID := range userIDs {
+		eventsWithUser := byUser[userID]
+		user := &eventsWithUser[0].User
+
+		var events []*domain.Event
+		for _, eventWithUser := range eventsWithUser {
+			events = append(events, &eventWithUser.Event)
+		}
+		message, err := w.buildMissedSummaryMessage(ctx, events, user, now)
+		if err != nil {
+			w.logger.Error("Failed to render missed reminders summary", zap.Error(err), zap.Int("user_id", userID))
+			continue
+		}
+
+		err = w.repos.WithTx(ctx, func(tx ports.Repositories) error {
+			for _, event := range events {
+				markReminded(event, now, event.SnoozedUntil != nil)
+				if err := tx.Event().Update(ctx, event); err != nil {
+					return fmt.Errorf("failed to update event after sending reminder: %w", err)
+				}
+			}
+			if err := tx.OutboundMessage().Create(ctx, domain.NewUserMessage(user, message, now)); err != nil {
+				return fmt.Errorf("failed to queue missed reminders summary: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			w.logger.Error("Failed to summarize missed reminders", zap.Error(err), zap.Int("user_id", userID))
+			continue
+		}
+
+		w.logger.Info("Queued missed reminders summary",
+			zap.Int("user_id", userID),
+			zap.String("user_number", user.WANumber),
+			zap.Int("count", len(events)),
+		)
+	}
 }
 
-func (w *ReminderWorker) buildConfirmationMessage(event *domain.Event) string {
-	var parts []string
-	parts = append(parts, "❓ *Confirmação de Compromisso*")
-	parts = append(parts, fmt.Sprintf("📅 %s", event.Title))
-	parts = append(parts, fmt.Sprintf("🕐 %s", event.StartsAt.Format("02/01/2006 15:04")))
+// buildReminderMessage renders the reminder, or the request to confirm the
+// event while it is still unconfirmed, with a notice when it is late.
+func (w *ReminderWorker) buildReminderMessage(ctx context.Context, event *domain.Event, user *domain.User, awaitingConfirmation, late bool, now time.Time) (string, error) {
+	data := domain.MessageData{
+		"Event": event,
+		"When":  domain.NewTimeRenderer(now, user.Location(), user.Language()).Event(event),
+	}
+
+	name := domain.TemplateReminder
+	if awaitingConfirmation {
+		name = domain.TemplateConfirmationRequest
+	} else {
+		data["Countdown"] = nil
+		timeUntil := event.OccurrenceStartsAt().Sub(now)
+		if timeUntil > 0 && !event.AllDay {
+			data["Countdown"] = domain.MessageData{
+				"Hours":   int(timeUntil.Hours()),
+				"Minutes": int(timeUntil.Minutes()),
+			}
+		}
+	}
+
+	message, err := w.messages.Render(ctx, user, name, data)
+	if err != nil || !late {
+		return message, err
+	}
+
+	notice, err := w.messages.Render(ctx, user, domain.TemplateLateNotice, domain.MessageData{
+		"Started": !now.Before(event.OccurrenceStartsAt()),
+	})
+	if err != nil {
+		return "", err
+	}
+	return notice + "\n\n" + message, nil
+}
+
+// buildConfirmationButtons renders the buttons sent along the request to
+// confirm the event, which carry its ID so a tap acts on it directly.
+func (w *ReminderWorker) buildConfirmationButtons(ctx context.Context, event *domain.Event, user *domain.User, now time.Time) (*domain.Interactive, error) {
+	data := domain.MessageData{
+		"Event": event,
+		"When":  domain.NewTimeRenderer(now, user.Location(), user.Language()).Event(event),
+	}
 
-	if event.Location != nil {
-		parts = append(parts, fmt.Sprintf("📍 %s", *event.Location))
+	buttons := []struct {
+		action domain.EventReply
+		name   string
+	}{
+		{action: domain.EventReplyConfirm, name: domain.TemplateConfirmButton},
+		{action: domain.EventReplyCancel, name: domain.TemplateCancelButton},
+		{action: domain.EventReplySnooze, name: domain.TemplateSnoozeButton},
 	}
 
-	parts = append(parts, "")
-	parts = append(parts, "Por favor, confirme sua presença:")
-	parts = append(parts, "✅ Responda 'OK' ou 'Confirmo' para confirmar")
-	parts = append(parts, "❌ Responda 'Cancelar' para cancelar")
+	interactive := &domain.Interactive{}
+	for _, button := range buttons {
+		title, err := w.messages.Render(ctx, user, button.name, data)
+		if err != nil {
+			return nil, err
+		}
+		interactive.Buttons = append(interactive.Buttons, domain.ReplyOption{
+			ID:    domain.EventReplyID(button.action, event.ID),
+			Title: title,
+		})
+	}
+	return interactive, nil
+}
+
+// buildTemplateMessage fills the WhatsApp template registered for the
+// reminder, if any, which is sent instead when the user has not written in
+// the last 24 hours. Its parameters are the event title and when it happens;
+// the confirmation request gets the confirmation buttons as quick replies.
+func (w *ReminderWorker) buildTemplateMessage(event *domain.Event, user *domain.User, buttons *domain.Interactive, now time.Time) *domain.TemplateMessage {
+	name := domain.TemplateReminder
+	if buttons != nil {
+		name = domain.TemplateConfirmationRequest
+	}
+
+	when := domain.NewTimeRenderer(now, user.Location(), user.Language()).Event(event)
+	template := w.templates.Message(name, user.Language(), event.Title, when)
+	if template != nil && buttons != nil {
+		for _, button := range buttons.Buttons {
+			template.ButtonPayloads = append(template.ButtonPayloads, button.ID)
+		}
+	}
+	return template
+}
+
+func (w *ReminderWorker) buildMissedSummaryMessage(ctx context.Context, events []*domain.Event, user *domain.User, now time.Time) (string, error) {
+	when := domain.NewTimeRenderer(now, user.Location(), user.Language())
+
+	items := make([]domain.MessageData, len(events))
+	for i, event := range events {
+		items[i] = domain.MessageData{
+			"Event":                event,
+			"When":                 when.Event(event),
+			"AwaitingConfirmation": event.RequireConfirmation && event.Status == domain.EventStatusScheduled,
+		}
+	}
 
-	return strings.Join(parts, "\n")
+	return w.messages.Render(ctx, user, domain.TemplateMissedSummary, domain.MessageData{"Events": items})
 }
@@ -0,0 +1,678 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alarm-agent/internal/adapters/templates"
+	"github.com/alarm-agent/internal/domain"
+	"github.com/alarm-agent/internal/ports"
+)
+
+// leasingEventRepository keeps events in memory and claims them the way the
+// Postgres repository does: atomically, skipping events leased by another
+// owner until their lease expires.
+type leasingEventRepository struct {
+	ports.EventRepository
+
+	mu     sync.Mutex
+	events map[int]*domain.EventWithUser
+	owners map[int]string
+	leases map[int]time.Time
+}
+
+func newLeasingEventRepository(events ...domain.EventWithUser) *leasingEventRepository {
+	r := &leasingEventRepository{
+		events: make(map[int]*domain.EventWithUser),
+		owners: make(map[int]string),
+		leases: make(map[int]time.Time),
+	}
+	for i := range events {
+		r.events[events[i].ID] = &events[i]
+	}
+	return r
+}
+
+func (r *leasingEventRepository) ClaimPendingReminders(ctx context.Context, now time.Time, lease domain.Lease) ([]domain.EventWithUser, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var claimed []domain.EventWithUser
+	for id, event := range r.events {
+		if until, leased := r.leases[id]; leased && until.After(now) && r.owners[id] != lease.Owner {
+			continue
+		}
+		if _, due := event.ReminderDueAt(now); !due {
+			continue
+		}
+		r.owners[id] = lease.Owner
+		r.leases[id] = lease.ExpiresAt
+		claimed = append(claimed, *event)
+	}
+	return claimed, nil
+}
+
+func (r *leasingEventRepository) ClaimRecurringEventsToAdvance(ctx context.Context, now time.Time, lease domain.Lease) ([]domain.EventWithUser, error) {
+	return nil, nil
+}
+
+func (r *leasingEventRepository) ClaimEventsToClose(ctx context.Context, now time.Time, lease domain.Lease) ([]domain.EventWithUser, error) {
+	return nil, nil
+}
+
+func (r *leasingEventRepository) ReleaseClaim(ctx context.Context, eventID int, owner string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.owners[eventID] == owner {
+		delete(r.owners, eventID)
+		delete(r.leases, eventID)
+	}
+	return nil
+}
+
+func (r *leasingEventRepository) GetByID(ctx context.Context, id int) (*domain.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event, ok := r.events[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := event.Event
+	return &copied, nil
+}
+
+func (r *leasingEventRepository) ListActive(ctx context.Context, afterID, limit int) ([]domain.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var events []domain.Event
+	for id, event := range r.events {
+		if id > afterID && (event.Status == domain.EventStatusScheduled || event.Status == domain.EventStatusConfirmed) {
+			events = append(events, event.Event)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (r *leasingEventRepository) add(event domain.EventWithUser) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events[event.ID] = &event
+}
+
+func (r *leasingEventRepository) Update(ctx context.Context, event *domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events[event.ID].Event = *event
+	return nil
+}
+
+type emptyExceptionRepository struct {
+	ports.EventExceptionRepository
+}
+
+func (r *emptyExceptionRepository) ListByEventIDs(ctx context.Context, eventIDs []int) ([]domain.EventException, error) {
+	return nil, nil
+}
+
+// recordingOutbox keeps the messages queued for each number.
+type recordingOutbox struct {
+	ports.OutboundMessageRepository
+
+	mu          sync.Mutex
+	queued      map[string]int
+	bodies      map[string][]string
+	interactive map[string][]*domain.Interactive
+	templates   map[string][]*domain.TemplateMessage
+}
+
+func newRecordingOutbox() *recordingOutbox {
+	return &recordingOutbox{
+		queued:      make(map[string]int),
+		bodies:      make(map[string][]string),
+		interactive: make(map[string][]*domain.Interactive),
+		templates:   make(map[string][]*domain.TemplateMessage),
+	}
+}
+
+func (o *recordingOutbox) Create(ctx context.Context, message *domain.OutboundMessage) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.queued[message.ToNumber]++
+	o.bodies[message.ToNumber] = append(o.bodies[message.ToNumber], message.Body)
+	o.interactive[message.ToNumber] = append(o.interactive[message.ToNumber], message.Interactive)
+	o.templates[message.ToNumber] = append(o.templates[message.ToNumber], message.Template)
+	return nil
+}
+
+// staticContacts returns a fixed list of allowed contacts.
+type staticContacts struct {
+	ports.UserAllowedContactRepository
+	contacts []domain.UserAllowedContact
+}
+
+func (r *staticContacts) List(ctx context.Context, userID int) ([]domain.UserAllowedContact, error) {
+	return r.contacts, nil
+}
+
+// recordingEscalations keeps the escalations created.
+type recordingEscalations struct {
+	ports.EventEscalationRepository
+	created []domain.EventEscalation
+}
+
+func (r *recordingEscalations) Create(ctx context.Context, escalation *domain.EventEscalation) error {
+	r.created = append(r.created, *escalation)
+	return nil
+}
+
+type memoryRepositories struct {
+	ports.Repositories
+	events      *leasingEventRepository
+	outbox      ports.OutboundMessageRepository
+	contacts    *staticContacts
+	escalations *recordingEscalations
+	identities  ports.UserIdentityRepository
+}
+
+func (r *memoryRepositories) Event() ports.EventRepository {
+	return r.events
+}
+
+func (r *memoryRepositories) EventException() ports.EventExceptionRepository {
+	return &emptyExceptionRepository{}
+}
+
+func (r *memoryRepositories) OutboundMessage() ports.OutboundMessageRepository {
+	return r.outbox
+}
+
+func (r *memoryRepositories) UserAllowedContact() ports.UserAllowedContactRepository {
+	return r.contacts
+}
+
+func (r *memoryRepositories) EventEscalation() ports.EventEscalationRepository {
+	return r.escalations
+}
+
+func (r *memoryRepositories) UserIdentity() ports.UserIdentityRepository {
+	return r.identities
+}
+
+func (r *memoryRepositories) WithTx(ctx context.Context, fn func(ports.Repositories) error) error {
+	return fn(r)
+}
+
+// defaultMessages renders the embedded message templates, without overrides.
+func defaultMessages(t *testing.T) ports.MessageRenderer {
+	messages, err := templates.NewRenderer(nil, "")
+	require.NoError(t, err)
+	return messages
+}
+
+// channelFeed hands out a channel tests push event changes into.
+type channelFeed struct {
+	changes chan int
+}
+
+func (f *channelFeed) Subscribe(ctx context.Context) (<-chan int, error) {
+	if f.changes == nil {
+		f.changes = make(chan int)
+	}
+	return f.changes, nil
+}
+
+type fixedTimeProvider struct {
+	now time.Time
+}
+
+func (p fixedTimeProvider) Now() time.Time {
+	return p.now
+}
+
+func (p fixedTimeProvider) Sleep(d time.Duration) {}
+
+var onTimeCatchUp = domain.CatchUpPolicy{Mode: domain.CatchUpLate, Grace: 2 * time.Minute}
+
+// TestReminderWorker_ConcurrentInstancesQueueOnce checks that workers only
+// queue the reminders of the events they claimed. The claim itself is
+// covered against Postgres by TestEventRepository_ClaimPendingReminders.
+func TestReminderWorker_ConcurrentInstancesQueueOnce(t *testing.T) {
+	now := time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC)
+
+	var events []domain.EventWithUser
+	for i := 1; i <= 50; i++ {
+		events = append(events, domain.EventWithUser{
+			Event: domain.Event{
+				ID:              i,
+				UserID:          i,
+				Title:           fmt.Sprintf("Evento %d", i),
+				StartsAt:        now.Add(30 * time.Minute),
+				ReminderOffsets: domain.ReminderSchedule{60},
+				Status:          domain.EventStatusScheduled,
+			},
+			User: domain.User{ID: i, WANumber: fmt.Sprintf("+55119000000%02d", i)},
+		})
+	}
+
+	outbox := newRecordingOutbox()
+	repos := &memoryRepositories{events: newLeasingEventRepository(events...), outbox: outbox}
+	clock := fixedTimeProvider{now: now}
+
+	workers := []*ReminderWorker{
+		NewReminderWorker(repos, &channelFeed{}, defaultMessages(t), clock, zap.NewNop(), time.Second, "worker-a", time.Minute, onTimeCatchUp, nil),
+		NewReminderWorker(repos, &channelFeed{}, defaultMessages(t), clock, zap.NewNop(), time.Second, "worker-b", time.Minute, onTimeCatchUp, nil),
+	}
+
+	// require must not fail the test from other goroutines, so their errors
+	// are checked once they are done.
+	ticks := 3 * len(workers)
+	errs := make(chan error, ticks)
+	var wg sync.WaitGroup
+	for _, worker := range workers {
+		for tick := 0; tick < 3; tick++ {
+			wg.Add(1)
+			go func(worker *ReminderWorker) {
+				defer wg.Done()
+				errs <- worker.processReminders(context.Background())
+			}(worker)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.Len(t, outbox.queued, len(events))
+	for _, event := range events {
+		assert.Equal(t, 1, outbox.queued[event.User.WANumber], "event %d", event.ID)
+	}
+}
+
+func TestReminderWorker_ReclaimsExpiredLease(t *testing.T) {
+	now := time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC)
+
+	event := domain.EventWithUser{
+		Event: domain.Event{
+			ID:              1,
+			UserID:          1,
+			Title:           "Dentista",
+			StartsAt:        now.Add(30 * time.Minute),
+			ReminderOffsets: domain.ReminderSchedule{60},
+			Status:          domain.EventStatusScheduled,
+		},
+		User: domain.User{ID: 1, WANumber: "+5511900000001"},
+	}
+
+	outbox := newRecordingOutbox()
+	repos := &memoryRepositories{events: newLeasingEventRepository(event), outbox: outbox}
+
+	// A crashed instance left the event leased.
+	repos.events.owners[event.ID] = "worker-a"
+	repos.events.leases[event.ID] = now.Add(time.Minute)
+
+	worker := NewReminderWorker(repos, &channelFeed{}, defaultMessages(t), fixedTimeProvider{now: now}, zap.NewNop(), time.Second, "worker-b", time.Minute, onTimeCatchUp, nil)
+	require.NoError(t, worker.processReminders(context.Background()))
+	assert.Empty(t, outbox.queued)
+
+	worker.timeProvider = fixedTimeProvider{now: now.Add(2 * time.Minute)}
+	require.NoError(t, worker.processReminders(context.Background()))
+	assert.Equal(t, 1, outbox.queued[event.User.WANumber])
+}
+
+func TestReminderWorker_CatchesUpMissedReminders(t *testing.T) {
+	now := time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC)
+	ongoingEnd := now.Add(time.Hour)
+
+	// Reminders that became due while the worker was down: one for an event
+	// still ahead and one for an event that started but has not ended.
+	upcoming := domain.EventWithUser{
+		Event: domain.Event{
+			ID:              1,
+			UserID:          1,
+			Title:           "Dentista",
+			StartsAt:        now.Add(30 * time.Minute),
+			ReminderOffsets: domain.ReminderSchedule{120},
+			Status:          domain.EventStatusScheduled,
+		},
+		User: domain.User{ID: 1, WANumber: "+5511900000001"},
+	}
+	ongoing := domain.EventWithUser{
+		Event: domain.Event{
+			ID:              2,
+			UserID:          1,
+			Title:           "Workshop",
+			StartsAt:        now.Add(-30 * time.Minute),
+			EndsAt:          &ongoingEnd,
+			ReminderOffsets: domain.ReminderSchedule{60},
+			Status:          domain.EventStatusConfirmed,
+		},
+		User: domain.User{ID: 1, WANumber: "+5511900000001"},
+	}
+
+	tests := []struct {
+		name         string
+		mode         domain.CatchUpMode
+		wantMessages int
+		wantContains []string
+		wantMissing  []string
+	}{
+		{name: "late", mode: domain.CatchUpLate, wantMessages: 2, wantContains: []string{"com atraso", "já começou", "Dentista", "Workshop"}},
+		{name: "summary", mode: domain.CatchUpSummary, wantMessages: 1, wantContains: []string{"Lembretes atrasados", "Dentista", "Workshop"}},
+		{name: "drop", mode: domain.CatchUpDrop, wantMessages: 1, wantContains: []string{"com atraso", "Dentista"}, wantMissing: []string{"Workshop"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outbox := newRecordingOutbox()
+			repos := &memoryRepositories{events: newLeasingEventRepository(upcoming, ongoing), outbox: outbox}
+			policy := domain.CatchUpPolicy{Mode: tt.mode, Grace: 2 * time.Minute}
+
+			worker := NewReminderWorker(repos, &channelFeed{}, defaultMessages(t), fixedTimeProvider{now: now}, zap.NewNop(), time.Second, "worker-a", time.Minute, policy, nil)
+			require.NoError(t, worker.processReminders(context.Background()))
+
+			bodies := outbox.bodies[upcoming.User.WANumber]
+			require.Len(t, bodies, tt.wantMessages)
+			sent := strings.Join(bodies, "\n")
+			for _, want := range tt.wantContains {
+				assert.Contains(t, sent, want)
+			}
+			for _, missing := range tt.wantMissing {
+				assert.NotContains(t, sent, missing)
+			}
+
+			// Every missed reminder is handled once, sent or dropped.
+			for _, event := range repos.events.events {
+				require.NotNil(t, event.LastNotifiedAt, "event %d", event.ID)
+			}
+			require.NoError(t, worker.processReminders(context.Background()))
+			assert.Len(t, outbox.bodies[upcoming.User.WANumber], tt.wantMessages)
+		})
+	}
+}
+
+func TestReminderWorker_SendsRecentRemindersAsUsual(t *testing.T) {
+	now := time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC)
+
+	event := domain.EventWithUser{
+		Event: domain.Event{
+			ID:              1,
+			UserID:          1,
+			Title:           "Dentista",
+			StartsAt:        now.Add(59 * time.Minute),
+			ReminderOffsets: domain.ReminderSchedule{60},
+			Status:          domain.EventStatusScheduled,
+		},
+		User: domain.User{ID: 1, WANumber: "+5511900000001"},
+	}
+
+	outbox := newRecordingOutbox()
+	repos := &memoryRepositories{events: newLeasingEventRepository(event), outbox: outbox}
+	policy := domain.CatchUpPolicy{Mode: domain.CatchUpSummary, Grace: 2 * time.Minute}
+
+	worker := NewReminderWorker(repos, &channelFeed{}, defaultMessages(t), fixedTimeProvider{now: now}, zap.NewNop(), time.Second, "worker-a", time.Minute, policy, nil)
+	require.NoError(t, worker.processReminders(context.Background()))
+
+	bodies := outbox.bodies[event.User.WANumber]
+	require.Len(t, bodies, 1)
+	assert.Contains(t, bodies[0], "Lembrete de Compromisso")
+	assert.NotContains(t, bodies[0], "atraso")
+}
+
+func TestReminderWorker_SchedulesChangedEvents(t *testing.T) {
+	now := time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC)
+
+	outbox := newRecordingOutbox()
+	repos := &memoryRepositories{events: newLeasingEventRepository(), outbox: outbox}
+	feed := &channelFeed{changes: make(chan int)}
+
+	worker := NewReminderWorker(repos, feed, defaultMessages(t), fixedTimeProvider{now: now}, zap.NewNop(), time.Hour, "worker-a", time.Minute, onTimeCatchUp, nil)
+	done := make(chan error, 1)
+	go func() { done <- worker.Start(context.Background()) }()
+
+	// An event created after the worker started, with its reminder due now.
+	event := domain.EventWithUser{
+		Event: domain.Event{
+			ID:              1,
+			UserID:          1,
+			Title:           "Dentista",
+			StartsAt:        now.Add(time.Hour),
+			ReminderOffsets: domain.ReminderSchedule{60},
+			Status:          domain.EventStatusScheduled,
+		},
+		User: domain.User{ID: 1, WANumber: "+5511900000001"},
+	}
+	repos.events.add(event)
+	feed.changes <- event.ID
+
+	require.Eventually(t, func() bool {
+		outbox.mu.Lock()
+		defer outbox.mu.Unlock()
+		return outbox.queued[event.User.WANumber] == 1
+	}, time.Second, 10*time.Millisecond)
+
+	worker.Stop()
+	require.NoError(t, <-done)
+}
+
+func TestReminderWorker_EscalatesUnansweredReminders(t *testing.T) {
+	now := time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC)
+	name := "Maria"
+
+	event := domain.EventWithUser{
+		Event: domain.Event{
+			ID:                  1,
+			UserID:              1,
+			Title:               "Remédio",
+			StartsAt:            now.Add(2 * time.Hour),
+			ReminderOffsets:     domain.ReminderSchedule{180, 120, 60},
+			RequireConfirmation: true,
+			Status:              domain.EventStatusScheduled,
+		},
+		User: domain.User{
+			ID:       1,
+			WANumber: "+5511900000001",
+			Name:     &name,
+			DefaultEscalationPolicy: domain.EscalationPolicy{
+				{AfterReminders: 1, Contacts: []string{"+5511900000002"}},
+				{AfterReminders: 2},
+			},
+		},
+	}
+
+	outbox := newRecordingOutbox()
+	escalations := &recordingEscalations{}
+	repos := &memoryRepositories{
+		events: newLeasingEventRepository(event),
+		outbox: outbox,
+		contacts: &staticContacts{contacts: []domain.UserAllowedContact{
+			{UserID: 1, ContactNumber: "+5511900000002"},
+			{UserID: 1, ContactNumber: "+5511900000003"},
+		}},
+		escalations: escalations,
+	}
+
+	worker := NewReminderWorker(repos, &channelFeed{}, defaultMessages(t), fixedTimeProvider{now: now.Add(-time.Hour)}, zap.NewNop(), time.Second, "worker-a", time.Minute, onTimeCatchUp, nil)
+
+	// The first reminder has nothing to escalate yet.
+	require.NoError(t, worker.processReminders(context.Background()))
+	assert.Empty(t, escalations.created)
+
+	// The second one finds the first unanswered: only the chosen contact is told.
+	worker.timeProvider = fixedTimeProvider{now: now}
+	require.NoError(t, worker.processReminders(context.Background()))
+	require.Len(t, escalations.created, 1)
+	assert.Equal(t, "+5511900000002", escalations.created[0].ContactNumber)
+	assert.Equal(t, 1, escalations.created[0].UnansweredReminders)
+	assert.Contains(t, outbox.bodies["+5511900000002"][0], "Maria ainda não confirmou")
+
+	// The third one reaches the next step, which tells every allowed contact.
+	worker.timeProvider = fixedTimeProvider{now: now.Add(time.Hour)}
+	require.NoError(t, worker.processReminders(context.Background()))
+	require.Len(t, escalations.created, 3)
+	assert.Equal(t, 2, outbox.queued["+5511900000002"])
+	assert.Equal(t, 1, outbox.queued["+5511900000003"])
+	assert.Equal(t, 3, outbox.queued[event.User.WANumber])
+
+	stored, err := repos.events.GetByID(context.Background(), event.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.EscalationLevel)
+}
+
+func TestReminderWorker_SendsConfirmationButtonsAndTemplate(t *testing.T) {
+	now := time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC)
+
+	event := domain.EventWithUser{
+		Event: domain.Event{
+			ID:                  7,
+			UserID:              1,
+			Title:               "Remédio",
+			StartsAt:            now.Add(time.Hour),
+			ReminderOffsets:     domain.ReminderSchedule{60},
+			RequireConfirmation: true,
+			Status:              domain.EventStatusScheduled,
+		},
+		User: domain.User{ID: 1, WANumber: "+5511900000001"},
+	}
+
+	outbox := newRecordingOutbox()
+	repos := &memoryRepositories{events: newLeasingEventRepository(event), outbox: outbox}
+
+	templates := domain.RegisteredTemplates{domain.TemplateConfirmationRequest: "alarm_confirmation"}
+	worker := NewReminderWorker(repos, &channelFeed{}, defaultMessages(t), fixedTimeProvider{now: now}, zap.NewNop(), time.Second, "worker-a", time.Minute, onTimeCatchUp, templates)
+	require.NoError(t, worker.processReminders(context.Background()))
+
+	require.Len(t, outbox.interactive[event.User.WANumber], 1)
+	interactive := outbox.interactive[event.User.WANumber][0]
+	require.NotNil(t, interactive)
+	assert.Equal(t, []domain.ReplyOption{
+		{ID: "confirm:7", Title: "✅ Confirmar"},
+		{ID: "cancel:7", Title: "❌ Cancelar"},
+		{ID: "snooze:7", Title: "⏰ Adiar"},
+	}, interactive.Buttons)
+
+	// Outside the session window, the registered template goes instead.
+	assert.Equal(t, &domain.TemplateMessage{
+		Name:           "alarm_confirmation",
+		Language:       "pt_BR",
+		Params:         []string{"Remédio", "hoje às 14h"},
+		ButtonPayloads: []string{"confirm:7", "cancel:7", "snooze:7"},
+	}, outbox.templates[event.User.WANumber][0])
+}
+
+func TestReminderWorker_DoesNotEscalateConfirmedEvents(t *testing.T) {
+	now := time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC)
+	policy := domain.EscalationPolicy{{AfterReminders: 1}}
+
+	event := domain.EventWithUser{
+		Event: domain.Event{
+			ID:                  1,
+			UserID:              1,
+			Title:               "Remédio",
+			StartsAt:            now.Add(time.Hour),
+			ReminderOffsets:     domain.ReminderSchedule{120, 60},
+			RequireConfirmation: true,
+			Status:              domain.EventStatusConfirmed,
+			NotificationsSent:   1,
+			EscalationPolicy:    &policy,
+		},
+		User: domain.User{ID: 1, WANumber: "+5511900000001"},
+	}
+
+	escalations := &recordingEscalations{}
+	repos := &memoryRepositories{
+		events:      newLeasingEventRepository(event),
+		outbox:      newRecordingOutbox(),
+		contacts:    &staticContacts{contacts: []domain.UserAllowedContact{{UserID: 1, ContactNumber: "+5511900000002"}}},
+		escalations: escalations,
+	}
+
+	worker := NewReminderWorker(repos, &channelFeed{}, defaultMessages(t), fixedTimeProvider{now: now}, zap.NewNop(), time.Second, "worker-a", time.Minute, onTimeCatchUp, nil)
+	require.NoError(t, worker.processReminders(context.Background()))
+	assert.Empty(t, escalations.created)
+}
+
+func TestReminderWorker_DeferredReminderCountsTowardsLimit(t *testing.T) {
+	now := time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC)
+
+	event := domain.EventWithUser{
+		Event: domain.Event{
+			ID:                     1,
+			UserID:                 1,
+			Title:                  "Remédio",
+			StartsAt:               now.Add(3 * time.Hour),
+			RemindBeforeMinutes:    180,
+			RemindFrequencyMinutes: 15,
+			MaxNotifications:       2,
+			RequireConfirmation:    true,
+			Status:                 domain.EventStatusScheduled,
+		},
+		User: domain.User{
+			ID:                      1,
+			WANumber:                "+5511900000001",
+			QuietHours:              domain.QuietHours{{Start: "12:00", End: "13:30"}},
+			DefaultEscalationPolicy: domain.EscalationPolicy{{AfterReminders: 1}},
+		},
+	}
+
+	outbox := newRecordingOutbox()
+	escalations := &recordingEscalations{}
+	repos := &memoryRepositories{
+		events:      newLeasingEventRepository(event),
+		outbox:      outbox,
+		contacts:    &staticContacts{contacts: []domain.UserAllowedContact{{UserID: 1, ContactNumber: "+5511900000002"}}},
+		escalations: escalations,
+	}
+
+	worker := NewReminderWorker(repos, &channelFeed{}, defaultMessages(t), fixedTimeProvider{now: now}, zap.NewNop(), time.Second, "worker-a", time.Minute, onTimeCatchUp, nil)
+	process := func(at time.Time) *domain.Event {
+		worker.timeProvider = fixedTimeProvider{now: at}
+		require.NoError(t, worker.processReminders(context.Background()))
+		stored, err := repos.events.GetByID(context.Background(), event.ID)
+		require.NoError(t, err)
+		return stored
+	}
+
+	// Due during quiet hours, the reminder waits for them to end without
+	// looking snoozed.
+	stored := process(now)
+	assert.Zero(t, outbox.queued[event.User.WANumber])
+	require.NotNil(t, stored.DeferredUntil)
+	assert.True(t, stored.DeferredUntil.Equal(now.Add(30*time.Minute)))
+	assert.Nil(t, stored.SnoozedUntil)
+
+	stored = process(now.Add(30 * time.Minute))
+	assert.Equal(t, 1, outbox.queued[event.User.WANumber])
+	assert.Equal(t, 1, stored.NotificationsSent, "the deferred reminder counts")
+	assert.Nil(t, stored.DeferredUntil)
+
+	// The next reminder finds the deferred one unanswered and escalates.
+	stored = process(now.Add(45 * time.Minute))
+	assert.Equal(t, 2, outbox.queued[event.User.WANumber])
+	assert.Equal(t, 2, stored.NotificationsSent)
+	require.Len(t, escalations.created, 1)
+	assert.Equal(t, 1, escalations.created[0].UnansweredReminders)
+
+	// MaxNotifications is reached.
+	process(now.Add(time.Hour))
+	assert.Equal(t, 2, outbox.queued[event.User.WANumber])
+}